@@ -15,9 +15,186 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists every API key, revoked ones included, newest first. Secrets are never returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "List API Keys",
+                "operationId": "list_api_keys",
+                "responses": {
+                    "200": {
+                        "description": "API keys",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.APIKeyDTO"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issues an API key for another service. The key is only returned by this call; store it safely. Scopes: catalog:read, reports:read, stock:read, stock:reserve, stock:write.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Create API Key",
+                "operationId": "create_api_key",
+                "parameters": [
+                    {
+                        "description": "API key to be created",
+                        "name": "apiKey",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateAPIKeyDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created key and its secret",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.IssuedAPIKeyDTO"
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes an API key. Requests using it are rejected from then on.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Revoke API Key",
+                "operationId": "revoke_api_key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/api-keys/{id}/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issues a new secret for an API key, keeping its ID and scopes. The previous secret stops working immediately.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Rotate API Key",
+                "operationId": "rotate_api_key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rotated key and its new secret",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.IssuedAPIKeyDTO"
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
         "/categories": {
             "get": {
-                "description": "Retrieves a list of all categories",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a page of categories, excluding soft-deleted ones unless includeDeleted is set. Use either offset or the nextCursor of the previous page to move forward.",
                 "consumes": [
                     "application/json"
                 ],
@@ -29,19 +206,106 @@ const docTemplate = `{
                 ],
                 "summary": "List Categories",
                 "operationId": "list_categories",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of categories to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned as nextCursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "createdAt"
+                        ],
+                        "type": "string",
+                        "default": "name",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only names containing this text (case-insensitive)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only names starting with this text (case-insensitive)",
+                        "name": "prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted categories",
+                        "name": "includeDeleted",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "List of categories",
+                        "description": "Page of categories",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dtos.CategoryDTO"
-                            }
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.PageDTO"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.CategoryDTO"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
                         }
                     }
                 }
             },
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "Creates a new category",
                 "consumes": [
                     "application/json"
@@ -71,12 +335,67 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dtos.CategoryDTO"
                         }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/tree": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves all categories nested under their parents",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Category Tree",
+                "operationId": "get_category_tree",
+                "responses": {
+                    "200": {
+                        "description": "Root categories with their subcategories",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.CategoryTreeDTO"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
                     }
                 }
             }
         },
         "/categories/{id}": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "Retrieves a category by its ID",
                 "consumes": [
                     "application/json"
@@ -104,11 +423,25 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dtos.CategoryDTO"
                         }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
                     }
                 }
             },
             "delete": {
-                "description": "Deletes an existing category",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes an existing category. With the default restrict policy the request fails with 409 while products or subcategories reference the category; reassign moves them to reassignTo first; soft hides the category but keeps product references.",
                 "consumes": [
                     "application/json"
                 ],
@@ -127,19 +460,66 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "restrict",
+                            "reassign",
+                            "soft"
+                        ],
+                        "type": "string",
+                        "default": "restrict",
+                        "description": "Deletion policy",
+                        "name": "policy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Target category ID for the reassign policy",
+                        "name": "reassignTo",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "204": {
-                        "description": "No Content",
+                        "description": "No Content"
+                    },
+                    "409": {
+                        "description": "Category still referenced",
                         "schema": {
-                            "$ref": "#/definitions/dtos.CategoryDTO"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.ProblemDTO"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/dtos.CategoryInUseDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
                         }
                     }
                 }
             },
             "patch": {
-                "description": "Updates an existing category",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Renames an existing category and optionally moves it under another parent (parentId 0 moves it to the root)",
                 "consumes": [
                     "application/json"
                 ],
@@ -171,51 +551,1936 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "No Content",
+                        "description": "No Content"
+                    },
+                    "default": {
+                        "description": "Problem details",
                         "schema": {
-                            "$ref": "#/definitions/dtos.CategoryDTO"
+                            "$ref": "#/definitions/dtos.ProblemDTO"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dtos.CategoryDTO": {
-            "type": "object",
-            "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "dtos.CreateCategoryDTO": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "name": {
-                    "type": "string"
-                }
-            }
         },
-        "dtos.UpdateCategoryDTO": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "name": {
-                    "type": "string"
-                }
-            }
+        "/categories/{id}/descendants": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves all subcategories of a category at any depth",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "List Category Descendants",
+                "operationId": "list_category_descendants",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of subcategories",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.CategoryDTO"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restores a soft-deleted category. Its parent category must not be deleted, and no active category may have taken its name meanwhile.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Restore Category",
+                "operationId": "restore_category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored category",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CategoryDTO"
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/movements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Streams the movements recorded within a date range, oldest first, as a CSV, NDJSON or Parquet file, optionally for a single product. A date as to includes the whole day. With compression=gzip, CSV and NDJSON files are gzipped and Parquet files compress their pages.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.apache.parquet",
+                    "application/gzip"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Export Stock Movements",
+                "operationId": "export_movements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the range (YYYY-MM-DD or RFC 3339)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range, included (YYYY-MM-DD or RFC 3339)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only export the movements of this product",
+                        "name": "productId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson",
+                            "parquet"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "gzip"
+                        ],
+                        "type": "string",
+                        "description": "Compression",
+                        "name": "compression",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported movements",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "Content-Disposition": {
+                                "type": "string",
+                                "description": "Attachment file name"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/products": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Streams every product, by ID, as a CSV, NDJSON or Parquet file. Soft-deleted products are excluded unless includeDeleted is set. With compression=gzip, CSV and NDJSON files are gzipped and Parquet files compress their pages. An export failing midway aborts the connection, so a truncated file is never mistaken for a complete one.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.apache.parquet",
+                    "application/gzip"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Export Products",
+                "operationId": "export_products",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson",
+                            "parquet"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "gzip"
+                        ],
+                        "type": "string",
+                        "description": "Compression",
+                        "name": "compression",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted products",
+                        "name": "includeDeleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported products",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "Content-Disposition": {
+                                "type": "string",
+                                "description": "Attachment file name"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/exports/stock-levels": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Streams the stock level of every product, by product ID, as a CSV, NDJSON or Parquet file. With compression=gzip, CSV and NDJSON files are gzipped and Parquet files compress their pages.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.apache.parquet",
+                    "application/gzip"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Export Stock Levels",
+                "operationId": "export_stock_levels",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson",
+                            "parquet"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "gzip"
+                        ],
+                        "type": "string",
+                        "description": "Compression",
+                        "name": "compression",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported stock levels",
+                        "schema": {
+                            "type": "file"
+                        },
+                        "headers": {
+                            "Content-Disposition": {
+                                "type": "string",
+                                "description": "Attachment file name"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "Returns 200 while the process can serve HTTP. It does not check dependencies, so a failing database never gets the container restarted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness Probe",
+                "operationId": "health_live",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LivenessDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Checks Postgres, and Kafka and Mongo when enabled, each with a timeout. Returns 503 when a required dependency is down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness Probe",
+                "operationId": "health_ready",
+                "responses": {
+                    "200": {
+                        "description": "All required dependencies are up",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReadinessDTO"
+                        }
+                    },
+                    "503": {
+                        "description": "A required dependency is down",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReadinessDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Queues the import of a CSV file with a header row, or of NDJSON objects, with the columns name, sku, category, price and the optional openingQuantity and unitCost. Every row is validated before anything is written: a file with an invalid row imports nothing and its job reports the row errors. Missing categories are created and opening quantities are received as stock. Poll the job returned in the Location header for the outcome.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Import Products",
+                "operationId": "create_import",
+                "parameters": [
+                    {
+                        "description": "CSV or NDJSON file",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "Format of the file, defaults to the one of the Content-Type",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the file",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Queued import",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ImportJobDTO"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the import job"
+                            }
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the progress of an import, or its outcome once finished: the created products and categories, or the row errors.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Get Import",
+                "operationId": "get_import",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Import ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ImportJobDTO"
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a page of products, optionally restricted to a category and all of its subcategories. Soft-deleted products are excluded unless includeDeleted is set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "List Products",
+                "operationId": "list_products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID (includes subcategories)",
+                        "name": "categoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of products to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned as nextCursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "createdAt"
+                        ],
+                        "type": "string",
+                        "default": "name",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only names containing this text (case-insensitive)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only names starting with this text (case-insensitive)",
+                        "name": "prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted products",
+                        "name": "includeDeleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of products",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.PageDTO"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.ProductDTO"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates a product in an existing category. Fails with 409 when an active product has the SKU.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Create Product",
+                "operationId": "create_product",
+                "parameters": [
+                    {
+                        "description": "Product to be created",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateProductDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductDTO"
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Full-text search over product names and descriptions, best matches first. Every word must match and partial words match as prefixes. The response carries category and price range facet counts over all matches; each facet ignores its own filter.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Search Products",
+                "operationId": "search_products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category ID (includes subcategories)",
+                        "name": "categoryId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price",
+                        "name": "minPrice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price",
+                        "name": "maxPrice",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only products with stock on hand",
+                        "name": "inStock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of results to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search results",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductSearchResultDTO"
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Soft-deletes a product. Its stock movements are kept and it can be restored later.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Delete Product",
+                "operationId": "delete_product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replaces the name, description, price and category of a product, and its SKU when one is given. Fails with 409 when another active product has the SKU.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Update Product",
+                "operationId": "update_product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product to be updated",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateProductDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductDTO"
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restores a soft-deleted product. No active product may have taken its SKU meanwhile.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Restore Product",
+                "operationId": "restore_product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored product",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductDTO"
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/valuation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Values the stock on hand by product and category as of a given date using FIFO or weighted-average cost",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Inventory Valuation Report",
+                "operationId": "get_valuation_report",
+                "parameters": [
+                    {
+                        "enum": [
+                            "fifo",
+                            "weighted_average"
+                        ],
+                        "type": "string",
+                        "default": "fifo",
+                        "description": "Costing method",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Valuation date (YYYY-MM-DD or RFC 3339), defaults to now",
+                        "name": "asOf",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValuationReportDTO"
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock/movements": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Records a receipt, issue or adjustment and updates the stock level. Receipts must carry a unit cost.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Record Stock Movement",
+                "operationId": "record_stock_movement",
+                "parameters": [
+                    {
+                        "description": "Movement to be recorded",
+                        "name": "movement",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateStockMovementDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.StockMovementDTO"
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock/movements/{productId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a page of the movement ledger of a product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "List Stock Movements",
+                "operationId": "list_stock_movements",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of movements to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned as nextCursor by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "createdAt"
+                        ],
+                        "type": "string",
+                        "default": "createdAt",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of movements",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dtos.PageDTO"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dtos.StockMovementDTO"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/stock/reservations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Reserves units of a product, e.g. for an order being placed. Fails with 409 when fewer units are available than requested.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stock"
+                ],
+                "summary": "Create Stock Reservation",
+                "operationId": "create_stock_reservation",
+                "parameters": [
+                    {
+                        "description": "Reservation to be created",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateReservationDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.StockReservationDTO"
+                        }
+                    },
+                    "default": {
+                        "description": "Problem details",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProblemDTO"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dtos.APIKeyDTO": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revokedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "rotatedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dtos.CategoryDTO": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "deletedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parentId": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
+        "dtos.CategoryFacetDTO": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "integer"
+                },
+                "categoryName": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CategoryInUseDTO": {
+            "type": "object",
+            "properties": {
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CategoryReferenceDTO"
+                    }
+                },
+                "subcategories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CategoryReferenceDTO"
+                    }
+                }
+            }
+        },
+        "dtos.CategoryReferenceDTO": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CategoryTreeDTO": {
+            "type": "object",
+            "properties": {
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CategoryTreeDTO"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parentId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CategoryValuationDTO": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "integer"
+                },
+                "categoryName": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProductValuationDTO"
+                    }
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "totalValue": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.CreateAPIKeyDTO": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dtos.CreateCategoryDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "parentId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CreateProductDTO": {
+            "type": "object",
+            "required": [
+                "categoryId",
+                "name",
+                "sku"
+            ],
+            "properties": {
+                "categoryId": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "price": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CreateReservationDTO": {
+            "type": "object",
+            "required": [
+                "productId",
+                "quantity"
+            ],
+            "properties": {
+                "expiresAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "productId": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "referenceId": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "dtos.CreateStockMovementDTO": {
+            "type": "object",
+            "required": [
+                "movementType",
+                "productId",
+                "quantity"
+            ],
+            "properties": {
+                "movementType": {
+                    "type": "string",
+                    "enum": [
+                        "receipt",
+                        "issue",
+                        "adjustment"
+                    ]
+                },
+                "productId": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "unitCost": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "dtos.DependencyStatusDTO": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "latencyMs": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "postgres"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "up",
+                        "down"
+                    ]
+                }
+            }
+        },
+        "dtos.ImportJobDTO": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "createdCategories": {
+                    "type": "integer"
+                },
+                "createdProducts": {
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "errorCount": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ImportRowErrorDTO"
+                    }
+                },
+                "failure": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "csv",
+                        "ndjson"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "format": "uuid"
+                },
+                "processedRows": {
+                    "type": "integer"
+                },
+                "startedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "running",
+                        "succeeded",
+                        "failed"
+                    ]
+                },
+                "totalRows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ImportRowErrorDTO": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.IssuedAPIKeyDTO": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revokedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "rotatedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dtos.LivenessDTO": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "dtos.PageDTO": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "pagination": {
+                    "$ref": "#/definitions/dtos.PaginationDTO"
+                }
+            }
+        },
+        "dtos.PaginationDTO": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "nextCursor": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.PriceRangeFacetDTO": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.ProblemDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "details": {},
+                "instance": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ProductDTO": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "deletedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
+        "dtos.ProductSearchFacetsDTO": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CategoryFacetDTO"
+                    }
+                },
+                "priceRanges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.PriceRangeFacetDTO"
+                    }
+                }
+            }
+        },
+        "dtos.ProductSearchHitDTO": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "deletedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                }
+            }
+        },
+        "dtos.ProductSearchResultDTO": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProductSearchHitDTO"
+                    }
+                },
+                "facets": {
+                    "$ref": "#/definitions/dtos.ProductSearchFacetsDTO"
+                },
+                "pagination": {
+                    "$ref": "#/definitions/dtos.PaginationDTO"
+                }
+            }
+        },
+        "dtos.ProductValuationDTO": {
+            "type": "object",
+            "properties": {
+                "productId": {
+                    "type": "integer"
+                },
+                "productName": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "totalValue": {
+                    "type": "number"
+                },
+                "unitCost": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.ReadinessDTO": {
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.DependencyStatusDTO"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ready",
+                        "unavailable"
+                    ]
+                }
+            }
+        },
+        "dtos.StockMovementDTO": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "movementType": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "unitCost": {
+                    "type": "number"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.StockReservationDTO": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "productId": {
+                    "type": "integer"
+                },
+                "referenceId": {
+                    "type": "string"
+                },
+                "reservedAt": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "reservedQty": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.UpdateCategoryDTO": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "parentId": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "dtos.UpdateProductDTO": {
+            "type": "object",
+            "required": [
+                "categoryId",
+                "name"
+            ],
+            "properties": {
+                "categoryId": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "price": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ValuationReportDTO": {
+            "type": "object",
+            "properties": {
+                "asOf": {
+                    "type": "string",
+                    "format": "date-time"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CategoryValuationDTO"
+                    }
+                },
+                "method": {
+                    "type": "string"
+                },
+                "totalValue": {
+                    "type": "number"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "API key of another service.",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "JWT access token, sent as \"Bearer \u003ctoken\u003e\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -1,14 +1,31 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 
 	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/handler"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/lifecycle"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/middleware"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/validation"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/config"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/health"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/metrics"
 	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/postgresrepository"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/ratelimit"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/tracing"
 	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/usecase"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"go.uber.org/zap"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -23,61 +40,437 @@ import (
 // @description This is a API for a inventory management using Gin framework.
 // @host localhost:8090
 // @BasePath /
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT access token, sent as "Bearer <token>".
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description API key of another service.
 func main() {
-	logger := setupLogger()
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "path to an optional YAML config file")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-config file] [%s]\n", os.Args[0], migrateUsage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Without a command the service is started.
+	var migrate *migrateCommand
+	if flag.NArg() > 0 {
+		cmd, err := parseMigrateCommand(flag.Args())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			flag.Usage()
+			os.Exit(2)
+		}
+		migrate = &cmd
+	}
+
+	cfg, err := loadConfig(*configPath, migrate)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	logger := setupLogger(cfg.Log)
 	defer logger.Sync()
-	db := setupDatabase()
+	// Code without a request logger in its context logs through the global
+	// logger.
+	zap.ReplaceGlobals(logger)
+
+	logger.Info("Configuration loaded", zap.Any("config", cfg.Redacted()))
+
+	if migrate != nil {
+		if err := runMigrate(cfg, logger, *migrate, os.Stdout); err != nil {
+			logger.Fatal("Migration failed", zap.Error(err))
+		}
+		return
+	}
+
+	if err := run(cfg, logger); err != nil {
+		logger.Fatal("Service stopped with error", zap.Error(err))
+	}
+	logger.Info("Service stopped")
+}
+
+// run wires the service and blocks until it has shut down.
+func run(cfg config.Config, logger *zap.Logger) error {
+	app := lifecycle.New(logger, cfg.Server.ShutdownTimeout)
+
+	if cfg.Tracing.Enabled {
+		// Registered first so it runs last and flushes the spans of the
+		// shutdown itself.
+		shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+			ServiceName: cfg.Tracing.ServiceName,
+			Exporter:    cfg.Tracing.Exporter,
+			Endpoint:    cfg.Tracing.Endpoint,
+			Insecure:    cfg.Tracing.Insecure,
+			SampleRatio: cfg.Tracing.SampleRatio,
+			Stdout:      os.Stdout,
+		})
+		if err != nil {
+			return fmt.Errorf("setting up tracing: %w", err)
+		}
+		app.OnShutdown("tracing", shutdownTracing)
+	}
+
+	db, err := setupDatabase(cfg.Database)
+	if err != nil {
+		return err
+	}
+	// Confines every repository to the tenant of the request.
+	if err := db.Use(postgresrepository.NewTenantPlugin()); err != nil {
+		return err
+	}
+	if cfg.Tracing.Enabled {
+		if err := db.Use(tracing.NewGormPlugin()); err != nil {
+			return err
+		}
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	app.OnShutdown("database", func(ctx context.Context) error {
+		return sqlDB.Close()
+	})
+	if cfg.Database.AutoMigrate {
+		if err := migrateOnStart(sqlDB, cfg.Database, logger); err != nil {
+			return fmt.Errorf("migrating the database: %w", err)
+		}
+	}
+
+	checker, err := setupHealthChecks(cfg, sqlDB)
+	if err != nil {
+		return err
+	}
+
+	var m *metrics.Metrics
+	if cfg.Metrics.Enabled {
+		m = metrics.New()
+		if err := db.Use(metrics.NewGormPlugin(m)); err != nil {
+			return err
+		}
+		m.RegisterDBStats(sqlDB, cfg.Database.Name)
+	}
 
 	categoryRepo := postgresrepository.NewCategoryRepositoryPostgres(db)
-	categoryUC := usecase.NewCategoryUsecase(categoryRepo)
+	productRepo := postgresrepository.NewProductRepositoryPostgres(db)
+	var stockLevelRepo domain.StockLevelRepository = postgresrepository.NewStockLevelRepositoryPostgres(db)
+	// The use cases are given nil interfaces, not nil pointers, when
+	// metrics are disabled.
+	var stockLevelObserver usecase.StockLevelObserver
+	var reservationCounter usecase.ReservationCounter
+	if m != nil {
+		gauge := metrics.NewStockLevelGauge(m, cfg.Metrics.WatchedProducts)
+		stockLevelRepo = metrics.NewStockLevelRepository(stockLevelRepo, gauge)
+		stockLevelObserver = gauge
+		reservationCounter = m
+	}
+	reservationRepo := postgresrepository.NewStockReservationRepositoryPostgres(db)
+	stockMovementRepo := postgresrepository.NewStockMovementRepositoryPostgres(db)
+	costLayerRepo := postgresrepository.NewCostLayerRepositoryPostgres(db)
+	transactor := postgresrepository.NewTransactorPostgres(db)
 
-	r := gin.Default()
-	categoryHandler := handler.NewCategoryHandler(categoryUC, logger)
+	categoryUC := usecase.NewCategoryUsecase(categoryRepo, productRepo, transactor)
+	productUC := usecase.NewProductUsecase(productRepo, categoryRepo)
+	stockMovementUC := usecase.NewStockMovementUsecase(stockMovementRepo, stockLevelRepo, costLayerRepo, productRepo, transactor, stockLevelObserver)
+	reservationUC := usecase.NewReservationUsecase(reservationRepo, stockLevelRepo, productRepo, transactor, reservationCounter)
+	valuationUC := usecase.NewValuationUsecase(stockMovementRepo, costLayerRepo, productRepo, categoryRepo)
+	apiKeyUC := usecase.NewAPIKeyUsecase(postgresrepository.NewAPIKeyRepositoryPostgres(db))
+	exportUC := usecase.NewExportUsecase(productRepo, stockLevelRepo, stockMovementRepo)
+	importJobRepo := postgresrepository.NewImportJobRepositoryPostgres(db)
+	importUC := usecase.NewProductImportUsecase(importJobRepo, productRepo, categoryRepo, stockMovementUC, transactor, cfg.Imports.MaxRows)
 
-	setupRoutes(r, categoryHandler)
+	importWorker := usecase.NewImportWorker(importJobRepo, importUC, logger.Named("imports"), cfg.Imports.PollInterval, cfg.Imports.JobTimeout)
+	app.AddWorker("imports", importWorker.Run)
+	expiryWorker := usecase.NewReservationExpiryWorker(reservationRepo, reservationCounter, logger.Named("reservations"), cfg.Reservations.ExpiryInterval)
+	app.AddWorker("reservations", expiryWorker.Run)
 
-	if err := r.Run(":8090"); err != nil {
-		log.Panic(err)
+	binding.Validator = validation.NewStructValidator()
+	if cfg.Log.Level != "debug" {
+		gin.SetMode(gin.ReleaseMode)
 	}
+	gin.DebugPrintRouteFunc = func(method, path, handlerName string, handlers int) {
+		logger.Debug("Route registered", zap.String("method", method), zap.String("path", path), zap.String("handler", handlerName))
+	}
+
+	r := gin.New()
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		return fmt.Errorf("setting trusted proxies: %w", err)
+	}
+	r.Use(middleware.Recovery(), middleware.RequestID())
+	if cfg.Tracing.Enabled {
+		r.Use(otelgin.Middleware(cfg.Tracing.ServiceName))
+	}
+	r.Use(middleware.Logger(logger))
+	if m != nil {
+		r.Use(middleware.Metrics(m))
+		r.GET(cfg.Metrics.Path, gin.WrapH(m.Handler()))
+	}
+	// Exports stream for longer than a query may take, under their own
+	// timeout.
+	r.Use(middleware.ErrorHandler(), middleware.DBTimeout(cfg.Database.QueryTimeout, "/exports"))
+	r.NoRoute(middleware.NotFound)
+	categoryHandler := handler.NewCategoryHandler(categoryUC)
+	productHandler := handler.NewProductHandler(productUC)
+	stockHandler := handler.NewStockHandler(stockMovementUC)
+	reservationHandler := handler.NewReservationHandler(reservationUC)
+	reportHandler := handler.NewReportHandler(valuationUC)
+	importHandler := handler.NewImportHandler(importUC, int64(cfg.Imports.MaxBytes))
+	exportHandler := handler.NewExportHandler(exportUC, cfg.Exports.Timeout)
+	healthHandler := handler.NewHealthHandler(checker)
+
+	api := r.Group("/")
+	var rateLimitStore ratelimit.Store
+	if cfg.RateLimit.Enabled {
+		rateLimitStore, err = setupRateLimitStore(cfg, app)
+		if err != nil {
+			return err
+		}
+		// Limits clients by address before their credentials are checked,
+		// then by key or user below.
+		ip := cfg.RateLimit.IP
+		api.Use(middleware.RateLimitByIP(rateLimitStore, ratelimit.Limit{Requests: ip.Requests, Window: ip.Window, Burst: ip.Burst}))
+	}
+	if cfg.Auth.Enabled {
+		verifier, policy, err := setupAuth(cfg.Auth)
+		if err != nil {
+			return fmt.Errorf("setting up authentication: %w", err)
+		}
+		userUC := usecase.NewUserUsecase(postgresrepository.NewUserRepositoryPostgres(db))
+		api.Use(middleware.Authenticate(verifier, userUC, apiKeyUC, policy))
+	} else {
+		logger.Warn("Authentication is disabled; API routes are public")
+	}
+	api.Use(middleware.Tenant(cfg.Tenancy.DefaultTenant))
+	if cfg.RateLimit.Enabled {
+		api.Use(middleware.RateLimit(rateLimitStore, rateLimitRules(cfg.RateLimit)))
+	}
+
+	setupRoutes(r, api, cfg.Features, healthHandler, categoryHandler, productHandler, stockHandler, reservationHandler, reportHandler, importHandler, exportHandler)
+	if cfg.Auth.Enabled {
+		// Keys can only be managed by authenticated admins.
+		setupAPIKeyRoutes(api, handler.NewAPIKeyHandler(apiKeyUC))
+	}
+
+	server := &http.Server{
+		Addr:              cfg.Server.Addr,
+		Handler:           r,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+
+	return app.Run(context.Background(), server)
 }
 
-func setupLogger() *zap.Logger {
-	logger, err := zap.NewProduction()
+func setupLogger(cfg config.LogConfig) *zap.Logger {
+	zapConfig := zap.NewProductionConfig()
+	if cfg.Format == "console" {
+		zapConfig = zap.NewDevelopmentConfig()
+	}
+	level, err := zap.ParseAtomicLevel(cfg.Level)
+	if err != nil {
+		log.Panic("Invalid log level: ", err)
+	}
+	zapConfig.Level = level
+
+	logger, err := zapConfig.Build()
 	if err != nil {
 		log.Panic("Failed to initialize logger: ", err)
 	}
 	return logger
 }
 
-func setupRoutes(r *gin.Engine, categoryHandler *handler.CategoryHandler) {
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-	r.GET("/health", handler.HealthCheckHandler)
+func setupRoutes(
+	r *gin.Engine,
+	api *gin.RouterGroup,
+	features config.FeatureConfig,
+	healthHandler *handler.HealthHandler,
+	categoryHandler *handler.CategoryHandler,
+	productHandler *handler.ProductHandler,
+	stockHandler *handler.StockHandler,
+	reservationHandler *handler.ReservationHandler,
+	reportHandler *handler.ReportHandler,
+	importHandler *handler.ImportHandler,
+	exportHandler *handler.ExportHandler,
+) {
+	if features.Swagger {
+		r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	}
+	r.GET("/health", healthHandler.Live)
+	r.GET("/health/live", healthHandler.Live)
+	r.GET("/health/ready", healthHandler.Ready)
 
-	setupCategoryRoutes(r, categoryHandler)
+	setupCategoryRoutes(api, categoryHandler)
+	setupProductRoutes(api, features, productHandler)
+	setupStockRoutes(api, stockHandler, reservationHandler)
+	setupReportRoutes(api, reportHandler)
+	setupImportRoutes(api, importHandler)
+	setupExportRoutes(api, exportHandler)
 }
 
-func setupCategoryRoutes(r *gin.Engine, categoryHandler *handler.CategoryHandler) {
-	r.POST("/categories", categoryHandler.CreateCategory)
-	r.GET("/categories", categoryHandler.ListCategories)
-	r.GET("/categories/:id", categoryHandler.GetCategoryByID)
-	r.PATCH("/categories/:id", categoryHandler.UpdateCategory)
-	r.DELETE("/categories/:id", categoryHandler.DeleteCategory)
+// The permission of each route is checked again by the use cases for writes;
+// the large adjustment rule is only known there.
+func setupCategoryRoutes(r *gin.RouterGroup, categoryHandler *handler.CategoryHandler) {
+	read := middleware.RequirePermission(auth.PermCategoriesRead)
+	write := middleware.RequirePermission(auth.PermCategoriesWrite)
+	r.POST("/categories", write, categoryHandler.CreateCategory)
+	r.GET("/categories", read, categoryHandler.ListCategories)
+	r.GET("/categories/tree", read, categoryHandler.GetCategoryTree)
+	r.GET("/categories/:id", read, categoryHandler.GetCategoryByID)
+	r.GET("/categories/:id/descendants", read, categoryHandler.ListDescendants)
+	r.PATCH("/categories/:id", write, categoryHandler.UpdateCategory)
+	r.DELETE("/categories/:id", middleware.RequirePermission(auth.PermCategoriesDelete), categoryHandler.DeleteCategory)
+	r.POST("/categories/:id/restore", write, categoryHandler.RestoreCategory)
 }
 
-func setupDatabase() *gorm.DB {
-	dbHost := os.Getenv("DB_HOST")
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbName := os.Getenv("DB_NAME")
-	dbPort := os.Getenv("DB_PORT")
+func setupProductRoutes(r *gin.RouterGroup, features config.FeatureConfig, productHandler *handler.ProductHandler) {
+	read := middleware.RequirePermission(auth.PermProductsRead)
+	write := middleware.RequirePermission(auth.PermProductsWrite)
+	r.POST("/products", write, productHandler.CreateProduct)
+	r.GET("/products", read, productHandler.ListProducts)
+	if features.ProductSearch {
+		r.GET("/products/search", read, productHandler.SearchProducts)
+	}
+	r.PATCH("/products/:id", write, productHandler.UpdateProduct)
+	r.DELETE("/products/:id", write, productHandler.DeleteProduct)
+	r.POST("/products/:id/restore", write, productHandler.RestoreProduct)
+}
+
+func setupStockRoutes(r *gin.RouterGroup, stockHandler *handler.StockHandler, reservationHandler *handler.ReservationHandler) {
+	r.POST("/stock/movements", middleware.RequirePermission(auth.PermStockWrite), stockHandler.RecordMovement)
+	r.GET("/stock/movements/:productId", middleware.RequirePermission(auth.PermStockRead), stockHandler.ListMovementsByProduct)
+	r.POST("/stock/reservations", middleware.RequirePermission(auth.PermStockReserve), reservationHandler.CreateReservation)
+}
 
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
-		dbHost, dbUser, dbPassword, dbName, dbPort)
+func setupReportRoutes(r *gin.RouterGroup, reportHandler *handler.ReportHandler) {
+	r.GET("/reports/valuation", middleware.RequirePermission(auth.PermReportsRead), reportHandler.GetValuationReport)
+}
+
+// Imports also need categories:write and stock:write, which the use case
+// checks.
+func setupImportRoutes(r *gin.RouterGroup, importHandler *handler.ImportHandler) {
+	r.POST("/imports", middleware.RequirePermission(auth.PermProductsWrite), importHandler.CreateImport)
+	r.GET("/imports/:id", middleware.RequirePermission(auth.PermProductsRead), importHandler.GetImport)
+}
+
+// Exports also need the read permission of their data, which the use case
+// checks.
+func setupExportRoutes(r *gin.RouterGroup, exportHandler *handler.ExportHandler) {
+	read := middleware.RequirePermission(auth.PermReportsRead)
+	r.GET("/exports/products", read, exportHandler.ExportProducts)
+	r.GET("/exports/stock-levels", read, exportHandler.ExportStockLevels)
+	r.GET("/exports/movements", read, exportHandler.ExportMovements)
+}
+
+func setupAPIKeyRoutes(r *gin.RouterGroup, apiKeyHandler *handler.APIKeyHandler) {
+	manage := middleware.RequirePermission(auth.PermAPIKeysManage)
+	r.POST("/api-keys", manage, apiKeyHandler.CreateAPIKey)
+	r.GET("/api-keys", manage, apiKeyHandler.ListAPIKeys)
+	r.DELETE("/api-keys/:id", manage, apiKeyHandler.RevokeAPIKey)
+	r.POST("/api-keys/:id/rotate", manage, apiKeyHandler.RotateAPIKey)
+}
+
+// setupAuth builds the token verifier from the HS256 secret and the JWKS
+// file, whichever are configured, and loads the permission policy.
+func setupAuth(cfg config.AuthConfig) (*auth.Verifier, *auth.Policy, error) {
+	opts := auth.VerifierOptions{
+		HMACSecret: []byte(cfg.HS256Secret),
+		Issuer:     cfg.Issuer,
+		Audience:   cfg.Audience,
+		Leeway:     cfg.Leeway,
+	}
+	if cfg.JWKSFile != "" {
+		keys, err := auth.LoadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts.RSAKeys = keys
+	}
+	verifier, err := auth.NewVerifier(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policy := auth.DefaultPolicy()
+	if cfg.PolicyFile != "" {
+		if policy, err = auth.LoadPolicy(cfg.PolicyFile); err != nil {
+			return nil, nil, err
+		}
+	}
+	return verifier, policy, nil
+}
+
+// setupRateLimitStore returns the bucket store of the rate limiter. The Redis
+// client is closed on shutdown.
+func setupRateLimitStore(cfg config.Config, app *lifecycle.Lifecycle) (ratelimit.Store, error) {
+	if cfg.RateLimit.Store != "redis" {
+		return ratelimit.NewMemoryStore(), nil
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	app.OnShutdown("redis", func(ctx context.Context) error {
+		return client.Close()
+	})
+	return ratelimit.NewRedisStore(client), nil
+}
+
+func rateLimitRules(cfg config.RateLimitConfig) ratelimit.Rules {
+	rules := ratelimit.Rules{
+		Default: ratelimit.Limit{Requests: cfg.Requests, Window: cfg.Window, Burst: cfg.Burst},
+		Routes:  make(map[string]ratelimit.Limit, len(cfg.Routes)),
+	}
+	for _, route := range cfg.Routes {
+		// Routes were checked when the configuration was loaded.
+		name, _ := ratelimit.ParseRoute(route.Route)
+		rules.Routes[name] = ratelimit.Limit{Requests: route.Requests, Window: route.Window, Burst: route.Burst}
+	}
+	return rules
+}
+
+// setupHealthChecks registers the readiness checks: Postgres always, Kafka
+// and Mongo when enabled, and Redis when it holds the rate limits.
+func setupHealthChecks(cfg config.Config, sqlDB *sql.DB) (*health.Checker, error) {
+	checks := []health.Check{health.Postgres(sqlDB)}
+	if cfg.Kafka.Enabled {
+		checks = append(checks, health.TCP("kafka", cfg.Kafka.Required, cfg.Kafka.Brokers))
+	}
+	if cfg.Mongo.Enabled {
+		addrs, err := health.MongoAddrs(cfg.Mongo.URI)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, health.TCP("mongo", cfg.Mongo.Required, addrs))
+	}
+	if cfg.RateLimit.Enabled && cfg.RateLimit.Store == "redis" {
+		// Rate limiting lets requests through while Redis is down.
+		checks = append(checks, health.TCP("redis", false, []string{cfg.Redis.Addr}))
+	}
+	return health.NewChecker(cfg.Health.CheckTimeout, checks...), nil
+}
+
+func setupDatabase(cfg config.DatabaseConfig) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("connecting to the database: %w", err)
+	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	sqlDB, err := db.DB()
 	if err != nil {
-		log.Panic("Failed to connect to the database: ", err)
+		return nil, err
 	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
-	return db
+	return db, nil
 }
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/config"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/migration"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/scripts/migrations"
+	"go.uber.org/zap"
+)
+
+const migrateUsage = "migrate up|down|status|to <version>"
+
+// migrateCommand is a parsed migrate subcommand.
+type migrateCommand struct {
+	action  string
+	version uint
+}
+
+func parseMigrateCommand(args []string) (migrateCommand, error) {
+	if len(args) == 2 && args[0] == "migrate" {
+		switch args[1] {
+		case "up", "down", "status":
+			return migrateCommand{action: args[1]}, nil
+		}
+	}
+	if len(args) == 3 && args[0] == "migrate" && args[1] == "to" {
+		version, err := strconv.ParseUint(args[2], 10, 32)
+		if err == nil {
+			return migrateCommand{action: "to", version: uint(version)}, nil
+		}
+	}
+	return migrateCommand{}, fmt.Errorf("unknown command %q, expected %s", strings.Join(args, " "), migrateUsage)
+}
+
+// loadConfig loads the configuration of the service, or only checks its
+// database and log settings for migrate, which neither serves requests nor
+// verifies tokens.
+func loadConfig(path string, migrate *migrateCommand) (config.Config, error) {
+	if migrate != nil {
+		return config.LoadDatabase(path)
+	}
+	return config.Load(path)
+}
+
+func newMigrator(sqlDB *sql.DB, logger *zap.Logger) (*migration.Migrator, error) {
+	loaded, err := migration.Load(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	return migration.New(sqlDB, loaded, logger.Named("migration")), nil
+}
+
+// runMigrate runs cmd against the configured database and prints the
+// resulting status to out.
+func runMigrate(cfg config.Config, logger *zap.Logger, cmd migrateCommand, out io.Writer) error {
+	db, err := setupDatabase(cfg.Database)
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+
+	migrator, err := newMigrator(sqlDB, logger)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Database.MigrationTimeout)
+	defer cancel()
+
+	switch cmd.action {
+	case "up":
+		err = migrator.Up(ctx)
+	case "down":
+		err = migrator.Down(ctx)
+	case "to":
+		err = migrator.To(ctx, cmd.version)
+	}
+	if err != nil {
+		return err
+	}
+	return printMigrationStatus(ctx, migrator, out)
+}
+
+// migrateOnStart applies the pending migrations before the service starts.
+func migrateOnStart(sqlDB *sql.DB, cfg config.DatabaseConfig, logger *zap.Logger) error {
+	migrator, err := newMigrator(sqlDB, logger)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.MigrationTimeout)
+	defer cancel()
+	return migrator.Up(ctx)
+}
+
+func printMigrationStatus(ctx context.Context, migrator *migration.Migrator, out io.Writer) error {
+	version, dirty, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE")
+	for _, m := range migrator.Migrations() {
+		state := "pending"
+		switch {
+		case m.Version == version && dirty:
+			state = "dirty"
+		case m.Version <= version:
+			state = "applied"
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\n", m.Version, m.Name, state)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if version > migrator.Latest() {
+		fmt.Fprintf(out, "The database is at version %d, newer than this binary.\n", version)
+	}
+	return nil
+}
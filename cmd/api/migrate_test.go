@@ -0,0 +1,33 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMigrateStatusOnlyNeedsTheDatabaseSettings(t *testing.T) {
+	// Nothing listens on port 1, so the command gets as far as connecting.
+	t.Setenv("DB_HOST", "127.0.0.1")
+	t.Setenv("DB_PORT", "1")
+	t.Setenv("DB_USER", "inventory")
+	t.Setenv("DB_NAME", "inventory")
+	t.Setenv("DB_SSLMODE", "disable")
+	t.Setenv("AUTH_ENABLED", "true")
+	t.Setenv("AUTH_HS256_SECRET", "")
+	t.Setenv("AUTH_JWKS_FILE", "")
+
+	cmd, err := parseMigrateCommand([]string{"migrate", "status"})
+	require.NoError(t, err)
+
+	cfg, err := loadConfig("", &cmd)
+	require.NoError(t, err)
+	err = runMigrate(cfg, zap.NewNop(), cmd, io.Discard)
+	assert.ErrorContains(t, err, "connecting to the database")
+
+	_, err = loadConfig("", nil)
+	assert.ErrorContains(t, err, "auth.hs256Secret or auth.jwksFile is required", "serving still needs the auth settings")
+}
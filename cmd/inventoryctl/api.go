@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/middleware"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+// apiBackend runs the commands through the HTTP API of a running service,
+// with the permissions of its credentials.
+type apiBackend struct {
+	baseURL  *url.URL
+	tenantID string
+	token    string
+	apiKey   string
+	client   *http.Client
+}
+
+func newAPIBackend(baseURL, tenantID, token, apiKey string) (*apiBackend, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid API URL %q, expected http(s)://host[:port]", baseURL)
+	}
+	return &apiBackend{
+		baseURL:  u,
+		tenantID: tenantID,
+		token:    token,
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// apiPage is a list response with the items decoded as T.
+type apiPage[T any] struct {
+	Data       []T                `json:"data"`
+	Pagination dtos.PaginationDTO `json:"pagination"`
+}
+
+// problemError is a problem+json response of the API.
+type problemError struct {
+	problem dtos.ProblemDTO
+}
+
+func (e *problemError) Error() string {
+	return fmt.Sprintf("%s (%d %s)", e.problem.Detail, e.problem.Status, e.problem.Code)
+}
+
+// do sends a request to path and decodes the response into result.
+func (b *apiBackend) do(ctx context.Context, method, path string, query url.Values, body, result any) error {
+	u := b.baseURL.JoinPath(path)
+	u.RawQuery = query.Encode()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	switch {
+	case b.token != "":
+		req.Header.Set("Authorization", "Bearer "+b.token)
+	case b.apiKey != "":
+		req.Header.Set("X-API-Key", b.apiKey)
+	}
+	if b.tenantID != "" {
+		req.Header.Set(middleware.TenantHeader, b.tenantID)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var problem dtos.ProblemDTO
+		if err := json.NewDecoder(resp.Body).Decode(&problem); err != nil || problem.Code == "" {
+			return fmt.Errorf("%s %s: %s", method, u.Path, resp.Status)
+		}
+		return &problemError{problem: problem}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("decoding the response of %s %s: %w", method, u.Path, err)
+	}
+	return nil
+}
+
+// list fetches every page of path.
+func list[T any](ctx context.Context, b *apiBackend, path string, query url.Values) ([]T, error) {
+	query.Set("limit", strconv.Itoa(domain.MaxPageLimit))
+	var items []T
+	for {
+		var page apiPage[T]
+		if err := b.do(ctx, http.MethodGet, path, query, nil, &page); err != nil {
+			return nil, err
+		}
+		items = append(items, page.Data...)
+		if page.Pagination.NextCursor == "" {
+			return items, nil
+		}
+		query.Set("cursor", page.Pagination.NextCursor)
+	}
+}
+
+func (b *apiBackend) Categories(ctx context.Context, includeDeleted bool) ([]dtos.CategoryDTO, error) {
+	query := url.Values{}
+	if includeDeleted {
+		query.Set("includeDeleted", "true")
+	}
+	return list[dtos.CategoryDTO](ctx, b, "/categories", query)
+}
+
+func (b *apiBackend) Products(ctx context.Context, categoryID int, includeDeleted bool) ([]dtos.ProductDTO, error) {
+	query := url.Values{}
+	if categoryID > 0 {
+		query.Set("categoryId", strconv.Itoa(categoryID))
+	}
+	if includeDeleted {
+		query.Set("includeDeleted", "true")
+	}
+	return list[dtos.ProductDTO](ctx, b, "/products", query)
+}
+
+func (b *apiBackend) Balances(context.Context, []int) ([]dtos.StockBalanceDTO, error) {
+	return nil, errDatabaseOnly
+}
+
+func (b *apiBackend) Movements(ctx context.Context, productID, limit int) ([]dtos.StockMovementDTO, error) {
+	query := url.Values{"order": {string(domain.SortDesc)}}
+	path := "/stock/movements/" + strconv.Itoa(productID)
+	var movements []dtos.StockMovementDTO
+	for len(movements) < limit {
+		query.Set("limit", strconv.Itoa(min(limit-len(movements), domain.MaxPageLimit)))
+		var page apiPage[dtos.StockMovementDTO]
+		if err := b.do(ctx, http.MethodGet, path, query, nil, &page); err != nil {
+			return nil, err
+		}
+		movements = append(movements, page.Data...)
+		if page.Pagination.NextCursor == "" {
+			break
+		}
+		query.Set("cursor", page.Pagination.NextCursor)
+	}
+	return movements, nil
+}
+
+func (b *apiBackend) Adjust(ctx context.Context, productID, quantity int, reason string) (dtos.StockMovementDTO, error) {
+	var movement dtos.StockMovementDTO
+	err := b.do(ctx, http.MethodPost, "/stock/movements", nil, dtos.CreateStockMovementDTO{
+		ProductID:    productID,
+		MovementType: domain.MovementTypeAdjustment,
+		Quantity:     quantity,
+		Reason:       reason,
+	}, &movement)
+	return movement, err
+}
+
+func (b *apiBackend) Reservations(context.Context, dtos.ListReservationsDTO) ([]dtos.StockReservationDTO, error) {
+	return nil, errDatabaseOnly
+}
+
+func (b *apiBackend) Release(context.Context, int) (dtos.StockReservationDTO, error) {
+	return dtos.StockReservationDTO{}, errDatabaseOnly
+}
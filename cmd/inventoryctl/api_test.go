@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIBackendFollowsCursors(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		page := dtos.PageDTO{Data: []dtos.CategoryDTO{{ID: 1, Name: "Shoes"}}, Pagination: dtos.PaginationDTO{NextCursor: "next"}}
+		if r.URL.Query().Get("cursor") == "next" {
+			page = dtos.PageDTO{Data: []dtos.CategoryDTO{{ID: 2, Name: "Boots"}}}
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	b, err := newAPIBackend(server.URL+"/", "seller-a", "", "0a1b2c3d4e5f.secret")
+	require.NoError(t, err)
+	categories, err := b.Categories(context.Background(), true)
+
+	require.NoError(t, err)
+	assert.Equal(t, []dtos.CategoryDTO{{ID: 1, Name: "Shoes"}, {ID: 2, Name: "Boots"}}, categories)
+	require.Len(t, requests, 2)
+	for _, r := range requests {
+		assert.Equal(t, "/categories", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("includeDeleted"))
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		assert.Equal(t, "seller-a", r.Header.Get("X-Tenant-ID"))
+		assert.Equal(t, "0a1b2c3d4e5f.secret", r.Header.Get("X-API-Key"))
+	}
+}
+
+func TestAPIBackendReportsProblems(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusConflict)
+		_ = json.NewEncoder(w).Encode(dtos.ProblemDTO{Status: http.StatusConflict, Detail: "insufficient stock", Code: "insufficient_stock"})
+	}))
+	defer server.Close()
+
+	b, err := newAPIBackend(server.URL, "", "token", "")
+	require.NoError(t, err)
+	_, err = b.Adjust(context.Background(), 1, -5, "damaged")
+
+	assert.EqualError(t, err, "insufficient stock (409 insufficient_stock)")
+}
+
+func TestAPIBackendRejectsDatabaseOnlyCommands(t *testing.T) {
+	b, err := newAPIBackend("http://localhost:8090", "", "", "")
+	require.NoError(t, err)
+
+	_, err = b.Balances(context.Background(), nil)
+	assert.ErrorIs(t, err, errDatabaseOnly)
+	_, err = b.Release(context.Background(), 1)
+	assert.ErrorIs(t, err, errDatabaseOnly)
+}
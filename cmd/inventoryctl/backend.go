@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+)
+
+// errDatabaseOnly is returned in API mode by the commands the HTTP API has
+// no endpoint for.
+var errDatabaseOnly = errors.New("the HTTP API does not support this command, run it without -api against the database")
+
+// backend runs the operations of the commands for one tenant, on the
+// database or through the HTTP API. List operations return every page.
+type backend interface {
+	Categories(ctx context.Context, includeDeleted bool) ([]dtos.CategoryDTO, error)
+	Products(ctx context.Context, categoryID int, includeDeleted bool) ([]dtos.ProductDTO, error)
+	Balances(ctx context.Context, productIDs []int) ([]dtos.StockBalanceDTO, error)
+	// Movements returns the last limit movements of a product, newest
+	// first.
+	Movements(ctx context.Context, productID, limit int) ([]dtos.StockMovementDTO, error)
+	Adjust(ctx context.Context, productID, quantity int, reason string) (dtos.StockMovementDTO, error)
+	Reservations(ctx context.Context, dto dtos.ListReservationsDTO) ([]dtos.StockReservationDTO, error)
+	Release(ctx context.Context, id int) (dtos.StockReservationDTO, error)
+}
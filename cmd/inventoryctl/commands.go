@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+)
+
+// command runs against a backend and returns what to print.
+type command func(ctx context.Context, b backend) (*output, error)
+
+// parseCommand parses the command and its arguments, the part of the command
+// line after the global flags.
+func parseCommand(args []string) (command, error) {
+	if len(args) < 2 {
+		return nil, errors.New("missing command")
+	}
+	name := args[0] + " " + args[1]
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	rest := args[2:]
+
+	switch name {
+	case "categories list":
+		deleted := flags.Bool("deleted", false, "include soft-deleted categories")
+		if err := parseArgs(flags, rest, 0, 0); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, b backend) (*output, error) {
+			categories, err := b.Categories(ctx, *deleted)
+			if err != nil {
+				return nil, err
+			}
+			return categoriesOutput(categories), nil
+		}, nil
+
+	case "products list":
+		categoryID := flags.Int("category", 0, "only products of this category and its subcategories")
+		deleted := flags.Bool("deleted", false, "include soft-deleted products")
+		if err := parseArgs(flags, rest, 0, 0); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, b backend) (*output, error) {
+			products, err := b.Products(ctx, *categoryID, *deleted)
+			if err != nil {
+				return nil, err
+			}
+			return productsOutput(products), nil
+		}, nil
+
+	case "stock balance":
+		if err := parseArgs(flags, rest, 0, -1); err != nil {
+			return nil, err
+		}
+		productIDs, err := parseIDs(flags.Args())
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, b backend) (*output, error) {
+			balances, err := b.Balances(ctx, productIDs)
+			if err != nil {
+				return nil, err
+			}
+			return balancesOutput(balances), nil
+		}, nil
+
+	case "stock history":
+		limit := flags.Int("limit", 20, "number of movements to show, newest first")
+		if err := parseArgs(flags, rest, 1, 1); err != nil {
+			return nil, err
+		}
+		ids, err := parseIDs(flags.Args())
+		if err != nil {
+			return nil, err
+		}
+		if *limit <= 0 {
+			return nil, errors.New("-limit must be positive")
+		}
+		return func(ctx context.Context, b backend) (*output, error) {
+			movements, err := b.Movements(ctx, ids[0], *limit)
+			if err != nil {
+				return nil, err
+			}
+			return movementsOutput(movements), nil
+		}, nil
+
+	case "stock adjust":
+		reason := flags.String("reason", "", "why the stock is adjusted, required")
+		if err := parseArgs(flags, rest, 2, 2); err != nil {
+			return nil, err
+		}
+		ids, err := parseIDs(flags.Args()[:1])
+		if err != nil {
+			return nil, err
+		}
+		quantity, err := strconv.Atoi(flags.Arg(1))
+		if err != nil || quantity == 0 {
+			return nil, fmt.Errorf("invalid quantity %q, expected a non-zero integer", flags.Arg(1))
+		}
+		if strings.TrimSpace(*reason) == "" {
+			return nil, errors.New("stock adjust requires -reason")
+		}
+		if len(*reason) > 255 {
+			return nil, errors.New("-reason cannot be longer than 255 characters")
+		}
+		return func(ctx context.Context, b backend) (*output, error) {
+			movement, err := b.Adjust(ctx, ids[0], quantity, *reason)
+			if err != nil {
+				return nil, err
+			}
+			return movementsOutput([]dtos.StockMovementDTO{movement}), nil
+		}, nil
+
+	case "reservations list":
+		productID := flags.Int("product", 0, "only reservations of this product")
+		olderThan := flags.Duration("older-than", 0, "only reservations made longer ago than this, e.g. 24h")
+		if err := parseArgs(flags, rest, 0, 0); err != nil {
+			return nil, err
+		}
+		if *olderThan < 0 {
+			return nil, errors.New("-older-than cannot be negative")
+		}
+		return func(ctx context.Context, b backend) (*output, error) {
+			dto := dtos.ListReservationsDTO{ProductID: *productID}
+			if *olderThan > 0 {
+				dto.ReservedBefore = time.Now().Add(-*olderThan)
+			}
+			reservations, err := b.Reservations(ctx, dto)
+			if err != nil {
+				return nil, err
+			}
+			return reservationsOutput(reservations), nil
+		}, nil
+
+	case "reservations release":
+		if err := parseArgs(flags, rest, 1, -1); err != nil {
+			return nil, err
+		}
+		ids, err := parseIDs(flags.Args())
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, b backend) (*output, error) {
+			var released []dtos.StockReservationDTO
+			for _, id := range ids {
+				reservation, err := b.Release(ctx, id)
+				if err != nil {
+					return reservationsOutput(released), fmt.Errorf("releasing reservation %d: %w", id, err)
+				}
+				released = append(released, reservation)
+			}
+			return reservationsOutput(released), nil
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown command %q", name)
+}
+
+// parseArgs parses the flags of a command and checks that between min and
+// max positional arguments follow; a negative max allows any number.
+func parseArgs(flags *flag.FlagSet, args []string, min, max int) error {
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%s: %w", flags.Name(), err)
+	}
+	n := flags.NArg()
+	switch {
+	case n < min:
+		return fmt.Errorf("%s: missing arguments", flags.Name())
+	case max >= 0 && n > max:
+		return fmt.Errorf("%s: unexpected argument %q", flags.Name(), flags.Arg(max))
+	}
+	return nil
+}
+
+func parseIDs(args []string) ([]int, error) {
+	ids := make([]int, 0, len(args))
+	for _, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid ID %q", arg)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCommandRejectsInvalidArguments(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{name: "no command", args: []string{"stock"}, expectedErr: "missing command"},
+		{name: "unknown command", args: []string{"stock", "move"}, expectedErr: `unknown command "stock move"`},
+		{name: "adjust without reason", args: []string{"stock", "adjust", "1", "5"}, expectedErr: "stock adjust requires -reason"},
+		{name: "adjust by zero", args: []string{"stock", "adjust", "-reason", "count", "1", "0"}, expectedErr: `invalid quantity "0", expected a non-zero integer`},
+		{name: "history without product", args: []string{"stock", "history"}, expectedErr: "stock history: missing arguments"},
+		{name: "invalid product ID", args: []string{"stock", "balance", "1", "x"}, expectedErr: `invalid ID "x"`},
+		{name: "extra argument", args: []string{"categories", "list", "shoes"}, expectedErr: `categories list: unexpected argument "shoes"`},
+		{name: "unknown flag", args: []string{"reservations", "list", "-older", "1h"}, expectedErr: "reservations list: flag provided but not defined: -older"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseCommand(tc.args)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestParseCommandAcceptsNegativeAdjustments(t *testing.T) {
+	cmd, err := parseCommand([]string{"stock", "adjust", "-reason", "damaged in transit", "7", "-3"})
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/user"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/mapper"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/config"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/postgresrepository"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/tenant"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/usecase"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dbBackend runs the commands through the use cases of the service, as an
+// administrator of the tenant.
+type dbBackend struct {
+	tenantID  string
+	principal *auth.Principal
+
+	categories   *usecase.CategoryUsecase
+	products     *usecase.ProductUsecase
+	movements    *usecase.StockMovementUsecase
+	balances     *usecase.StockBalanceUsecase
+	reservations *usecase.ReservationUsecase
+}
+
+// openDatabase connects to the database of the service configuration at
+// configPath and returns a backend acting for tenantID, or the default tenant
+// when it is empty.
+func openDatabase(ctx context.Context, configPath, tenantID string) (*dbBackend, func() error, error) {
+	// The tool only needs the database, not the settings of the server.
+	cfg, err := config.LoadDatabase(configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tenantID == "" {
+		tenantID = cfg.Tenancy.DefaultTenant
+	}
+	if !tenant.Valid(tenantID) {
+		return nil, nil, fmt.Errorf("invalid tenant %q, set -tenant", tenantID)
+	}
+
+	logger, err := setupLogger(cfg.Log)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The use cases log through the global logger, e.g. released
+	// reservations.
+	zap.ReplaceGlobals(logger.Named("inventoryctl"))
+
+	db, err := gorm.Open(postgres.Open(cfg.Database.DSN()), &gorm.Config{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("connecting to the database: %w", err)
+	}
+	if err := db.Use(postgresrepository.NewTenantPlugin()); err != nil {
+		return nil, nil, err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	categoryRepo := postgresrepository.NewCategoryRepositoryPostgres(db)
+	productRepo := postgresrepository.NewProductRepositoryPostgres(db)
+	stockLevelRepo := postgresrepository.NewStockLevelRepositoryPostgres(db)
+	reservationRepo := postgresrepository.NewStockReservationRepositoryPostgres(db)
+	transactor := postgresrepository.NewTransactorPostgres(db)
+	b := &dbBackend{
+		tenantID:     tenantID,
+		categories:   usecase.NewCategoryUsecase(categoryRepo, productRepo, transactor),
+		products:     usecase.NewProductUsecase(productRepo, categoryRepo),
+		movements:    usecase.NewStockMovementUsecase(postgresrepository.NewStockMovementRepositoryPostgres(db), stockLevelRepo, postgresrepository.NewCostLayerRepositoryPostgres(db), productRepo, transactor, nil),
+		balances:     usecase.NewStockBalanceUsecase(stockLevelRepo, reservationRepo, productRepo),
+		reservations: usecase.NewReservationUsecase(reservationRepo, stockLevelRepo, productRepo, transactor, nil),
+	}
+
+	// Movements and releases are attributed to a user named after the
+	// operating system account running the tool.
+	subject := "inventoryctl"
+	if current, err := user.Current(); err == nil {
+		subject += ":" + current.Username
+	}
+	u, err := usecase.NewUserUsecase(postgresrepository.NewUserRepositoryPostgres(db)).
+		ResolveUser(ctx, domain.User{Subject: subject})
+	if err != nil {
+		sqlDB.Close()
+		return nil, nil, fmt.Errorf("resolving the user %s: %w", subject, err)
+	}
+	claims := auth.Claims{Roles: []string{auth.RoleAdmin}, TenantID: tenantID}
+	claims.Subject = subject
+	b.principal = auth.NewPrincipal(claims, u.ID, auth.DefaultPolicy())
+
+	return b, sqlDB.Close, nil
+}
+
+func setupLogger(cfg config.LogConfig) (*zap.Logger, error) {
+	zapConfig := zap.NewProductionConfig()
+	if cfg.Format == "console" {
+		zapConfig = zap.NewDevelopmentConfig()
+	}
+	level, err := zap.ParseAtomicLevel(cfg.Level)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level: %w", err)
+	}
+	zapConfig.Level = level
+	return zapConfig.Build()
+}
+
+// scope returns ctx acting as the administrator of the tenant.
+func (b *dbBackend) scope(ctx context.Context) context.Context {
+	return auth.WithPrincipal(tenant.WithID(ctx, b.tenantID), b.principal)
+}
+
+func (b *dbBackend) Categories(ctx context.Context, includeDeleted bool) ([]dtos.CategoryDTO, error) {
+	dto := dtos.ListCategoriesDTO{ListQueryDTO: dtos.ListQueryDTO{Limit: domain.MaxPageLimit}, IncludeDeleted: includeDeleted}
+	var categories []dtos.CategoryDTO
+	for {
+		page, err := b.categories.ListCategories(b.scope(ctx), dto)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, mapper.ToSlice(page.Items, mapper.ToCategoryDTO)...)
+		if page.NextCursor == "" {
+			return categories, nil
+		}
+		dto.Cursor = page.NextCursor
+	}
+}
+
+func (b *dbBackend) Products(ctx context.Context, categoryID int, includeDeleted bool) ([]dtos.ProductDTO, error) {
+	dto := dtos.ListProductsDTO{ListQueryDTO: dtos.ListQueryDTO{Limit: domain.MaxPageLimit}, CategoryID: categoryID, IncludeDeleted: includeDeleted}
+	var products []dtos.ProductDTO
+	for {
+		page, err := b.products.ListProducts(b.scope(ctx), dto)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, mapper.ToSlice(page.Items, mapper.ToProductDTO)...)
+		if page.NextCursor == "" {
+			return products, nil
+		}
+		dto.Cursor = page.NextCursor
+	}
+}
+
+func (b *dbBackend) Balances(ctx context.Context, productIDs []int) ([]dtos.StockBalanceDTO, error) {
+	balances, err := b.balances.GetBalances(b.scope(ctx), productIDs)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToSlice(balances, mapper.ToStockBalanceDTO), nil
+}
+
+func (b *dbBackend) Movements(ctx context.Context, productID, limit int) ([]dtos.StockMovementDTO, error) {
+	dto := dtos.ListStockMovementsDTO{ListQueryDTO: dtos.ListQueryDTO{Order: string(domain.SortDesc)}}
+	var movements []dtos.StockMovementDTO
+	for len(movements) < limit {
+		dto.Limit = min(limit-len(movements), domain.MaxPageLimit)
+		page, err := b.movements.ListMovementsByProduct(b.scope(ctx), productID, dto)
+		if err != nil {
+			return nil, err
+		}
+		movements = append(movements, mapper.ToSlice(page.Items, mapper.ToStockMovementDTO)...)
+		if page.NextCursor == "" {
+			break
+		}
+		dto.Cursor = page.NextCursor
+	}
+	return movements, nil
+}
+
+func (b *dbBackend) Adjust(ctx context.Context, productID, quantity int, reason string) (dtos.StockMovementDTO, error) {
+	movement, err := b.movements.RecordMovement(b.scope(ctx), dtos.CreateStockMovementDTO{
+		ProductID:    productID,
+		MovementType: domain.MovementTypeAdjustment,
+		Quantity:     quantity,
+		Reason:       reason,
+	})
+	if err != nil {
+		return dtos.StockMovementDTO{}, err
+	}
+	return mapper.ToStockMovementDTO(movement), nil
+}
+
+func (b *dbBackend) Reservations(ctx context.Context, dto dtos.ListReservationsDTO) ([]dtos.StockReservationDTO, error) {
+	reservations, err := b.reservations.ListActiveReservations(b.scope(ctx), dto)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToSlice(reservations, mapper.ToStockReservationDTO), nil
+}
+
+func (b *dbBackend) Release(ctx context.Context, id int) (dtos.StockReservationDTO, error) {
+	reservation, err := b.reservations.ReleaseReservation(b.scope(ctx), id)
+	if err != nil {
+		return dtos.StockReservationDTO{}, err
+	}
+	return mapper.ToStockReservationDTO(reservation), nil
+}
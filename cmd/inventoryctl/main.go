@@ -0,0 +1,99 @@
+// Command inventoryctl administers the inventory of one tenant from the
+// command line. It works on the database with the service configuration,
+// or on a running service through its HTTP API when -api is set.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+)
+
+const usage = `Usage: inventoryctl [flags] <command> [command flags] [arguments]
+
+Commands:
+  categories list [-deleted]
+  products list [-category ID] [-deleted]
+  stock balance [PRODUCT_ID...]
+  stock history [-limit N] PRODUCT_ID
+  stock adjust -reason TEXT PRODUCT_ID QUANTITY
+  reservations list [-product ID] [-older-than DURATION]
+  reservations release RESERVATION_ID...
+
+The API credentials are read from INVENTORYCTL_TOKEN (a bearer token) or
+INVENTORYCTL_API_KEY.
+
+Flags:
+`
+
+var formats = []string{"table", "json", "csv"}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("inventoryctl", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configPath := flags.String("config", os.Getenv("CONFIG_FILE"), "path to an optional YAML config file of the service, database mode only")
+	apiURL := flags.String("api", os.Getenv("INVENTORYCTL_API_URL"), "base URL of the service, e.g. http://localhost:8090; the database is used when empty")
+	tenantID := flags.String("tenant", os.Getenv("INVENTORYCTL_TENANT"), "tenant to act for, defaults to the configured default tenant")
+	format := flags.String("o", "table", "output format: table, json or csv")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if !slices.Contains(formats, *format) {
+		fmt.Fprintf(stderr, "unknown output format %q, expected table, json or csv\n", *format)
+		return 2
+	}
+	cmd, err := parseCommand(flags.Args())
+	if err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(stderr, err)
+		}
+		flags.Usage()
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var b backend
+	if *apiURL != "" {
+		b, err = newAPIBackend(*apiURL, *tenantID, os.Getenv("INVENTORYCTL_TOKEN"), os.Getenv("INVENTORYCTL_API_KEY"))
+	} else {
+		var closeDB func() error
+		b, closeDB, err = openDatabase(ctx, *configPath, *tenantID)
+		if err == nil {
+			defer closeDB()
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "inventoryctl:", err)
+		return 1
+	}
+
+	out, err := cmd(ctx, b)
+	// Commands acting on several arguments report what succeeded before
+	// the failure.
+	if out != nil {
+		if writeErr := out.write(stdout, *format); writeErr != nil {
+			err = errors.Join(err, writeErr)
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "inventoryctl:", err)
+		return 1
+	}
+	return 0
+}
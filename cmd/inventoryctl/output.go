@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+)
+
+// output is the result of a command. Tables and CSV show rows under
+// columns, JSON encodes data, the DTOs the HTTP API would return.
+type output struct {
+	columns []string
+	rows    [][]string
+	data    any
+}
+
+func (o *output) write(w io.Writer, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(o.data)
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(o.columns); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(o.rows); err != nil {
+			return err
+		}
+		return cw.Error()
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(o.columns, "\t")))
+		for _, row := range o.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+}
+
+// newOutput builds the rows of items with row, keeping items as the JSON
+// data. A nil slice is encoded as an empty JSON array.
+func newOutput[T any](columns []string, items []T, row func(T) []string) *output {
+	if items == nil {
+		items = []T{}
+	}
+	o := &output{columns: columns, data: items}
+	for _, item := range items {
+		o.rows = append(o.rows, row(item))
+	}
+	return o
+}
+
+func categoriesOutput(categories []dtos.CategoryDTO) *output {
+	return newOutput([]string{"id", "name", "parent_id", "created_at", "deleted_at"}, categories, func(c dtos.CategoryDTO) []string {
+		return []string{itoa(c.ID), c.Name, optionalID(c.ParentID), c.CreatedAt, c.DeletedAt}
+	})
+}
+
+func productsOutput(products []dtos.ProductDTO) *output {
+	return newOutput([]string{"id", "sku", "name", "category_id", "price", "created_at", "deleted_at"}, products, func(p dtos.ProductDTO) []string {
+		return []string{itoa(p.ID), p.SKU, p.Name, itoa(p.CategoryID), strconv.FormatFloat(p.Price, 'f', 2, 64), p.CreatedAt, p.DeletedAt}
+	})
+}
+
+func balancesOutput(balances []dtos.StockBalanceDTO) *output {
+	return newOutput([]string{"product_id", "product_name", "on_hand", "reserved", "available"}, balances, func(b dtos.StockBalanceDTO) []string {
+		return []string{itoa(b.ProductID), b.ProductName, itoa(b.OnHand), itoa(b.Reserved), itoa(b.Available)}
+	})
+}
+
+func movementsOutput(movements []dtos.StockMovementDTO) *output {
+	return newOutput([]string{"id", "product_id", "type", "quantity", "unit_cost", "reason", "user_id", "created_at"}, movements, func(m dtos.StockMovementDTO) []string {
+		unitCost := ""
+		if m.UnitCost != nil {
+			unitCost = strconv.FormatFloat(*m.UnitCost, 'f', -1, 64)
+		}
+		return []string{itoa(m.ID), itoa(m.ProductID), m.MovementType, itoa(m.Quantity), unitCost, m.Reason, m.UserID, m.CreatedAt}
+	})
+}
+
+func reservationsOutput(reservations []dtos.StockReservationDTO) *output {
+	return newOutput([]string{"id", "product_id", "quantity", "reference_id", "status", "reserved_at", "expires_at"}, reservations, func(r dtos.StockReservationDTO) []string {
+		return []string{itoa(r.ID), itoa(r.ProductID), itoa(r.ReservedQty), r.ReferenceID, r.Status, r.ReservedAt, r.ExpiresAt}
+	})
+}
+
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
+
+func optionalID(id *int) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.Itoa(*id)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputFormats(t *testing.T) {
+	balances := []dtos.StockBalanceDTO{
+		{ProductID: 1, ProductName: "Cable, 2m", OnHand: 10, Reserved: 3, Available: 7},
+		{ProductID: 12, ProductName: "Adapter", OnHand: 4, Available: 4},
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format: "table",
+			expected: "PRODUCT_ID  PRODUCT_NAME  ON_HAND  RESERVED  AVAILABLE\n" +
+				"1           Cable, 2m     10       3         7\n" +
+				"12          Adapter       4        0         4\n",
+		},
+		{
+			format: "csv",
+			expected: "product_id,product_name,on_hand,reserved,available\n" +
+				"1,\"Cable, 2m\",10,3,7\n" +
+				"12,Adapter,4,0,4\n",
+		},
+		{
+			format: "json",
+			expected: `[
+  {
+    "productId": 1,
+    "productName": "Cable, 2m",
+    "onHand": 10,
+    "reserved": 3,
+    "available": 7
+  },
+  {
+    "productId": 12,
+    "productName": "Adapter",
+    "onHand": 4,
+    "reserved": 0,
+    "available": 4
+  }
+]
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, balancesOutput(balances).write(&buf, tc.format))
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestEmptyOutput(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, reservationsOutput(nil).write(&buf, "json"))
+	assert.Equal(t, "[]\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, reservationsOutput(nil).write(&buf, "csv"))
+	assert.Equal(t, "id,product_id,quantity,reference_id,status,reserved_at,expires_at\n", buf.String())
+}
@@ -0,0 +1,122 @@
+// Package apperror defines the error model returned by the HTTP API and maps
+// domain errors onto it.
+package apperror
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/validation"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+)
+
+// Error is an API error. It is rendered as an RFC 7807 problem document by
+// the error middleware; Code is a stable machine readable identifier and
+// Details carries optional structured context.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+	Details any
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func New(status int, code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// Wrap returns a copy of e that records err as its cause.
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+// WithDetails returns a copy of e carrying details.
+func (e *Error) WithDetails(details any) *Error {
+	withDetails := *e
+	withDetails.Details = details
+	return &withDetails
+}
+
+var (
+	ErrInvalidID       = New(http.StatusBadRequest, "invalid_id", "Invalid ID format")
+	ErrInvalidPayload  = New(http.StatusBadRequest, "invalid_payload", "Invalid request payload")
+	ErrInvalidQuery    = New(http.StatusBadRequest, "invalid_query", "Invalid query parameters")
+	ErrValidation      = New(http.StatusBadRequest, "validation_failed", "Request validation failed")
+	ErrPayloadTooLarge = New(http.StatusRequestEntityTooLarge, "payload_too_large", "The request body is too large")
+	ErrUnauthorized    = New(http.StatusUnauthorized, "unauthorized", "A valid bearer token is required")
+	ErrForbidden       = New(http.StatusForbidden, "forbidden", "You are not allowed to perform this operation")
+	ErrTenantRequired  = New(http.StatusBadRequest, "tenant_required", "The X-Tenant-ID header is required")
+	ErrInvalidTenant   = New(http.StatusBadRequest, "invalid_tenant", "Invalid tenant ID")
+	ErrTenantMismatch  = New(http.StatusForbidden, "tenant_mismatch", "The X-Tenant-ID header does not match the tenant of your credentials")
+	ErrRouteNotFound   = New(http.StatusNotFound, "route_not_found", "Route not found")
+	ErrRateLimited     = New(http.StatusTooManyRequests, "rate_limited", "Too many requests, retry later")
+	ErrTimeout         = New(http.StatusGatewayTimeout, "timeout", "The request did not complete in time")
+	ErrInternal        = New(http.StatusInternalServerError, "internal_error", "Internal Server Error")
+)
+
+// InvalidPayload reports a request body that could not be bound. Validation
+// failures list the offending fields in the details.
+func InvalidPayload(err error) *Error {
+	return bindingError(ErrInvalidPayload, err)
+}
+
+// InvalidQuery reports query parameters that could not be bound. Validation
+// failures list the offending fields in the details.
+func InvalidQuery(err error) *Error {
+	return bindingError(ErrInvalidQuery, err)
+}
+
+func bindingError(base *Error, err error) *Error {
+	if fieldErrors, ok := validation.FieldErrors(err); ok {
+		return ErrValidation.Wrap(err).WithDetails(fieldErrors)
+	}
+	return base.Wrap(err)
+}
+
+// FromError converts err to an API error. Errors that are already API errors
+// are returned as is, denied permissions become forbidden, known domain
+// errors are looked up in the mapping table, deadlines exceeded while
+// querying become timeouts and anything else becomes an internal error that
+// keeps err as its cause.
+func FromError(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var forbiddenErr *auth.ForbiddenError
+	if errors.As(err, &forbiddenErr) {
+		return ErrForbidden.Wrap(err).WithDetails(dtos.ForbiddenDTO{Permission: string(forbiddenErr.Permission)})
+	}
+
+	for _, m := range domainErrors {
+		if errors.Is(err, m.err) {
+			mapped := New(m.status, m.code, m.err.Error()).Wrap(err)
+			if m.details != nil {
+				mapped.Details = m.details(err)
+			}
+			return mapped
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrTimeout.Wrap(err)
+	}
+
+	return ErrInternal.Wrap(err)
+}
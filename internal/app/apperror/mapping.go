@@ -0,0 +1,74 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/mapper"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+type domainErrorMapping struct {
+	err    error
+	status int
+	code   string
+	// details optionally extracts structured context from the error.
+	details func(err error) any
+}
+
+// domainErrors maps domain errors to HTTP statuses. The domain message is
+// safe to show to clients and becomes the problem detail. Entries are
+// matched in order with errors.Is.
+var domainErrors = []domainErrorMapping{
+	{err: domain.ErrInvalidPageLimit, status: http.StatusBadRequest, code: "invalid_page_limit"},
+	{err: domain.ErrInvalidPageOffset, status: http.StatusBadRequest, code: "invalid_page_offset"},
+	{err: domain.ErrInvalidSortField, status: http.StatusBadRequest, code: "invalid_sort_field"},
+	{err: domain.ErrInvalidSortOrder, status: http.StatusBadRequest, code: "invalid_sort_order"},
+	{err: domain.ErrInvalidCursor, status: http.StatusBadRequest, code: "invalid_cursor"},
+
+	{err: domain.ErrCategoryNotFound, status: http.StatusNotFound, code: "category_not_found"},
+	{err: domain.ErrParentCategoryNotFound, status: http.StatusBadRequest, code: "parent_category_not_found"},
+	{err: domain.ErrParentCategoryDeleted, status: http.StatusConflict, code: "parent_category_deleted"},
+	{err: domain.ErrCategoryCycle, status: http.StatusBadRequest, code: "category_cycle"},
+	{err: domain.ErrCategoryInUse, status: http.StatusConflict, code: "category_in_use", details: categoryInUseDetails},
+	{err: domain.ErrInvalidDeletePolicy, status: http.StatusBadRequest, code: "invalid_delete_policy"},
+	{err: domain.ErrCategoryNotDeleted, status: http.StatusConflict, code: "category_not_deleted"},
+	{err: domain.ErrCategoryNameTaken, status: http.StatusConflict, code: "category_name_taken"},
+	{err: domain.ErrReassignTargetRequired, status: http.StatusBadRequest, code: "reassign_target_required"},
+	{err: domain.ErrInvalidReassignTarget, status: http.StatusBadRequest, code: "invalid_reassign_target"},
+
+	{err: domain.ErrProductNotFound, status: http.StatusNotFound, code: "product_not_found"},
+	{err: domain.ErrProductNotDeleted, status: http.StatusConflict, code: "product_not_deleted"},
+	{err: domain.ErrProductSKUTaken, status: http.StatusConflict, code: "product_sku_taken"},
+	{err: domain.ErrEmptySearchQuery, status: http.StatusBadRequest, code: "empty_search_query"},
+	{err: domain.ErrInvalidPriceRange, status: http.StatusBadRequest, code: "invalid_price_range"},
+
+	{err: domain.ErrStockLevelNotFound, status: http.StatusNotFound, code: "stock_level_not_found"},
+	{err: domain.ErrInsufficientStock, status: http.StatusConflict, code: "insufficient_stock"},
+	{err: domain.ErrInvalidMovementType, status: http.StatusBadRequest, code: "invalid_movement_type"},
+	{err: domain.ErrInvalidMovementQty, status: http.StatusBadRequest, code: "invalid_movement_quantity"},
+	{err: domain.ErrMissingUnitCost, status: http.StatusBadRequest, code: "missing_unit_cost"},
+	{err: domain.ErrInvalidValuationMethod, status: http.StatusBadRequest, code: "invalid_valuation_method"},
+
+	{err: domain.ErrReservationNotFound, status: http.StatusNotFound, code: "reservation_not_found"},
+	{err: domain.ErrReservationNotActive, status: http.StatusConflict, code: "reservation_not_active"},
+
+	{err: domain.ErrImportJobNotFound, status: http.StatusNotFound, code: "import_job_not_found"},
+	{err: domain.ErrUnsupportedImportFormat, status: http.StatusUnsupportedMediaType, code: "unsupported_import_format"},
+	{err: domain.ErrEmptyImport, status: http.StatusBadRequest, code: "empty_import"},
+
+	{err: domain.ErrInvalidExportRange, status: http.StatusBadRequest, code: "invalid_export_range"},
+
+	{err: domain.ErrAPIKeyNotFound, status: http.StatusNotFound, code: "api_key_not_found"},
+	{err: domain.ErrAPIKeyRevoked, status: http.StatusConflict, code: "api_key_revoked"},
+	{err: domain.ErrInvalidAPIKeyName, status: http.StatusBadRequest, code: "invalid_api_key_name"},
+	{err: domain.ErrInvalidAPIKeyScope, status: http.StatusBadRequest, code: "invalid_api_key_scope"},
+}
+
+func categoryInUseDetails(err error) any {
+	var inUseErr *domain.CategoryInUseError
+	if !errors.As(err, &inUseErr) {
+		return nil
+	}
+	return mapper.ToCategoryInUseDTO(inUseErr)
+}
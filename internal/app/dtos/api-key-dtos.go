@@ -0,0 +1,25 @@
+package dtos
+
+type CreateAPIKeyDTO struct {
+	Name   string   `json:"name" validate:"required,max=255"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,required"`
+}
+
+type APIKeyDTO struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	CreatedBy  string   `json:"createdBy,omitempty"`
+	CreatedAt  string   `json:"createdAt" format:"date-time"`
+	LastUsedAt string   `json:"lastUsedAt,omitempty" format:"date-time"`
+	RotatedAt  string   `json:"rotatedAt,omitempty" format:"date-time"`
+	RevokedAt  string   `json:"revokedAt,omitempty" format:"date-time"`
+}
+
+// IssuedAPIKeyDTO is returned when a key is created or rotated. Key is not
+// stored and cannot be retrieved again.
+type IssuedAPIKeyDTO struct {
+	APIKeyDTO
+	Key string `json:"key"`
+}
@@ -2,16 +2,52 @@
 package dtos
 
 type CreateCategoryDTO struct {
-	Name string `json:"name" validate:"required"`
+	Name     string `json:"name" validate:"required,max=255"`
+	ParentID *int   `json:"parentId,omitempty" validate:"omitempty,gt=0"`
 }
 
+// UpdateCategoryDTO renames a category and optionally moves it. A nil
+// ParentID keeps the current parent and 0 moves the category to the root.
 type UpdateCategoryDTO struct {
-	Name string `json:"name" validate:"required"`
+	Name     string `json:"name" validate:"required,max=255"`
+	ParentID *int   `json:"parentId,omitempty" validate:"omitempty,gte=0"`
 }
 
 type CategoryDTO struct {
 	ID        int    `json:"id"`
 	Name      string `json:"name"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"updatedAt,omitempty"`
+	ParentID  *int   `json:"parentId,omitempty"`
+	CreatedAt string `json:"createdAt" format:"date-time"`
+	UpdatedAt string `json:"updatedAt,omitempty" format:"date-time"`
+	DeletedAt string `json:"deletedAt,omitempty" format:"date-time"`
+}
+
+type CategoryTreeDTO struct {
+	ID       int               `json:"id"`
+	Name     string            `json:"name"`
+	ParentID *int              `json:"parentId,omitempty"`
+	Children []CategoryTreeDTO `json:"children"`
+}
+
+type ListCategoriesDTO struct {
+	ListQueryDTO
+	IncludeDeleted bool `form:"includeDeleted"`
+}
+
+// DeleteCategoryDTO carries the query parameters of a category deletion.
+type DeleteCategoryDTO struct {
+	Policy     string `form:"policy" normalize:"lower" validate:"omitempty,oneof=restrict reassign soft"`
+	ReassignTo int    `form:"reassignTo" validate:"gte=0"`
+}
+
+type CategoryReferenceDTO struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// CategoryInUseDTO lists the products and subcategories blocking a deletion.
+// It is returned as the details of the category_in_use problem.
+type CategoryInUseDTO struct {
+	Products      []CategoryReferenceDTO `json:"products"`
+	Subcategories []CategoryReferenceDTO `json:"subcategories"`
 }
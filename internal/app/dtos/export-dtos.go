@@ -0,0 +1,22 @@
+package dtos
+
+// ExportQueryDTO holds the query parameters shared by the exports. The
+// format defaults to CSV.
+type ExportQueryDTO struct {
+	Format      string `form:"format" normalize:"lower" validate:"omitempty,oneof=csv ndjson parquet"`
+	Compression string `form:"compression" normalize:"lower" validate:"omitempty,oneof=gzip"`
+}
+
+type ProductExportQueryDTO struct {
+	ExportQueryDTO
+	IncludeDeleted bool `form:"includeDeleted"`
+}
+
+// MovementExportQueryDTO bounds the exported ledger with calendar dates or
+// RFC 3339 timestamps; a date as to includes the whole day.
+type MovementExportQueryDTO struct {
+	ExportQueryDTO
+	From      string `form:"from" validate:"required"`
+	To        string `form:"to" validate:"required"`
+	ProductID int    `form:"productId" validate:"gte=0"`
+}
@@ -0,0 +1,18 @@
+package dtos
+
+type LivenessDTO struct {
+	Status string `json:"status" example:"ok"`
+}
+
+type DependencyStatusDTO struct {
+	Name      string  `json:"name" example:"postgres"`
+	Status    string  `json:"status" enums:"up,down"`
+	Required  bool    `json:"required"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type ReadinessDTO struct {
+	Status       string                `json:"status" enums:"ready,unavailable"`
+	Dependencies []DependencyStatusDTO `json:"dependencies"`
+}
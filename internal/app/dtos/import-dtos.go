@@ -0,0 +1,41 @@
+package dtos
+
+// CreateImportDTO queues the import of an uploaded file. Format is one of
+// the domain.ImportFormat constants.
+type CreateImportDTO struct {
+	Format  string
+	DryRun  bool
+	Payload []byte
+}
+
+type ImportRowErrorDTO struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportJobDTO is the status of an import. Errors lists the first invalid
+// rows; ErrorCount counts them all.
+type ImportJobDTO struct {
+	ID                string              `json:"id" format:"uuid"`
+	Status            string              `json:"status" enums:"pending,running,succeeded,failed"`
+	Format            string              `json:"format" enums:"csv,ndjson"`
+	DryRun            bool                `json:"dryRun"`
+	TotalRows         int                 `json:"totalRows"`
+	ProcessedRows     int                 `json:"processedRows"`
+	CreatedProducts   int                 `json:"createdProducts"`
+	CreatedCategories int                 `json:"createdCategories"`
+	ErrorCount        int                 `json:"errorCount"`
+	Errors            []ImportRowErrorDTO `json:"errors"`
+	Failure           string              `json:"failure,omitempty"`
+	CreatedAt         string              `json:"createdAt" format:"date-time"`
+	StartedAt         string              `json:"startedAt,omitempty" format:"date-time"`
+	FinishedAt        string              `json:"finishedAt,omitempty" format:"date-time"`
+}
+
+// ImportQueryDTO holds the query parameters of an upload. The format
+// defaults to the one of the Content-Type header.
+type ImportQueryDTO struct {
+	Format string `form:"format" normalize:"lower" validate:"omitempty,oneof=csv ndjson"`
+	DryRun bool   `form:"dryRun"`
+}
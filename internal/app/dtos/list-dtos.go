@@ -0,0 +1,27 @@
+package dtos
+
+// ListQueryDTO carries the paging, sorting and filtering query parameters
+// shared by list endpoints.
+type ListQueryDTO struct {
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+	Cursor string `form:"cursor"`
+	Sort   string `form:"sort"`
+	Order  string `form:"order"`
+	Q      string `form:"q"`
+	Prefix string `form:"prefix"`
+}
+
+type PaginationDTO struct {
+	Total      int64  `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// PageDTO is the envelope of list responses. Data holds the slice of
+// resource DTOs.
+type PageDTO struct {
+	Data       any           `json:"data"`
+	Pagination PaginationDTO `json:"pagination"`
+}
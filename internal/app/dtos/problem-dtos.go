@@ -0,0 +1,28 @@
+package dtos
+
+// ProblemDTO is the application/problem+json body (RFC 7807) returned by
+// every failed request. Code identifies the error for clients, Details holds
+// error specific context and RequestID correlates the response with the
+// server logs.
+type ProblemDTO struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code"`
+	RequestID string `json:"requestId,omitempty"`
+	Details   any    `json:"details,omitempty"`
+}
+
+// FieldErrorDTO describes one failed validation rule of a request field.
+type FieldErrorDTO struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ForbiddenDTO names the permission the caller was missing.
+type ForbiddenDTO struct {
+	Permission string `json:"permission"`
+}
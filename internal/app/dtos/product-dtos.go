@@ -0,0 +1,77 @@
+package dtos
+
+type CreateProductDTO struct {
+	Name        string  `json:"name" validate:"required,max=255"`
+	SKU         string  `json:"sku" validate:"required,sku"`
+	Description string  `json:"description,omitempty" validate:"max=2000"`
+	Price       float64 `json:"price" validate:"gte=0"`
+	CategoryID  int     `json:"categoryId" validate:"required,gt=0"`
+}
+
+// UpdateProductDTO replaces the details of a product. An empty SKU keeps
+// the current one.
+type UpdateProductDTO struct {
+	Name        string  `json:"name" validate:"required,max=255"`
+	SKU         string  `json:"sku,omitempty" validate:"omitempty,sku"`
+	Description string  `json:"description,omitempty" validate:"max=2000"`
+	Price       float64 `json:"price" validate:"gte=0"`
+	CategoryID  int     `json:"categoryId" validate:"required,gt=0"`
+}
+
+type ListProductsDTO struct {
+	ListQueryDTO
+	CategoryID     int  `form:"categoryId"`
+	IncludeDeleted bool `form:"includeDeleted"`
+}
+
+type ProductDTO struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	SKU         string  `json:"sku,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Price       float64 `json:"price"`
+	CategoryID  int     `json:"categoryId"`
+	CreatedAt   string  `json:"createdAt" format:"date-time"`
+	UpdatedAt   string  `json:"updatedAt,omitempty" format:"date-time"`
+	DeletedAt   string  `json:"deletedAt,omitempty" format:"date-time"`
+}
+
+type SearchProductsDTO struct {
+	Q          string   `form:"q"`
+	CategoryID int      `form:"categoryId"`
+	MinPrice   *float64 `form:"minPrice" validate:"omitempty,gte=0"`
+	MaxPrice   *float64 `form:"maxPrice" validate:"omitempty,gte=0"`
+	InStock    bool     `form:"inStock"`
+	Limit      int      `form:"limit"`
+	Offset     int      `form:"offset"`
+}
+
+type ProductSearchHitDTO struct {
+	ProductDTO
+	Rank float64 `json:"rank"`
+}
+
+type CategoryFacetDTO struct {
+	CategoryID   int    `json:"categoryId"`
+	CategoryName string `json:"categoryName"`
+	Count        int64  `json:"count"`
+}
+
+// PriceRangeFacetDTO counts matches priced from Min (inclusive) up to Max
+// (exclusive). A missing bound means the range is open on that side.
+type PriceRangeFacetDTO struct {
+	Min   *float64 `json:"min,omitempty"`
+	Max   *float64 `json:"max,omitempty"`
+	Count int64    `json:"count"`
+}
+
+type ProductSearchFacetsDTO struct {
+	Categories  []CategoryFacetDTO   `json:"categories"`
+	PriceRanges []PriceRangeFacetDTO `json:"priceRanges"`
+}
+
+type ProductSearchResultDTO struct {
+	Data       []ProductSearchHitDTO  `json:"data"`
+	Facets     ProductSearchFacetsDTO `json:"facets"`
+	Pagination PaginationDTO          `json:"pagination"`
+}
@@ -0,0 +1,24 @@
+package dtos
+
+type ProductValuationDTO struct {
+	ProductID   int     `json:"productId"`
+	ProductName string  `json:"productName"`
+	Quantity    int     `json:"quantity"`
+	UnitCost    float64 `json:"unitCost"`
+	TotalValue  float64 `json:"totalValue"`
+}
+
+type CategoryValuationDTO struct {
+	CategoryID   int                   `json:"categoryId"`
+	CategoryName string                `json:"categoryName"`
+	Quantity     int                   `json:"quantity"`
+	TotalValue   float64               `json:"totalValue"`
+	Products     []ProductValuationDTO `json:"products"`
+}
+
+type ValuationReportDTO struct {
+	Method     string                 `json:"method"`
+	AsOf       string                 `json:"asOf" format:"date-time"`
+	TotalValue float64                `json:"totalValue"`
+	Categories []CategoryValuationDTO `json:"categories"`
+}
@@ -0,0 +1,63 @@
+package dtos
+
+import "time"
+
+// CreateStockMovementDTO records a movement. Receipts and issues need a
+// positive quantity while adjustments may be negative.
+type CreateStockMovementDTO struct {
+	ProductID    int      `json:"productId" validate:"required,gt=0"`
+	MovementType string   `json:"movementType" normalize:"lower" validate:"required,oneof=receipt issue adjustment"`
+	Quantity     int      `json:"quantity" validate:"required"`
+	UnitCost     *float64 `json:"unitCost,omitempty" validate:"omitempty,gte=0"`
+	Reason       string   `json:"reason" validate:"max=255"`
+}
+
+type ListStockMovementsDTO struct {
+	ListQueryDTO
+}
+
+type StockMovementDTO struct {
+	ID           int      `json:"id"`
+	ProductID    int      `json:"productId"`
+	MovementType string   `json:"movementType"`
+	Quantity     int      `json:"quantity"`
+	UnitCost     *float64 `json:"unitCost,omitempty"`
+	Reason       string   `json:"reason,omitempty"`
+	UserID       string   `json:"userId,omitempty"`
+	CreatedAt    string   `json:"createdAt" format:"date-time"`
+}
+
+// CreateReservationDTO reserves units of a product, e.g. for an order being
+// checked out. Without expiresAt the reservation lasts until released.
+type CreateReservationDTO struct {
+	ProductID   int        `json:"productId" validate:"required,gt=0"`
+	Quantity    int        `json:"quantity" validate:"required,positive"`
+	ReferenceID string     `json:"referenceId,omitempty" validate:"max=100"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty" format:"date-time" validate:"omitempty,future"`
+}
+
+// ListReservationsDTO filters the active reservations. Zero values match
+// every reservation.
+type ListReservationsDTO struct {
+	ProductID      int
+	ReservedBefore time.Time
+}
+
+type StockBalanceDTO struct {
+	ProductID   int    `json:"productId"`
+	ProductName string `json:"productName"`
+	OnHand      int    `json:"onHand"`
+	Reserved    int    `json:"reserved"`
+	Available   int    `json:"available"`
+}
+
+type StockReservationDTO struct {
+	ID          int    `json:"id"`
+	ProductID   int    `json:"productId"`
+	ReservedQty int    `json:"reservedQty"`
+	ReferenceID string `json:"referenceId,omitempty"`
+	ReservedAt  string `json:"reservedAt" format:"date-time"`
+	ExpiresAt   string `json:"expiresAt,omitempty" format:"date-time"`
+	Status      string `json:"status"`
+	UserID      string `json:"userId,omitempty"`
+}
@@ -0,0 +1,214 @@
+// Package export encodes the rows of bulk exports as CSV, NDJSON or Parquet
+// while they are streamed, so an export never holds more than a Parquet row
+// group in memory.
+package export
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/parquet"
+)
+
+const (
+	FormatCSV     = "csv"
+	FormatNDJSON  = "ndjson"
+	FormatParquet = "parquet"
+)
+
+// Table describes the columns of an export and how a record becomes a row,
+// holding one value per column: nil for a missing optional value.
+type Table[T any] struct {
+	Name    string
+	Columns []parquet.Column
+	Row     func(T) []any
+}
+
+// Encoder writes the rows of a table in an export format.
+type Encoder interface {
+	Write(row []any) error
+	// Close writes what is still buffered and the end of the file. It does
+	// not close the underlying writer.
+	Close() error
+}
+
+// NewEncoder returns an encoder of format writing to w. Compressed CSV and
+// NDJSON are gzipped as a whole, while Parquet files compress their pages
+// and stay readable as Parquet.
+func NewEncoder(w io.Writer, format string, columns []parquet.Column, compress bool) (Encoder, error) {
+	if format == FormatParquet {
+		codec := parquet.Uncompressed
+		if compress {
+			codec = parquet.Gzip
+		}
+		return parquet.NewWriter(w, columns, codec), nil
+	}
+
+	var zw *gzip.Writer
+	if compress {
+		zw = gzip.NewWriter(w)
+		w = zw
+	}
+	var enc Encoder
+	switch format {
+	case FormatCSV:
+		enc = newCSVEncoder(w, columns)
+	case FormatNDJSON:
+		enc = newNDJSONEncoder(w, columns)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+	if zw != nil {
+		enc = gzipEncoder{Encoder: enc, zw: zw}
+	}
+	return enc, nil
+}
+
+// ContentType returns the media type of a file of format.
+func ContentType(format string, compress bool) string {
+	switch {
+	case format == FormatParquet:
+		return "application/vnd.apache.parquet"
+	case compress:
+		return "application/gzip"
+	case format == FormatCSV:
+		return "text/csv; charset=utf-8"
+	default:
+		return "application/x-ndjson"
+	}
+}
+
+// FileName returns the name of the file of an export of name.
+func FileName(name, format string, compress bool) string {
+	fileName := name + "." + format
+	if compress && format != FormatParquet {
+		fileName += ".gz"
+	}
+	return fileName
+}
+
+type gzipEncoder struct {
+	Encoder
+	zw *gzip.Writer
+}
+
+func (e gzipEncoder) Close() error {
+	if err := e.Encoder.Close(); err != nil {
+		return err
+	}
+	return e.zw.Close()
+}
+
+// csvEncoder writes a header row of the column names, then a record per
+// row with empty fields for missing values.
+type csvEncoder struct {
+	w       *csv.Writer
+	columns []parquet.Column
+	record  []string
+	started bool
+}
+
+func newCSVEncoder(w io.Writer, columns []parquet.Column) *csvEncoder {
+	return &csvEncoder{w: csv.NewWriter(w), columns: columns, record: make([]string, len(columns))}
+}
+
+func (e *csvEncoder) Write(row []any) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	for i, value := range row {
+		e.record[i] = formatValue(value)
+	}
+	return e.w.Write(e.record)
+}
+
+func (e *csvEncoder) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	for i, column := range e.columns {
+		e.record[i] = column.Name
+	}
+	return e.w.Write(e.record)
+}
+
+func (e *csvEncoder) Close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return formatTime(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// formatTime keeps the full precision of timestamps, which order the
+// movement ledger.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// ndjsonEncoder writes a JSON object per row, keyed by column name in column
+// order. Missing values are null.
+type ndjsonEncoder struct {
+	w    *bufio.Writer
+	keys [][]byte
+	line []byte
+}
+
+func newNDJSONEncoder(w io.Writer, columns []parquet.Column) *ndjsonEncoder {
+	keys := make([][]byte, len(columns))
+	for i, column := range columns {
+		key, _ := json.Marshal(column.Name)
+		keys[i] = append(key, ':')
+	}
+	return &ndjsonEncoder{w: bufio.NewWriter(w), keys: keys}
+}
+
+func (e *ndjsonEncoder) Write(row []any) error {
+	line := append(e.line[:0], '{')
+	for i, value := range row {
+		if i > 0 {
+			line = append(line, ',')
+		}
+		line = append(line, e.keys[i]...)
+		if t, ok := value.(time.Time); ok {
+			value = formatTime(t)
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		line = append(line, encoded...)
+	}
+	line = append(line, '}', '\n')
+	e.line = line
+	_, err := e.w.Write(line)
+	return err
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return e.w.Flush()
+}
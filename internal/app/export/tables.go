@@ -0,0 +1,86 @@
+package export
+
+import (
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/parquet"
+)
+
+// The columns are named after the fields of the JSON API.
+
+var Products = Table[*domain.Product]{
+	Name: "products",
+	Columns: []parquet.Column{
+		{Name: "id", Type: parquet.Int64},
+		{Name: "sku", Type: parquet.String, Optional: true},
+		{Name: "name", Type: parquet.String},
+		{Name: "description", Type: parquet.String, Optional: true},
+		{Name: "price", Type: parquet.Double},
+		{Name: "categoryId", Type: parquet.Int64},
+		{Name: "createdAt", Type: parquet.Timestamp},
+		{Name: "updatedAt", Type: parquet.Timestamp, Optional: true},
+		{Name: "deletedAt", Type: parquet.Timestamp, Optional: true},
+	},
+	Row: func(p *domain.Product) []any {
+		var deletedAt any
+		if p.DeletedAt.Valid {
+			deletedAt = p.DeletedAt.Time
+		}
+		return []any{
+			p.ID, optionalString(p.SKU), p.Name, optionalString(p.Description), p.Price, p.CategoryID,
+			p.CreatedAt, optionalTime(p.UpdatedAt), deletedAt,
+		}
+	},
+}
+
+var StockLevels = Table[*domain.StockLevel]{
+	Name: "stock-levels",
+	Columns: []parquet.Column{
+		{Name: "productId", Type: parquet.Int64},
+		{Name: "quantity", Type: parquet.Int64},
+		{Name: "updatedAt", Type: parquet.Timestamp},
+	},
+	Row: func(l *domain.StockLevel) []any {
+		return []any{l.ProductID, l.Quantity, l.UpdatedAt}
+	},
+}
+
+var Movements = Table[*domain.StockMovement]{
+	Name: "movements",
+	Columns: []parquet.Column{
+		{Name: "id", Type: parquet.Int64},
+		{Name: "productId", Type: parquet.Int64},
+		{Name: "movementType", Type: parquet.String},
+		{Name: "quantity", Type: parquet.Int64},
+		{Name: "unitCost", Type: parquet.Double, Optional: true},
+		{Name: "reason", Type: parquet.String, Optional: true},
+		{Name: "userId", Type: parquet.String, Optional: true},
+		{Name: "createdAt", Type: parquet.Timestamp},
+	},
+	Row: func(m *domain.StockMovement) []any {
+		var unitCost any
+		if m.UnitCost != nil {
+			unitCost = *m.UnitCost
+		}
+		return []any{
+			m.ID, m.ProductID, m.MovementType, m.Quantity, unitCost,
+			optionalString(m.Reason), optionalString(m.UserID), m.CreatedAt,
+		}
+	},
+}
+
+// optionalString returns nil for an empty string, which the API omits too.
+func optionalString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func optionalTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
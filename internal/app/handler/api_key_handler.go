@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/apperror"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/mapper"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type APIKeyHandler struct {
+	apiKeyUsecase *usecase.APIKeyUsecase
+}
+
+func NewAPIKeyHandler(apiKeyUsecase *usecase.APIKeyUsecase) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyUsecase: apiKeyUsecase}
+}
+
+// CreateAPIKey issues an API key
+// @Summary Create API Key
+// @Description Issues an API key for another service. The key is only returned by this call; store it safely. Scopes: catalog:read, reports:read, stock:read, stock:reserve, stock:write.
+// @ID create_api_key
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param apiKey body dtos.CreateAPIKeyDTO true "API key to be created"
+// @Success 201 {object} dtos.IssuedAPIKeyDTO "Created key and its secret"
+// @Security BearerAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var createAPIKeyDTO dtos.CreateAPIKeyDTO
+
+	if err := c.ShouldBindJSON(&createAPIKeyDTO); err != nil {
+		_ = c.Error(apperror.InvalidPayload(err))
+		return
+	}
+
+	key, plaintext, err := h.apiKeyUsecase.CreateAPIKey(c.Request.Context(), createAPIKeyDTO)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ToIssuedAPIKeyDTO(key, plaintext))
+}
+
+// ListAPIKeys lists API keys
+// @Summary List API Keys
+// @Description Lists every API key, revoked ones included, newest first. Secrets are never returned.
+// @ID list_api_keys
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Success 200 {array} dtos.APIKeyDTO "API keys"
+// @Security BearerAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	keys, err := h.apiKeyUsecase.ListAPIKeys(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToSlice(keys, mapper.ToAPIKeyDTO))
+}
+
+// RevokeAPIKey revokes an API key
+// @Summary Revoke API Key
+// @Description Revokes an API key. Requests using it are rejected from then on.
+// @ID revoke_api_key
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param id path string true "API key ID" format(uuid)
+// @Success 204
+// @Security BearerAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	if err := h.apiKeyUsecase.RevokeAPIKey(c.Request.Context(), id.String()); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// RotateAPIKey replaces the secret of an API key
+// @Summary Rotate API Key
+// @Description Issues a new secret for an API key, keeping its ID and scopes. The previous secret stops working immediately.
+// @ID rotate_api_key
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param id path string true "API key ID" format(uuid)
+// @Success 200 {object} dtos.IssuedAPIKeyDTO "Rotated key and its new secret"
+// @Security BearerAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /api-keys/{id}/rotate [post]
+func (h *APIKeyHandler) RotateAPIKey(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	key, plaintext, err := h.apiKeyUsecase.RotateAPIKey(c.Request.Context(), id.String())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToIssuedAPIKeyDTO(key, plaintext))
+}
@@ -5,8 +5,10 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/apperror"
 	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
-	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/mapper"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
 	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/usecase"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -14,11 +16,10 @@ import (
 
 type CategoryHandler struct {
 	categoryUsecase *usecase.CategoryUsecase
-	logger          *zap.Logger
 }
 
-func NewCategoryHandler(categoryUsecase *usecase.CategoryUsecase, logger *zap.Logger) *CategoryHandler {
-	return &CategoryHandler{categoryUsecase: categoryUsecase, logger: logger}
+func NewCategoryHandler(categoryUsecase *usecase.CategoryUsecase) *CategoryHandler {
+	return &CategoryHandler{categoryUsecase: categoryUsecase}
 }
 
 // CreateCategory creates a new category
@@ -30,54 +31,62 @@ func NewCategoryHandler(categoryUsecase *usecase.CategoryUsecase, logger *zap.Lo
 // @Produce json
 // @Param category body dtos.CreateCategoryDTO true "Category to be created"
 // @Success 201 {object} dtos.CategoryDTO
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
 // @Router /categories [post]
 func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 	var createCategoryDTO dtos.CreateCategoryDTO
 
 	if err := c.ShouldBindJSON(&createCategoryDTO); err != nil {
-		h.logger.Error(
-			"Failed to decode payload for category creation",
-			zap.Error(err),
-		)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		_ = c.Error(apperror.InvalidPayload(err))
 		return
 	}
 
 	category, err := h.categoryUsecase.CreateCategory(c.Request.Context(), createCategoryDTO)
 	if err != nil {
-		h.logger.Error(
-			"Internal error while creating category",
-			zap.Error(err),
-			zap.Any("payload", createCategoryDTO),
-		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
+		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, category)
+	c.JSON(http.StatusCreated, mapper.ToCategoryDTO(category))
 }
 
-// ListCategories lists all categories
+// ListCategories lists categories
 // @Summary List Categories
-// @Description Retrieves a list of all categories
+// @Description Retrieves a page of categories, excluding soft-deleted ones unless includeDeleted is set. Use either offset or the nextCursor of the previous page to move forward.
 // @ID list_categories
 // @Tags categories
 // @Accept json
 // @Produce json
-// @Success 200 {array} dtos.CategoryDTO "List of categories"
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Number of categories to skip"
+// @Param cursor query string false "Cursor returned as nextCursor by the previous page"
+// @Param sort query string false "Sort field" Enums(name, createdAt) default(name)
+// @Param order query string false "Sort direction" Enums(asc, desc) default(asc)
+// @Param q query string false "Only names containing this text (case-insensitive)"
+// @Param prefix query string false "Only names starting with this text (case-insensitive)"
+// @Param includeDeleted query bool false "Include soft-deleted categories"
+// @Success 200 {object} dtos.PageDTO{data=[]dtos.CategoryDTO} "Page of categories"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
 // @Router /categories [get]
 func (h *CategoryHandler) ListCategories(c *gin.Context) {
-	categories, err := h.categoryUsecase.ListCategories(c.Request.Context())
+	var listCategoriesDTO dtos.ListCategoriesDTO
+
+	if err := c.ShouldBindQuery(&listCategoriesDTO); err != nil {
+		_ = c.Error(apperror.InvalidQuery(err))
+		return
+	}
+
+	page, err := h.categoryUsecase.ListCategories(c.Request.Context(), listCategoriesDTO)
 	if err != nil {
-		h.logger.Error(
-			"Internal error while listing categories",
-			zap.Error(err),
-		)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
+		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, categories)
+	c.JSON(http.StatusOK, mapper.ToPageDTO(page, mapper.ToCategoryDTO))
 }
 
 // GetCategoryByID find category by ID
@@ -89,47 +98,84 @@ func (h *CategoryHandler) ListCategories(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Category ID"
 // @Success 200 {object} dtos.CategoryDTO "Category found"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
 // @Router /categories/{id} [get]
 func (h *CategoryHandler) GetCategoryByID(c *gin.Context) {
 	idStr := c.Param("id")
 
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		h.logger.Warn(
-			"Invalid ID format when fetching category",
-			zap.String("idStr", idStr),
-			zap.Error(err),
-		)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		_ = c.Error(apperror.ErrInvalidID.Wrap(err))
 		return
 	}
 
 	category, err := h.categoryUsecase.GetCategoryByID(c.Request.Context(), id)
 	if err != nil {
-		switch err {
-		case domain.ErrCategoryNotFound:
-			h.logger.Info(
-				"Category not found when searching by ID",
-				zap.Int("id", id),
-			)
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		default:
-			h.logger.Error(
-				"Internal error while fetching category by ID",
-				zap.Int("id", id),
-				zap.Error(err),
-			)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
-		}
-		return
-	}
-
-	c.JSON(http.StatusOK, category)
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToCategoryDTO(category))
+}
+
+// GetCategoryTree returns the category hierarchy
+// @Summary Category Tree
+// @Description Retrieves all categories nested under their parents
+// @ID get_category_tree
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Success 200 {array} dtos.CategoryTreeDTO "Root categories with their subcategories"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /categories/tree [get]
+func (h *CategoryHandler) GetCategoryTree(c *gin.Context) {
+	tree, err := h.categoryUsecase.GetCategoryTree(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToCategoryTreeDTOs(tree))
+}
+
+// ListDescendants lists every subcategory of a category
+// @Summary List Category Descendants
+// @Description Retrieves all subcategories of a category at any depth
+// @ID list_category_descendants
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {array} dtos.CategoryDTO "List of subcategories"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /categories/{id}/descendants [get]
+func (h *CategoryHandler) ListDescendants(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	descendants, err := h.categoryUsecase.ListDescendants(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToSlice(descendants, mapper.ToCategoryDTO))
 }
 
 // UpdateCategory updates an existing category
 // @Summary Update Category
-// @Description Updates an existing category
+// @Description Renames an existing category and optionally moves it under another parent (parentId 0 moves it to the root)
 // @ID update_category
 // @Tags categories
 // @Accept json
@@ -137,6 +183,9 @@ func (h *CategoryHandler) GetCategoryByID(c *gin.Context) {
 // @Param id path int true "Category ID"
 // @Param category body dtos.UpdateCategoryDTO true "Category to be updated"
 // @Success 204
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
 // @Router /categories/{id} [patch]
 func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 
@@ -144,46 +193,20 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		h.logger.Warn(
-			"Invalid ID format when updating category",
-			zap.String("idStr", idStr),
-			zap.Error(err),
-		)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		_ = c.Error(apperror.ErrInvalidID.Wrap(err))
 		return
 	}
 
 	var updateCategoryDTO dtos.UpdateCategoryDTO
 
 	if err := c.ShouldBindJSON(&updateCategoryDTO); err != nil {
-		h.logger.Error(
-			"Failed to decode payload for category update",
-			zap.Error(err),
-			zap.Int("categoryID", id),
-		)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+		_ = c.Error(apperror.InvalidPayload(err))
 		return
 	}
 
 	err = h.categoryUsecase.UpdateCategory(c.Request.Context(), id, updateCategoryDTO)
 	if err != nil {
-		switch err {
-		case domain.ErrInvalidCategoryName:
-			h.logger.Warn(
-				"Invalid category name on update",
-				zap.Int("categoryID", id),
-				zap.Error(err),
-			)
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		default:
-			h.logger.Error(
-				"Internal error while updating category",
-				zap.Int("categoryID", id),
-				zap.Error(err),
-				zap.Any("payload", updateCategoryDTO),
-			)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
-		}
+		_ = c.Error(err)
 		return
 	}
 
@@ -192,47 +215,83 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 
 // DeleteCategory deletes an existing category
 // @Summary Delete Category
-// @Description Deletes an existing category
+// @Description Deletes an existing category. With the default restrict policy the request fails with 409 while products or subcategories reference the category; reassign moves them to reassignTo first; soft hides the category but keeps product references.
 // @ID delete_category
 // @Tags categories
 // @Accept json
 // @Produce json
 // @Param id path int true "Category ID"
+// @Param policy query string false "Deletion policy" Enums(restrict, reassign, soft) default(restrict)
+// @Param reassignTo query int false "Target category ID for the reassign policy"
 // @Success 204
+// @Failure 409 {object} dtos.ProblemDTO{details=dtos.CategoryInUseDTO} "Category still referenced"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
 // @Router /categories/{id} [delete]
 func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 	idStr := c.Param("id")
 
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		h.logger.Warn(
-			"Invalid ID format when deleting category",
-			zap.String("idStr", idStr),
-			zap.Error(err),
-		)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		_ = c.Error(apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	var deleteCategoryDTO dtos.DeleteCategoryDTO
+
+	if err := c.ShouldBindQuery(&deleteCategoryDTO); err != nil {
+		_ = c.Error(apperror.InvalidQuery(err))
 		return
 	}
 
-	err = h.categoryUsecase.DeleteCategory(c.Request.Context(), id)
+	err = h.categoryUsecase.DeleteCategory(c.Request.Context(), id, deleteCategoryDTO)
 	if err != nil {
-		switch err {
-		case domain.ErrCategoryNotFound:
-			h.logger.Info(
-				"Category not found when deleting",
-				zap.Int("categoryID", id),
-			)
-			c.JSON(http.StatusNoContent, nil)
-		default:
-			h.logger.Error(
-				"Internal error while deleting category",
-				zap.Int("categoryID", id),
-				zap.Error(err),
-			)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal Server Error"})
-		}
+		_ = c.Error(err)
 		return
 	}
 
+	logging.FromContext(c.Request.Context()).Info(
+		"Category deleted",
+		zap.Int("categoryID", id),
+		zap.Any("query", deleteCategoryDTO),
+	)
+
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// RestoreCategory restores a soft-deleted category
+// @Summary Restore Category
+// @Description Restores a soft-deleted category. Its parent category must not be deleted, and no active category may have taken its name meanwhile.
+// @ID restore_category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} dtos.CategoryDTO "Restored category"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /categories/{id}/restore [post]
+func (h *CategoryHandler) RestoreCategory(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	category, err := h.categoryUsecase.RestoreCategory(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	logging.FromContext(c.Request.Context()).Info(
+		"Category restored",
+		zap.Int("categoryID", id),
+	)
+
+	c.JSON(http.StatusOK, mapper.ToCategoryDTO(category))
+}
@@ -0,0 +1,601 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/middleware"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/validation"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/health"
+	apiKeyRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/APIKeyRepository"
+	categoryRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/CategoryRepository"
+	costLayerRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/CostLayerRepository"
+	importJobRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/ImportJobRepository"
+	productRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/ProductRepository"
+	stockLevelRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockLevelRepository"
+	stockMovementRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockMovementRepository"
+	stockReservationRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockReservationRepository"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const swaggerPath = "../../../api/docs/swagger.json"
+
+type contractRepos struct {
+	category      *categoryRepositoryMock.MockCategoryRepository
+	product       *productRepositoryMock.MockProductRepository
+	stockLevel    *stockLevelRepositoryMock.MockStockLevelRepository
+	stockMovement *stockMovementRepositoryMock.MockStockMovementRepository
+	reservation   *stockReservationRepositoryMock.MockStockReservationRepository
+	costLayer     *costLayerRepositoryMock.MockCostLayerRepository
+	apiKey        *apiKeyRepositoryMock.MockAPIKeyRepository
+	importJob     *importJobRepositoryMock.MockImportJobRepository
+}
+
+// TestResponsesMatchSwagger checks handler responses against the schemas
+// that the generated swagger.json declares for the same route and status.
+func TestResponsesMatchSwagger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	binding.Validator = validation.NewStructValidator()
+
+	spec := loadSwagger(t)
+
+	now := time.Date(2025, 3, 14, 9, 26, 53, 0, time.UTC)
+	parentID := 1
+	unitCost := 2.5
+
+	tests := []struct {
+		method    string
+		route     string
+		url       string
+		body      string
+		mockSetup func(r contractRepos)
+		status    int
+	}{
+		{
+			method: http.MethodPost, route: "/categories", url: "/categories",
+			body: `{"name":"Chairs","parentId":1}`,
+			mockSetup: func(r contractRepos) {
+				r.category.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1, Name: "Furniture"}, nil)
+				r.category.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					c := args.Get(1).(*domain.Category)
+					c.ID, c.CreatedAt = 2, now
+				}).Return(nil)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			method: http.MethodGet, route: "/categories", url: "/categories?limit=1",
+			mockSetup: func(r contractRepos) {
+				r.category.On("List", mock.Anything, mock.Anything).Return(&domain.Page[*domain.Category]{
+					Items: []*domain.Category{{ID: 1, Name: "Furniture", CreatedAt: now, UpdatedAt: &now}},
+					Total: 2, Limit: 1, NextCursor: "abc",
+				}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodGet, route: "/categories/{id}", url: "/categories/2",
+			mockSetup: func(r contractRepos) {
+				r.category.On("GetByID", mock.Anything, 2).Return(&domain.Category{ID: 2, Name: "Chairs", ParentID: &parentID, CreatedAt: now}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodGet, route: "/categories/{id}", url: "/categories/9",
+			mockSetup: func(r contractRepos) {
+				r.category.On("GetByID", mock.Anything, 9).Return(nil, domain.ErrCategoryNotFound)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			method: http.MethodGet, route: "/categories/tree", url: "/categories/tree",
+			mockSetup: func(r contractRepos) {
+				r.category.On("ListAll", mock.Anything, false).Return([]*domain.Category{
+					{ID: 1, Name: "Furniture"},
+					{ID: 2, Name: "Chairs", ParentID: &parentID},
+				}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodGet, route: "/categories/{id}/descendants", url: "/categories/1/descendants",
+			mockSetup: func(r contractRepos) {
+				r.category.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1}, nil)
+				r.category.On("ListDescendants", mock.Anything, 1, false).Return([]*domain.Category{{ID: 2, Name: "Chairs", ParentID: &parentID, CreatedAt: now}}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodDelete, route: "/categories/{id}", url: "/categories/1",
+			mockSetup: func(r contractRepos) {
+				r.category.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1}, nil)
+				r.category.On("ListDescendants", mock.Anything, 1, true).Return([]*domain.Category{{ID: 2, Name: "Chairs"}}, nil)
+				r.product.On("ListByCategoryIDs", mock.Anything, []int{1}, true).Return([]*domain.Product{{ID: 5, Name: "Desk"}}, nil)
+			},
+			status: http.StatusConflict,
+		},
+		{
+			method: http.MethodPost, route: "/categories/{id}/restore", url: "/categories/2/restore",
+			mockSetup: func(r contractRepos) {
+				r.category.On("GetDeletedByID", mock.Anything, 2).Return(&domain.Category{ID: 2}, nil)
+				r.category.On("Restore", mock.Anything, 2).Return(nil)
+				r.category.On("GetByID", mock.Anything, 2).Return(&domain.Category{ID: 2, Name: "Chairs", CreatedAt: now}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodPost, route: "/categories/{id}/restore", url: "/categories/3/restore",
+			mockSetup: func(r contractRepos) {
+				r.category.On("GetDeletedByID", mock.Anything, 3).Return(&domain.Category{ID: 3, Name: "Chairs"}, nil)
+				r.category.On("Restore", mock.Anything, 3).Return(domain.ErrCategoryNameTaken)
+			},
+			status: http.StatusConflict,
+		},
+		{
+			method: http.MethodGet, route: "/products", url: "/products",
+			mockSetup: func(r contractRepos) {
+				r.product.On("List", mock.Anything, []int(nil), mock.Anything).Return(&domain.Page[*domain.Product]{
+					Items: []*domain.Product{{ID: 5, Name: "Desk", Price: 120, CategoryID: 1, CreatedAt: now, UpdatedAt: now}},
+					Total: 1, Limit: 20,
+				}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodGet, route: "/products/search", url: "/products/search?q=desk",
+			mockSetup: func(r contractRepos) {
+				r.product.On("Search", mock.Anything, mock.Anything).Return(&domain.ProductSearchResult{
+					Hits:           []domain.ProductSearchHit{{Product: &domain.Product{ID: 5, Name: "Desk", CreatedAt: now}, Rank: 0.6}},
+					Total:          1,
+					Limit:          20,
+					CategoryFacets: []domain.CategoryFacet{{CategoryID: 1, CategoryName: "Furniture", Count: 1}},
+					PriceFacets:    domain.PriceRangeFacets(),
+				}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodPost, route: "/products/{id}/restore", url: "/products/5/restore",
+			mockSetup: func(r contractRepos) {
+				r.product.On("GetDeletedByID", mock.Anything, 5).Return(&domain.Product{ID: 5}, nil)
+				r.product.On("Restore", mock.Anything, 5).Return(nil)
+				r.product.On("GetByID", mock.Anything, 5).Return(&domain.Product{ID: 5, Name: "Desk", CreatedAt: now}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodPost, route: "/products", url: "/products",
+			body: `{"name":"Oak chair","sku":"CHAIR-OAK-01","price":120,"categoryId":2}`,
+			mockSetup: func(r contractRepos) {
+				r.category.On("GetByID", mock.Anything, 2).Return(&domain.Category{ID: 2}, nil)
+				r.product.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					p := args.Get(1).(*domain.Product)
+					p.ID, p.CreatedAt, p.UpdatedAt = 8, now, now
+				}).Return(nil)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			method: http.MethodPost, route: "/products", url: "/products",
+			body:   `{"name":"Oak chair","sku":"chair oak","price":120,"categoryId":2}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			method: http.MethodPatch, route: "/products/{id}", url: "/products/8",
+			body: `{"name":"Oak chair","sku":"CHAIR-OAK-02","price":130,"categoryId":2}`,
+			mockSetup: func(r contractRepos) {
+				r.product.On("GetByID", mock.Anything, 8).Return(&domain.Product{ID: 8, SKU: "CHAIR-OAK-01", CategoryID: 2, CreatedAt: now}, nil)
+				r.product.On("Update", mock.Anything, mock.Anything).Return(domain.ErrProductSKUTaken)
+			},
+			status: http.StatusConflict,
+		},
+		{
+			method: http.MethodPost, route: "/products/{id}/restore", url: "/products/6/restore",
+			mockSetup: func(r contractRepos) {
+				r.product.On("GetDeletedByID", mock.Anything, 6).Return(&domain.Product{ID: 6, SKU: "DSK-1"}, nil)
+				r.product.On("Restore", mock.Anything, 6).Return(domain.ErrProductSKUTaken)
+			},
+			status: http.StatusConflict,
+		},
+		{
+			method: http.MethodPost, route: "/stock/movements", url: "/stock/movements",
+			body: `{"productId":5,"movementType":"receipt","quantity":4,"unitCost":2.5}`,
+			mockSetup: func(r contractRepos) {
+				r.product.On("GetByID", mock.Anything, 5).Return(&domain.Product{ID: 5}, nil)
+				r.stockLevel.On("LockByProductID", mock.Anything, 5).Return(&domain.StockLevel{ProductID: 5}, nil)
+				r.costLayer.On("ListOpenByProductID", mock.Anything, 5).Return([]*domain.CostLayer{}, nil)
+				r.stockMovement.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					m := args.Get(1).(*domain.StockMovement)
+					m.ID, m.CreatedAt = 7, now
+				}).Return(nil)
+				r.costLayer.On("Create", mock.Anything, mock.Anything).Return(nil)
+				r.stockLevel.On("UpdateQuantity", mock.Anything, mock.Anything).Return(nil)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			method: http.MethodPost, route: "/stock/movements", url: "/stock/movements",
+			body:   `{"productId":5,"movementType":"transfer","quantity":4}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			method: http.MethodPost, route: "/stock/reservations", url: "/stock/reservations",
+			body: `{"productId":5,"quantity":3,"referenceId":"order-1042"}`,
+			mockSetup: func(r contractRepos) {
+				r.product.On("GetByID", mock.Anything, 5).Return(&domain.Product{ID: 5}, nil)
+				r.stockLevel.On("LockByProductID", mock.Anything, 5).Return(&domain.StockLevel{ProductID: 5, Quantity: 4}, nil)
+				r.reservation.On("ListActiveByProduct", mock.Anything, 5).Return([]*domain.StockReservation{}, nil)
+				r.reservation.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					res := args.Get(1).(*domain.StockReservation)
+					res.ID, res.ReservedAt, res.Status = 11, now, domain.ReservationStatusActive
+				}).Return(nil)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			method: http.MethodPost, route: "/stock/reservations", url: "/stock/reservations",
+			body: `{"productId":5,"quantity":3}`,
+			mockSetup: func(r contractRepos) {
+				r.product.On("GetByID", mock.Anything, 5).Return(&domain.Product{ID: 5}, nil)
+				r.stockLevel.On("LockByProductID", mock.Anything, 5).Return(&domain.StockLevel{ProductID: 5, Quantity: 4}, nil)
+				r.reservation.On("ListActiveByProduct", mock.Anything, 5).Return([]*domain.StockReservation{{ProductID: 5, ReservedQty: 2}}, nil)
+			},
+			status: http.StatusConflict,
+		},
+		{
+			method: http.MethodGet, route: "/stock/movements/{productId}", url: "/stock/movements/5",
+			mockSetup: func(r contractRepos) {
+				r.product.On("GetByID", mock.Anything, 5).Return(&domain.Product{ID: 5}, nil)
+				r.stockMovement.On("ListByProductID", mock.Anything, 5, mock.Anything).Return(&domain.Page[*domain.StockMovement]{
+					Items: []*domain.StockMovement{{ID: 7, ProductID: 5, MovementType: "receipt", Quantity: 4, UnitCost: &unitCost, CreatedAt: now}},
+					Total: 1, Limit: 20,
+				}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodGet, route: "/health/live", url: "/health/live",
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodGet, route: "/health/ready", url: "/health/ready",
+			status: http.StatusServiceUnavailable,
+		},
+		{
+			method: http.MethodGet, route: "/reports/valuation", url: "/reports/valuation?asOf=2025-03-14",
+			mockSetup: func(r contractRepos) {
+				r.costLayer.On("ListOpening", mock.Anything).Return([]*domain.CostLayer{}, nil)
+				r.stockMovement.On("ListUntil", mock.Anything, mock.Anything).Return([]*domain.StockMovement{
+					{ID: 7, ProductID: 5, MovementType: "receipt", Quantity: 4, UnitCost: &unitCost, CreatedAt: now},
+				}, nil)
+				r.product.On("ListAll", mock.Anything, true).Return([]*domain.Product{{ID: 5, Name: "Desk", CategoryID: 1}}, nil)
+				r.category.On("ListAll", mock.Anything, true).Return([]*domain.Category{{ID: 1, Name: "Furniture"}}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodPost, route: "/api-keys", url: "/api-keys",
+			body: `{"name":"orders","scopes":["stock:reserve","catalog:read"]}`,
+			mockSetup: func(r contractRepos) {
+				r.apiKey.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					k := args.Get(1).(*domain.APIKey)
+					k.ID, k.CreatedAt = "5d0c1f0e-8a3b-4d8e-9c51-2f5e7a9b1c3d", now
+				}).Return(nil)
+			},
+			status: http.StatusCreated,
+		},
+		{
+			method: http.MethodGet, route: "/api-keys", url: "/api-keys",
+			mockSetup: func(r contractRepos) {
+				r.apiKey.On("List", mock.Anything).Return([]*domain.APIKey{
+					{ID: "5d0c1f0e-8a3b-4d8e-9c51-2f5e7a9b1c3d", Name: "orders", Prefix: "0a1b2c3d4e5f", Scopes: []string{"catalog:read"}, CreatedAt: now, LastUsedAt: &now},
+				}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodPost, route: "/api-keys/{id}/rotate", url: "/api-keys/5d0c1f0e-8a3b-4d8e-9c51-2f5e7a9b1c3d/rotate",
+			mockSetup: func(r contractRepos) {
+				r.apiKey.On("GetByID", mock.Anything, "5d0c1f0e-8a3b-4d8e-9c51-2f5e7a9b1c3d").Return(nil, domain.ErrAPIKeyNotFound)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			method: http.MethodPost, route: "/imports", url: "/imports?format=csv&dryRun=true",
+			body: "name,sku,category,price\nDesk,DSK-1,Furniture,120\n",
+			mockSetup: func(r contractRepos) {
+				r.importJob.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					j := args.Get(1).(*domain.ImportJob)
+					j.ID, j.CreatedAt = "9b2e4c1a-7d3f-4e8a-b5c6-1a2b3c4d5e6f", now
+				}).Return(nil)
+			},
+			status: http.StatusAccepted,
+		},
+		{
+			method: http.MethodPost, route: "/imports", url: "/imports",
+			body:   `{"name":"Desk"}`,
+			status: http.StatusUnsupportedMediaType,
+		},
+		{
+			method: http.MethodGet, route: "/imports/{id}", url: "/imports/9b2e4c1a-7d3f-4e8a-b5c6-1a2b3c4d5e6f",
+			mockSetup: func(r contractRepos) {
+				r.importJob.On("GetByID", mock.Anything, "9b2e4c1a-7d3f-4e8a-b5c6-1a2b3c4d5e6f").Return(&domain.ImportJob{
+					ID: "9b2e4c1a-7d3f-4e8a-b5c6-1a2b3c4d5e6f", Format: domain.ImportFormatCSV, Status: domain.ImportStatusFailed,
+					TotalRows: 2, ErrorCount: 1, Errors: []domain.ImportRowError{{Line: 3, Field: "price", Message: "cannot be negative"}},
+					CreatedAt: now, StartedAt: &now, FinishedAt: &now,
+				}, nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			method: http.MethodGet, route: "/exports/movements", url: "/exports/movements?from=yesterday&to=2026-01-31",
+			status: http.StatusBadRequest,
+		},
+		{
+			method: http.MethodGet, route: "/exports/movements", url: "/exports/movements?from=2026-02-01&to=2026-01-31",
+			status: http.StatusBadRequest,
+		},
+		{
+			method: http.MethodGet, route: "/exports/stock-levels", url: "/exports/stock-levels",
+			mockSetup: func(r contractRepos) {
+				r.stockLevel.On("Stream", mock.Anything, mock.Anything).Return(context.DeadlineExceeded)
+			},
+			status: http.StatusGatewayTimeout,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s %s %d", tc.method, tc.route, tc.status), func(t *testing.T) {
+			repos := contractRepos{
+				category:      categoryRepositoryMock.NewMockCategoryRepository(t),
+				product:       productRepositoryMock.NewMockProductRepository(t),
+				stockLevel:    stockLevelRepositoryMock.NewMockStockLevelRepository(t),
+				stockMovement: stockMovementRepositoryMock.NewMockStockMovementRepository(t),
+				reservation:   stockReservationRepositoryMock.NewMockStockReservationRepository(t),
+				costLayer:     costLayerRepositoryMock.NewMockCostLayerRepository(t),
+				apiKey:        apiKeyRepositoryMock.NewMockAPIKeyRepository(t),
+				importJob:     importJobRepositoryMock.NewMockImportJobRepository(t),
+			}
+			if tc.mockSetup != nil {
+				tc.mockSetup(repos)
+			}
+
+			r := newContractRouter(repos)
+			req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			require.Equal(t, tc.status, w.Code, w.Body.String())
+
+			schema := spec.responseSchema(t, tc.route, tc.method, tc.status)
+			var body any
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			for _, violation := range spec.validate(schema, body, "$") {
+				t.Error(violation)
+			}
+		})
+	}
+}
+
+// inlineTransactor runs units of work in place.
+type inlineTransactor struct{}
+
+func (inlineTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func newContractRouter(repos contractRepos) *gin.Engine {
+	categoryHandler := NewCategoryHandler(usecase.NewCategoryUsecase(repos.category, repos.product, inlineTransactor{}))
+	productHandler := NewProductHandler(usecase.NewProductUsecase(repos.product, repos.category))
+	stockHandler := NewStockHandler(usecase.NewStockMovementUsecase(repos.stockMovement, repos.stockLevel, repos.costLayer, repos.product, inlineTransactor{}, nil))
+	reservationHandler := NewReservationHandler(usecase.NewReservationUsecase(repos.reservation, repos.stockLevel, repos.product, inlineTransactor{}, nil))
+	reportHandler := NewReportHandler(usecase.NewValuationUsecase(repos.stockMovement, repos.costLayer, repos.product, repos.category))
+	apiKeyHandler := NewAPIKeyHandler(usecase.NewAPIKeyUsecase(repos.apiKey))
+	importHandler := NewImportHandler(usecase.NewProductImportUsecase(repos.importJob, repos.product, repos.category, nil, inlineTransactor{}, 100), 1<<20)
+	exportHandler := NewExportHandler(usecase.NewExportUsecase(repos.product, repos.stockLevel, repos.stockMovement), time.Minute)
+	healthHandler := NewHealthHandler(health.NewChecker(time.Second,
+		health.Check{Name: "postgres", Required: true, Probe: func(ctx context.Context) error { return nil }},
+		health.Check{Name: "kafka", Required: true, Probe: func(ctx context.Context) error { return errors.New("connection refused") }},
+	))
+
+	r := gin.New()
+	r.Use(middleware.RequestID(), middleware.ErrorHandler())
+	r.POST("/categories", categoryHandler.CreateCategory)
+	r.GET("/categories", categoryHandler.ListCategories)
+	r.GET("/categories/tree", categoryHandler.GetCategoryTree)
+	r.GET("/categories/:id", categoryHandler.GetCategoryByID)
+	r.GET("/categories/:id/descendants", categoryHandler.ListDescendants)
+	r.DELETE("/categories/:id", categoryHandler.DeleteCategory)
+	r.POST("/categories/:id/restore", categoryHandler.RestoreCategory)
+	r.POST("/products", productHandler.CreateProduct)
+	r.GET("/products", productHandler.ListProducts)
+	r.PATCH("/products/:id", productHandler.UpdateProduct)
+	r.GET("/products/search", productHandler.SearchProducts)
+	r.POST("/products/:id/restore", productHandler.RestoreProduct)
+	r.POST("/stock/movements", stockHandler.RecordMovement)
+	r.GET("/stock/movements/:productId", stockHandler.ListMovementsByProduct)
+	r.POST("/stock/reservations", reservationHandler.CreateReservation)
+	r.GET("/reports/valuation", reportHandler.GetValuationReport)
+	r.GET("/health/live", healthHandler.Live)
+	r.GET("/health/ready", healthHandler.Ready)
+	r.POST("/api-keys", apiKeyHandler.CreateAPIKey)
+	r.GET("/api-keys", apiKeyHandler.ListAPIKeys)
+	r.POST("/api-keys/:id/rotate", apiKeyHandler.RotateAPIKey)
+	r.POST("/imports", importHandler.CreateImport)
+	r.GET("/imports/:id", importHandler.GetImport)
+	r.GET("/exports/products", exportHandler.ExportProducts)
+	r.GET("/exports/stock-levels", exportHandler.ExportStockLevels)
+	r.GET("/exports/movements", exportHandler.ExportMovements)
+	return r
+}
+
+// swaggerSpec is the subset of a Swagger 2.0 document needed to check
+// responses.
+type swaggerSpec struct {
+	Paths       map[string]map[string]swaggerOperation `json:"paths"`
+	Definitions map[string]*schema                     `json:"definitions"`
+}
+
+type swaggerOperation struct {
+	Responses map[string]struct {
+		Schema *schema `json:"schema"`
+	} `json:"responses"`
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *schema            `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	Required             []string           `json:"required"`
+	AllOf                []*schema          `json:"allOf"`
+	Enum                 []any              `json:"enum"`
+}
+
+func loadSwagger(t *testing.T) *swaggerSpec {
+	t.Helper()
+	data, err := os.ReadFile(swaggerPath)
+	require.NoError(t, err)
+	var spec swaggerSpec
+	require.NoError(t, json.Unmarshal(data, &spec))
+	return &spec
+}
+
+// responseSchema returns the schema documented for status, falling back to
+// the default response.
+func (s *swaggerSpec) responseSchema(t *testing.T, route, method string, status int) *schema {
+	t.Helper()
+	op, ok := s.Paths[route][strings.ToLower(method)]
+	require.True(t, ok, "%s %s is not documented", method, route)
+	response, ok := op.Responses[fmt.Sprint(status)]
+	if !ok {
+		response, ok = op.Responses["default"]
+	}
+	require.True(t, ok, "%s %s does not document status %d", method, route, status)
+	require.NotNil(t, response.Schema, "%s %s documents status %d without a schema", method, route, status)
+	return response.Schema
+}
+
+// resolve follows references and merges allOf parts into a single schema.
+func (s *swaggerSpec) resolve(sc *schema) *schema {
+	if sc.Ref != "" {
+		return s.resolve(s.Definitions[strings.TrimPrefix(sc.Ref, "#/definitions/")])
+	}
+	if len(sc.AllOf) == 0 {
+		return sc
+	}
+	merged := &schema{Type: "object", Properties: map[string]*schema{}}
+	for _, part := range sc.AllOf {
+		part = s.resolve(part)
+		for name, prop := range part.Properties {
+			merged.Properties[name] = prop
+		}
+		merged.Required = append(merged.Required, part.Required...)
+	}
+	return merged
+}
+
+var rfc3339Pattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`)
+
+// validate returns a description of every place where value does not match
+// sc. Objects may not carry properties the schema does not declare.
+func (s *swaggerSpec) validate(sc *schema, value any, path string) []string {
+	sc = s.resolve(sc)
+	var violations []string
+	fail := func(format string, args ...any) {
+		violations = append(violations, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	switch sc.Type {
+	case "":
+		if sc.Properties == nil {
+			return nil
+		}
+		fallthrough
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			fail("expected object, got %T", value)
+			return violations
+		}
+		for _, name := range sc.Required {
+			if _, ok := object[name]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+		for name, v := range object {
+			prop, ok := sc.Properties[name]
+			if !ok && sc.AdditionalProperties != nil {
+				prop, ok = sc.AdditionalProperties, true
+			}
+			if !ok {
+				if sc.Properties != nil || sc.AdditionalProperties != nil {
+					fail("undocumented property %q", name)
+				}
+				continue
+			}
+			violations = append(violations, s.validate(prop, v, path+"."+name)...)
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			fail("expected array, got %T", value)
+			return violations
+		}
+		for i, item := range items {
+			violations = append(violations, s.validate(sc.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			fail("expected string, got %T", value)
+		} else if sc.Format == "date-time" && !rfc3339Pattern.MatchString(str) {
+			fail("expected an RFC 3339 timestamp, got %q", str)
+		}
+	case "integer":
+		n, ok := value.(float64)
+		if !ok || n != math.Trunc(n) {
+			fail("expected integer, got %v", value)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			fail("expected number, got %T", value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("expected boolean, got %T", value)
+		}
+	}
+
+	if len(sc.Enum) > 0 && !containsValue(sc.Enum, value) {
+		fail("value %v is not one of %v", value, sc.Enum)
+	}
+	return violations
+}
+
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
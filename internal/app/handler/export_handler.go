@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/apperror"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/export"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+var errInvalidExportDate = apperror.New(http.StatusBadRequest, "invalid_export_date", "Invalid from or to date")
+
+type ExportHandler struct {
+	exportUsecase *usecase.ExportUsecase
+	timeout       time.Duration
+}
+
+// NewExportHandler returns a handler giving each export up to timeout to
+// stream.
+func NewExportHandler(exportUsecase *usecase.ExportUsecase, timeout time.Duration) *ExportHandler {
+	return &ExportHandler{exportUsecase: exportUsecase, timeout: timeout}
+}
+
+// ExportProducts streams the catalogue
+// @Summary Export Products
+// @Description Streams every product, by ID, as a CSV, NDJSON or Parquet file. Soft-deleted products are excluded unless includeDeleted is set. With compression=gzip, CSV and NDJSON files are gzipped and Parquet files compress their pages. An export failing midway aborts the connection, so a truncated file is never mistaken for a complete one.
+// @ID export_products
+// @Tags exports
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.apache.parquet
+// @Produce application/gzip
+// @Param format query string false "File format" Enums(csv, ndjson, parquet) default(csv)
+// @Param compression query string false "Compression" Enums(gzip)
+// @Param includeDeleted query bool false "Include soft-deleted products"
+// @Success 200 {file} file "Exported products"
+// @Header 200 {string} Content-Disposition "Attachment file name"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /exports/products [get]
+func (h *ExportHandler) ExportProducts(c *gin.Context) {
+	var query dtos.ProductExportQueryDTO
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(apperror.InvalidQuery(err))
+		return
+	}
+
+	streamExport(c, h.timeout, query.ExportQueryDTO, export.Products, func(ctx context.Context, fn func(*domain.Product) error) error {
+		return h.exportUsecase.ExportProducts(ctx, query.IncludeDeleted, fn)
+	})
+}
+
+// ExportStockLevels streams the stock levels
+// @Summary Export Stock Levels
+// @Description Streams the stock level of every product, by product ID, as a CSV, NDJSON or Parquet file. With compression=gzip, CSV and NDJSON files are gzipped and Parquet files compress their pages.
+// @ID export_stock_levels
+// @Tags exports
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.apache.parquet
+// @Produce application/gzip
+// @Param format query string false "File format" Enums(csv, ndjson, parquet) default(csv)
+// @Param compression query string false "Compression" Enums(gzip)
+// @Success 200 {file} file "Exported stock levels"
+// @Header 200 {string} Content-Disposition "Attachment file name"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /exports/stock-levels [get]
+func (h *ExportHandler) ExportStockLevels(c *gin.Context) {
+	var query dtos.ExportQueryDTO
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(apperror.InvalidQuery(err))
+		return
+	}
+
+	streamExport(c, h.timeout, query, export.StockLevels, h.exportUsecase.ExportStockLevels)
+}
+
+// ExportMovements streams the movement ledger
+// @Summary Export Stock Movements
+// @Description Streams the movements recorded within a date range, oldest first, as a CSV, NDJSON or Parquet file, optionally for a single product. A date as to includes the whole day. With compression=gzip, CSV and NDJSON files are gzipped and Parquet files compress their pages.
+// @ID export_movements
+// @Tags exports
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Produce application/vnd.apache.parquet
+// @Produce application/gzip
+// @Param from query string true "Start of the range (YYYY-MM-DD or RFC 3339)"
+// @Param to query string true "End of the range, included (YYYY-MM-DD or RFC 3339)"
+// @Param productId query int false "Only export the movements of this product"
+// @Param format query string false "File format" Enums(csv, ndjson, parquet) default(csv)
+// @Param compression query string false "Compression" Enums(gzip)
+// @Success 200 {file} file "Exported movements"
+// @Header 200 {string} Content-Disposition "Attachment file name"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /exports/movements [get]
+func (h *ExportHandler) ExportMovements(c *gin.Context) {
+	var query dtos.MovementExportQueryDTO
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(apperror.InvalidQuery(err))
+		return
+	}
+
+	from, err := parseFrom(query.From)
+	if err != nil {
+		_ = c.Error(errInvalidExportDate.Wrap(err))
+		return
+	}
+	to, err := parseAsOf(query.To)
+	if err != nil {
+		_ = c.Error(errInvalidExportDate.Wrap(err))
+		return
+	}
+
+	streamExport(c, h.timeout, query.ExportQueryDTO, export.Movements, func(ctx context.Context, fn func(*domain.StockMovement) error) error {
+		return h.exportUsecase.ExportMovements(ctx, from, to, query.ProductID, fn)
+	})
+}
+
+// parseFrom accepts a calendar date, meaning the start of that day, or a
+// full RFC 3339 timestamp.
+func parseFrom(value string) (time.Time, error) {
+	if day, err := time.Parse(dateLayout, value); err == nil {
+		return day, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// streamExport writes the records run hands out as a file attachment, with
+// timeout to do so in place of the query and write timeouts. The headers go
+// out with the first bytes of the file, so that a failure before them, such
+// as a denied permission, still gets a problem response. A failure after
+// them aborts the connection.
+func streamExport[T any](c *gin.Context, timeout time.Duration, query dtos.ExportQueryDTO, table export.Table[T], run func(context.Context, func(T) error) error) {
+	format := query.Format
+	if format == "" {
+		format = export.FormatCSV
+	}
+	compress := query.Compression == "gzip"
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+	defer cancel()
+	// Recorders and other writers without deadlines are fine to stream to.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(timeout))
+
+	w := &exportWriter{c: c, header: func(h http.Header) {
+		h.Set("Content-Type", export.ContentType(format, compress))
+		h.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+			"filename": export.FileName(table.Name, format, compress),
+		}))
+	}}
+	enc, err := export.NewEncoder(w, format, table.Columns, compress)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	rows := 0
+	err = run(ctx, func(record T) error {
+		rows++
+		return enc.Write(table.Row(record))
+	})
+	if err == nil {
+		err = enc.Close()
+	}
+
+	logger := logging.FromContext(c.Request.Context()).With(
+		zap.String("export", table.Name),
+		zap.String("format", format),
+		zap.Bool("compressed", compress),
+		zap.Int("rows", rows),
+	)
+	if err == nil {
+		logger.Info("Export finished")
+		return
+	}
+	if !w.started {
+		_ = c.Error(err)
+		return
+	}
+	if errors.Is(err, context.Canceled) {
+		logger.Warn("Export cancelled", zap.Error(err))
+	} else {
+		logger.Error("Export failed midway", zap.Error(err))
+	}
+	// Ending the response normally would hand a truncated file to the
+	// client as a complete one.
+	panic(http.ErrAbortHandler)
+}
+
+// exportWriter sends the response headers with the first bytes written.
+type exportWriter struct {
+	c       *gin.Context
+	header  func(http.Header)
+	started bool
+}
+
+func (w *exportWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.header(w.c.Writer.Header())
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(p)
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	productRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/ProductRepository"
+	stockLevelRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockLevelRepository"
+	stockMovementRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockMovementRepository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newExportRouter(t *testing.T) (*gin.Engine, contractRepos) {
+	gin.SetMode(gin.TestMode)
+	repos := contractRepos{
+		product:       productRepositoryMock.NewMockProductRepository(t),
+		stockLevel:    stockLevelRepositoryMock.NewMockStockLevelRepository(t),
+		stockMovement: stockMovementRepositoryMock.NewMockStockMovementRepository(t),
+	}
+	return newContractRouter(repos), repos
+}
+
+// streamRecords makes a Stream mock hand records to the callback passed as
+// its argument at index.
+func streamRecords[T any](index int, records ...T) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		fn := args.Get(index).(func(T) error)
+		for _, record := range records {
+			if err := fn(record); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func TestExportProductsCSV(t *testing.T) {
+	r, repos := newExportRouter(t)
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	repos.product.On("Stream", mock.Anything, false, mock.Anything).
+		Run(streamRecords(2,
+			&domain.Product{ID: 1, SKU: "DSK-1", Name: "Desk, oak", Price: 120, CategoryID: 3, CreatedAt: created, UpdatedAt: created},
+			&domain.Product{ID: 2, Name: "Lamp", Price: 19.99, CategoryID: 3, CreatedAt: created},
+		)).
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/exports/products", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename=products.csv", w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "id,sku,name,description,price,categoryId,createdAt,updatedAt,deletedAt\n"+
+		"1,DSK-1,\"Desk, oak\",,120,3,2026-03-01T09:00:00Z,2026-03-01T09:00:00Z,\n"+
+		"2,,Lamp,,19.99,3,2026-03-01T09:00:00Z,,\n", w.Body.String())
+}
+
+func TestExportMovementsGzippedNDJSON(t *testing.T) {
+	r, repos := newExportRouter(t)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 31, 23, 59, 59, 999999999, time.UTC)
+	unitCost := 2.5
+	repos.stockMovement.On("StreamBetween", mock.Anything, from, to, 7, mock.Anything).
+		Run(streamRecords(4,
+			&domain.StockMovement{ID: 1, ProductID: 7, MovementType: domain.MovementTypeReceipt, Quantity: 10, UnitCost: &unitCost, CreatedAt: from},
+			&domain.StockMovement{ID: 2, ProductID: 7, MovementType: domain.MovementTypeIssue, Quantity: 4, Reason: "order 42", CreatedAt: from.Add(time.Millisecond)},
+		)).
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/exports/movements?from=2026-01-01&to=2026-01-31&productId=7&format=ndjson&compression=gzip", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/gzip", w.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename=movements.ndjson.gz", w.Header().Get("Content-Disposition"))
+
+	zr, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	var lines []string
+	scanner := bufio.NewScanner(zr)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, []string{
+		`{"id":1,"productId":7,"movementType":"receipt","quantity":10,"unitCost":2.5,"reason":null,"userId":null,"createdAt":"2026-01-01T00:00:00Z"}`,
+		`{"id":2,"productId":7,"movementType":"issue","quantity":4,"unitCost":null,"reason":"order 42","userId":null,"createdAt":"2026-01-01T00:00:00.001Z"}`,
+	}, lines)
+}
+
+func TestExportStockLevelsParquet(t *testing.T) {
+	r, repos := newExportRouter(t)
+	repos.stockLevel.On("Stream", mock.Anything, mock.Anything).
+		Run(streamRecords(1, &domain.StockLevel{ProductID: 1, Quantity: 5, UpdatedAt: time.Now()})).
+		Return(nil)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/exports/stock-levels?format=parquet&compression=gzip", nil))
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/vnd.apache.parquet", w.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename=stock-levels.parquet", w.Header().Get("Content-Disposition"))
+	body := w.Body.String()
+	assert.Equal(t, "PAR1", body[:4])
+	assert.Equal(t, "PAR1", body[len(body)-4:])
+}
+
+func TestExportFailingBeforeTheFileGetsAProblem(t *testing.T) {
+	r, repos := newExportRouter(t)
+	repos.product.On("Stream", mock.Anything, true, mock.Anything).Return(errors.New("connection refused"))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/exports/products?includeDeleted=true", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/problem+json")
+}
+
+func TestExportFailingMidwayAbortsTheResponse(t *testing.T) {
+	r, repos := newExportRouter(t)
+	products := make([]*domain.Product, 500)
+	for i := range products {
+		products[i] = &domain.Product{ID: i + 1, Name: "A product with a rather long name", CreatedAt: time.Now()}
+	}
+	repos.product.On("Stream", mock.Anything, false, mock.Anything).
+		Run(streamRecords(2, products...)).
+		Return(errors.New("connection reset"))
+
+	w := httptest.NewRecorder()
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/exports/products", nil))
+	})
+	assert.Equal(t, http.StatusOK, w.Code, "the file had started")
+}
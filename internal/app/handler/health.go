@@ -2,15 +2,76 @@
 package handler
 
 import (
+	"math"
 	"net/http"
 
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/health"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
-// HealthCheckHandler handles the health check endpoint.
-func HealthCheckHandler(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "ok",
-		"message": "stock-service up",
-	})
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{checker: checker}
+}
+
+// Live reports that the process is running
+// @Summary Liveness Probe
+// @Description Returns 200 while the process can serve HTTP. It does not check dependencies, so a failing database never gets the container restarted.
+// @ID health_live
+// @Tags health
+// @Produce json
+// @Success 200 {object} dtos.LivenessDTO
+// @Router /health/live [get]
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, dtos.LivenessDTO{Status: "ok"})
+}
+
+// Ready reports whether the dependencies are reachable
+// @Summary Readiness Probe
+// @Description Checks Postgres, and Kafka and Mongo when enabled, each with a timeout. Returns 503 when a required dependency is down.
+// @ID health_ready
+// @Tags health
+// @Produce json
+// @Success 200 {object} dtos.ReadinessDTO "All required dependencies are up"
+// @Failure 503 {object} dtos.ReadinessDTO "A required dependency is down"
+// @Router /health/ready [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.checker.Run(c.Request.Context())
+
+	response := dtos.ReadinessDTO{
+		Status:       "ready",
+		Dependencies: make([]dtos.DependencyStatusDTO, 0, len(report.Results)),
+	}
+	for _, result := range report.Results {
+		dependency := dtos.DependencyStatusDTO{
+			Name:      result.Name,
+			Status:    "up",
+			Required:  result.Required,
+			LatencyMs: math.Round(float64(result.Latency.Microseconds())) / 1000,
+		}
+		if !result.Healthy {
+			dependency.Status = "down"
+			dependency.Error = result.Err.Error()
+			logging.FromContext(c.Request.Context()).Warn(
+				"Dependency check failed",
+				zap.String("dependency", result.Name),
+				zap.Bool("required", result.Required),
+				zap.Error(result.Err),
+			)
+		}
+		response.Dependencies = append(response.Dependencies, dependency)
+	}
+
+	status := http.StatusOK
+	if !report.Ready {
+		response.Status = "unavailable"
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, response)
 }
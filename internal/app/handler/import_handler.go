@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/apperror"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/mapper"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// importFormats maps the media types of an upload to its import format.
+var importFormats = map[string]string{
+	"text/csv":             domain.ImportFormatCSV,
+	"application/x-ndjson": domain.ImportFormatNDJSON,
+	"application/ndjson":   domain.ImportFormatNDJSON,
+}
+
+type ImportHandler struct {
+	importUsecase *usecase.ProductImportUsecase
+	maxBytes      int64
+}
+
+// NewImportHandler returns a handler accepting files of up to maxBytes.
+func NewImportHandler(importUsecase *usecase.ProductImportUsecase, maxBytes int64) *ImportHandler {
+	return &ImportHandler{importUsecase: importUsecase, maxBytes: maxBytes}
+}
+
+// CreateImport queues the import of a file of products
+// @Summary Import Products
+// @Description Queues the import of a CSV file with a header row, or of NDJSON objects, with the columns name, sku, category, price and the optional openingQuantity and unitCost. Every row is validated before anything is written: a file with an invalid row imports nothing and its job reports the row errors. Missing categories are created and opening quantities are received as stock. Poll the job returned in the Location header for the outcome.
+// @ID create_import
+// @Tags imports
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param file body string true "CSV or NDJSON file"
+// @Param format query string false "Format of the file, defaults to the one of the Content-Type" Enums(csv, ndjson)
+// @Param dryRun query bool false "Only validate the file"
+// @Success 202 {object} dtos.ImportJobDTO "Queued import"
+// @Header 202 {string} Location "URL of the import job"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /imports [post]
+func (h *ImportHandler) CreateImport(c *gin.Context) {
+	var query dtos.ImportQueryDTO
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		_ = c.Error(apperror.InvalidQuery(err))
+		return
+	}
+
+	format := query.Format
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		format = importFormats[mediaType]
+	}
+	if format == "" {
+		_ = c.Error(domain.ErrUnsupportedImportFormat)
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			_ = c.Error(apperror.ErrPayloadTooLarge.Wrap(err))
+			return
+		}
+		_ = c.Error(apperror.ErrInvalidPayload.Wrap(err))
+		return
+	}
+
+	job, err := h.importUsecase.CreateImport(c.Request.Context(), dtos.CreateImportDTO{
+		Format:  format,
+		DryRun:  query.DryRun,
+		Payload: payload,
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	logging.FromContext(c.Request.Context()).Info(
+		"Import queued",
+		zap.String("importID", job.ID),
+		zap.String("format", job.Format),
+		zap.Bool("dryRun", job.DryRun),
+		zap.Int("bytes", len(payload)),
+	)
+
+	c.Header("Location", "/imports/"+job.ID)
+	c.JSON(http.StatusAccepted, mapper.ToImportJobDTO(job))
+}
+
+// GetImport returns the status of an import
+// @Summary Get Import
+// @Description Retrieves the progress of an import, or its outcome once finished: the created products and categories, or the row errors.
+// @ID get_import
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Param id path string true "Import ID" format(uuid)
+// @Success 200 {object} dtos.ImportJobDTO
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /imports/{id} [get]
+func (h *ImportHandler) GetImport(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	job, err := h.importUsecase.GetImport(c.Request.Context(), id.String())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToImportJobDTO(job))
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/apperror"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/mapper"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ProductHandler struct {
+	productUsecase *usecase.ProductUsecase
+}
+
+func NewProductHandler(productUsecase *usecase.ProductUsecase) *ProductHandler {
+	return &ProductHandler{productUsecase: productUsecase}
+}
+
+// CreateProduct creates a new product
+// @Summary Create Product
+// @Description Creates a product in an existing category. Fails with 409 when an active product has the SKU.
+// @ID create_product
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param product body dtos.CreateProductDTO true "Product to be created"
+// @Success 201 {object} dtos.ProductDTO
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /products [post]
+func (h *ProductHandler) CreateProduct(c *gin.Context) {
+	var createProductDTO dtos.CreateProductDTO
+
+	if err := c.ShouldBindJSON(&createProductDTO); err != nil {
+		_ = c.Error(apperror.InvalidPayload(err))
+		return
+	}
+
+	product, err := h.productUsecase.CreateProduct(c.Request.Context(), createProductDTO)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ToProductDTO(product))
+}
+
+// UpdateProduct updates an existing product
+// @Summary Update Product
+// @Description Replaces the name, description, price and category of a product, and its SKU when one is given. Fails with 409 when another active product has the SKU.
+// @ID update_product
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Param product body dtos.UpdateProductDTO true "Product to be updated"
+// @Success 200 {object} dtos.ProductDTO
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /products/{id} [patch]
+func (h *ProductHandler) UpdateProduct(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	var updateProductDTO dtos.UpdateProductDTO
+
+	if err := c.ShouldBindJSON(&updateProductDTO); err != nil {
+		_ = c.Error(apperror.InvalidPayload(err))
+		return
+	}
+
+	product, err := h.productUsecase.UpdateProduct(c.Request.Context(), id, updateProductDTO)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToProductDTO(product))
+}
+
+// ListProducts lists products
+// @Summary List Products
+// @Description Retrieves a page of products, optionally restricted to a category and all of its subcategories. Soft-deleted products are excluded unless includeDeleted is set.
+// @ID list_products
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param categoryId query int false "Category ID (includes subcategories)"
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Number of products to skip"
+// @Param cursor query string false "Cursor returned as nextCursor by the previous page"
+// @Param sort query string false "Sort field" Enums(name, createdAt) default(name)
+// @Param order query string false "Sort direction" Enums(asc, desc) default(asc)
+// @Param q query string false "Only names containing this text (case-insensitive)"
+// @Param prefix query string false "Only names starting with this text (case-insensitive)"
+// @Param includeDeleted query bool false "Include soft-deleted products"
+// @Success 200 {object} dtos.PageDTO{data=[]dtos.ProductDTO} "Page of products"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /products [get]
+func (h *ProductHandler) ListProducts(c *gin.Context) {
+	var listProductsDTO dtos.ListProductsDTO
+
+	if err := c.ShouldBindQuery(&listProductsDTO); err != nil {
+		_ = c.Error(apperror.InvalidQuery(err))
+		return
+	}
+
+	page, err := h.productUsecase.ListProducts(c.Request.Context(), listProductsDTO)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToPageDTO(page, mapper.ToProductDTO))
+}
+
+// SearchProducts searches products by text
+// @Summary Search Products
+// @Description Full-text search over product names and descriptions, best matches first. Every word must match and partial words match as prefixes. The response carries category and price range facet counts over all matches; each facet ignores its own filter.
+// @ID search_products
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param q query string true "Search text"
+// @Param categoryId query int false "Category ID (includes subcategories)"
+// @Param minPrice query number false "Minimum price"
+// @Param maxPrice query number false "Maximum price"
+// @Param inStock query bool false "Only products with stock on hand"
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Number of results to skip"
+// @Success 200 {object} dtos.ProductSearchResultDTO "Search results"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /products/search [get]
+func (h *ProductHandler) SearchProducts(c *gin.Context) {
+	var searchProductsDTO dtos.SearchProductsDTO
+
+	if err := c.ShouldBindQuery(&searchProductsDTO); err != nil {
+		_ = c.Error(apperror.InvalidQuery(err))
+		return
+	}
+
+	result, err := h.productUsecase.SearchProducts(c.Request.Context(), searchProductsDTO)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToProductSearchResultDTO(result))
+}
+
+// DeleteProduct soft-deletes a product
+// @Summary Delete Product
+// @Description Soft-deletes a product. Its stock movements are kept and it can be restored later.
+// @ID delete_product
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 204
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /products/{id} [delete]
+func (h *ProductHandler) DeleteProduct(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	err = h.productUsecase.DeleteProduct(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	logging.FromContext(c.Request.Context()).Info(
+		"Product deleted",
+		zap.Int("productID", id),
+	)
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// RestoreProduct restores a soft-deleted product
+// @Summary Restore Product
+// @Description Restores a soft-deleted product. No active product may have taken its SKU meanwhile.
+// @ID restore_product
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path int true "Product ID"
+// @Success 200 {object} dtos.ProductDTO "Restored product"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /products/{id}/restore [post]
+func (h *ProductHandler) RestoreProduct(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	product, err := h.productUsecase.RestoreProduct(c.Request.Context(), id)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	logging.FromContext(c.Request.Context()).Info(
+		"Product restored",
+		zap.Int("productID", id),
+	)
+
+	c.JSON(http.StatusOK, mapper.ToProductDTO(product))
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/apperror"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/mapper"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+var errInvalidAsOf = apperror.New(http.StatusBadRequest, "invalid_as_of", "Invalid asOf date")
+
+type ReportHandler struct {
+	valuationUsecase *usecase.ValuationUsecase
+}
+
+func NewReportHandler(valuationUsecase *usecase.ValuationUsecase) *ReportHandler {
+	return &ReportHandler{valuationUsecase: valuationUsecase}
+}
+
+// GetValuationReport computes the inventory valuation
+// @Summary Inventory Valuation Report
+// @Description Values the stock on hand by product and category as of a given date using FIFO or weighted-average cost
+// @ID get_valuation_report
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param method query string false "Costing method" Enums(fifo, weighted_average) default(fifo)
+// @Param asOf query string false "Valuation date (YYYY-MM-DD or RFC 3339), defaults to now"
+// @Success 200 {object} dtos.ValuationReportDTO
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /reports/valuation [get]
+func (h *ReportHandler) GetValuationReport(c *gin.Context) {
+	method := c.Query("method")
+
+	asOf, err := parseAsOf(c.Query("asOf"))
+	if err != nil {
+		_ = c.Error(errInvalidAsOf.Wrap(err))
+		return
+	}
+
+	report, err := h.valuationUsecase.GetValuation(c.Request.Context(), method, asOf)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToValuationReportDTO(report))
+}
+
+// parseAsOf accepts a calendar date, meaning the end of that day, or a full
+// RFC 3339 timestamp. An empty value means now.
+func parseAsOf(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	if day, err := time.Parse(dateLayout, value); err == nil {
+		return day.Add(24*time.Hour - time.Nanosecond), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/apperror"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/mapper"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type ReservationHandler struct {
+	reservationUsecase *usecase.ReservationUsecase
+}
+
+func NewReservationHandler(reservationUsecase *usecase.ReservationUsecase) *ReservationHandler {
+	return &ReservationHandler{reservationUsecase: reservationUsecase}
+}
+
+// CreateReservation reserves stock of a product
+// @Summary Create Stock Reservation
+// @Description Reserves units of a product, e.g. for an order being placed. Fails with 409 when fewer units are available than requested.
+// @ID create_stock_reservation
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Param reservation body dtos.CreateReservationDTO true "Reservation to be created"
+// @Success 201 {object} dtos.StockReservationDTO
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /stock/reservations [post]
+func (h *ReservationHandler) CreateReservation(c *gin.Context) {
+	var createReservationDTO dtos.CreateReservationDTO
+
+	if err := c.ShouldBindJSON(&createReservationDTO); err != nil {
+		_ = c.Error(apperror.InvalidPayload(err))
+		return
+	}
+
+	reservation, err := h.reservationUsecase.CreateReservation(c.Request.Context(), createReservationDTO)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapper.ToStockReservationDTO(reservation))
+}
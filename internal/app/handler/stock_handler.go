@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/apperror"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/mapper"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type StockHandler struct {
+	stockMovementUsecase *usecase.StockMovementUsecase
+}
+
+func NewStockHandler(stockMovementUsecase *usecase.StockMovementUsecase) *StockHandler {
+	return &StockHandler{stockMovementUsecase: stockMovementUsecase}
+}
+
+// RecordMovement records a stock movement
+// @Summary Record Stock Movement
+// @Description Records a receipt, issue or adjustment and updates the stock level. Receipts must carry a unit cost.
+// @ID record_stock_movement
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Param movement body dtos.CreateStockMovementDTO true "Movement to be recorded"
+// @Success 201 {object} dtos.StockMovementDTO
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /stock/movements [post]
+func (h *StockHandler) RecordMovement(c *gin.Context) {
+	var createMovementDTO dtos.CreateStockMovementDTO
+
+	if err := c.ShouldBindJSON(&createMovementDTO); err != nil {
+		_ = c.Error(apperror.InvalidPayload(err))
+		return
+	}
+
+	movement, err := h.stockMovementUsecase.RecordMovement(c.Request.Context(), createMovementDTO)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	logging.FromContext(c.Request.Context()).Info(
+		"Stock movement recorded",
+		zap.Int("movementID", movement.ID),
+		zap.Int("productID", movement.ProductID),
+		zap.String("movementType", movement.MovementType),
+		zap.Int("quantity", movement.Quantity),
+	)
+
+	c.JSON(http.StatusCreated, mapper.ToStockMovementDTO(movement))
+}
+
+// ListMovementsByProduct lists the movement history of a product
+// @Summary List Stock Movements
+// @Description Retrieves a page of the movement ledger of a product
+// @ID list_stock_movements
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Param productId path int true "Product ID"
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Number of movements to skip"
+// @Param cursor query string false "Cursor returned as nextCursor by the previous page"
+// @Param sort query string false "Sort field" Enums(createdAt) default(createdAt)
+// @Param order query string false "Sort direction" Enums(asc, desc) default(asc)
+// @Success 200 {object} dtos.PageDTO{data=[]dtos.StockMovementDTO} "Page of movements"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Failure default {object} dtos.ProblemDTO "Problem details"
+// @Router /stock/movements/{productId} [get]
+func (h *StockHandler) ListMovementsByProduct(c *gin.Context) {
+	idStr := c.Param("productId")
+
+	productID, err := strconv.Atoi(idStr)
+	if err != nil {
+		_ = c.Error(apperror.ErrInvalidID.Wrap(err))
+		return
+	}
+
+	var listMovementsDTO dtos.ListStockMovementsDTO
+
+	if err := c.ShouldBindQuery(&listMovementsDTO); err != nil {
+		_ = c.Error(apperror.InvalidQuery(err))
+		return
+	}
+
+	page, err := h.stockMovementUsecase.ListMovementsByProduct(c.Request.Context(), productID, listMovementsDTO)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapper.ToPageDTO(page, mapper.ToStockMovementDTO))
+}
@@ -0,0 +1,152 @@
+// Package lifecycle runs the HTTP server and background workers of the
+// service and shuts them down in order when the process is asked to stop.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Worker is a background task, such as a message consumer. Run must return
+// once ctx is cancelled.
+type Worker struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Hook releases a resource, such as the database pool, during shutdown.
+type Hook struct {
+	Name  string
+	Close func(ctx context.Context) error
+}
+
+// Lifecycle shuts down in this order: stop accepting connections and drain
+// in-flight requests, cancel the workers and wait for them, then run the
+// shutdown hooks in reverse registration order. The whole sequence shares
+// ShutdownTimeout.
+type Lifecycle struct {
+	logger          *zap.Logger
+	shutdownTimeout time.Duration
+	workers         []Worker
+	hooks           []Hook
+}
+
+func New(logger *zap.Logger, shutdownTimeout time.Duration) *Lifecycle {
+	return &Lifecycle{logger: logger, shutdownTimeout: shutdownTimeout}
+}
+
+func (l *Lifecycle) AddWorker(name string, run func(ctx context.Context) error) {
+	l.workers = append(l.workers, Worker{Name: name, Run: run})
+}
+
+// OnShutdown registers a hook. Register resources in the order they are
+// created; they are closed in reverse.
+func (l *Lifecycle) OnShutdown(name string, close func(ctx context.Context) error) {
+	l.hooks = append(l.hooks, Hook{Name: name, Close: close})
+}
+
+// Run listens on server.Addr and blocks until SIGINT or SIGTERM is received,
+// ctx is cancelled or the server fails, then shuts everything down.
+func (l *Lifecycle) Run(ctx context.Context, server *http.Server) error {
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		l.closeHooks(ctx)
+		return err
+	}
+	return l.Serve(ctx, server, listener)
+}
+
+// Serve is Run on an existing listener.
+func (l *Lifecycle) Serve(ctx context.Context, server *http.Server, listener net.Listener) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		l.logger.Info("HTTP server listening", zap.String("addr", listener.Addr().String()))
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+
+	workerCtx, cancelWorkers := context.WithCancel(context.Background())
+	defer cancelWorkers()
+	var workers sync.WaitGroup
+	for _, w := range l.workers {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			l.logger.Info("Worker started", zap.String("worker", w.Name))
+			if err := w.Run(workerCtx); err != nil && !errors.Is(err, context.Canceled) {
+				l.logger.Error("Worker stopped with error", zap.String("worker", w.Name), zap.Error(err))
+				return
+			}
+			l.logger.Info("Worker stopped", zap.String("worker", w.Name))
+		}()
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		l.logger.Info("Shutdown requested")
+	case err := <-serverErr:
+		runErr = err
+		l.logger.Error("HTTP server failed", zap.Error(err))
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), l.shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		l.logger.Error("HTTP server did not drain in time", zap.Error(err))
+		runErr = errors.Join(runErr, err)
+	} else {
+		l.logger.Info("HTTP server drained")
+	}
+
+	cancelWorkers()
+	if err := waitGroup(shutdownCtx, &workers); err != nil {
+		l.logger.Error("Workers did not stop in time", zap.Error(err))
+		runErr = errors.Join(runErr, err)
+	}
+
+	return errors.Join(runErr, l.closeHooks(shutdownCtx))
+}
+
+func (l *Lifecycle) closeHooks(ctx context.Context) error {
+	var errs []error
+	for i := len(l.hooks) - 1; i >= 0; i-- {
+		hook := l.hooks[i]
+		if err := hook.Close(ctx); err != nil {
+			l.logger.Error("Shutdown hook failed", zap.String("hook", hook.Name), zap.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+		l.logger.Info("Shutdown hook completed", zap.String("hook", hook.Name))
+	}
+	return errors.Join(errs...)
+}
+
+func waitGroup(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
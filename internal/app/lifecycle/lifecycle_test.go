@@ -0,0 +1,116 @@
+package lifecycle
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestServeDrainsRequestsBeforeStoppingWorkersAndHooks(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+
+	requestStarted := make(chan struct{})
+	releaseRequest := make(chan struct{})
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requestStarted)
+		<-releaseRequest
+		record("request done")
+		_, _ = io.WriteString(w, "ok")
+	})}
+
+	l := New(zap.NewNop(), 5*time.Second)
+	l.AddWorker("consumer", func(ctx context.Context) error {
+		<-ctx.Done()
+		record("worker stopped")
+		return ctx.Err()
+	})
+	l.OnShutdown("database", func(ctx context.Context) error {
+		record("database closed")
+		return nil
+	})
+	l.OnShutdown("producer", func(ctx context.Context) error {
+		record("producer closed")
+		return nil
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- l.Serve(ctx, server, listener) }()
+
+	response := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			response <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		response <- string(body)
+	}()
+
+	<-requestStarted
+	cancel()
+	// Give Shutdown time to close the listener while the request is in flight.
+	time.Sleep(50 * time.Millisecond)
+	close(releaseRequest)
+
+	assert.Equal(t, "ok", <-response)
+	assert.NoError(t, <-served)
+	assert.Equal(t, []string{"request done", "worker stopped", "producer closed", "database closed"}, events)
+}
+
+func TestServeReturnsWhenShutdownTimesOut(t *testing.T) {
+	requestStarted := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requestStarted)
+		<-release
+	})}
+
+	l := New(zap.NewNop(), 50*time.Millisecond)
+	hookCalled := false
+	l.OnShutdown("database", func(ctx context.Context) error {
+		hookCalled = true
+		return nil
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- l.Serve(ctx, server, listener) }()
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String())
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	<-requestStarted
+	cancel()
+
+	assert.ErrorIs(t, <-served, context.DeadlineExceeded)
+	assert.True(t, hookCalled)
+}
@@ -0,0 +1,34 @@
+package mapper
+
+import (
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+func ToAPIKeyDTO(key *domain.APIKey) dtos.APIKeyDTO {
+	response := dtos.APIKeyDTO{
+		ID:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    key.Scopes,
+		CreatedBy: key.CreatedBy,
+		CreatedAt: Timestamp(key.CreatedAt),
+	}
+	if response.Scopes == nil {
+		response.Scopes = []string{}
+	}
+	if key.LastUsedAt != nil {
+		response.LastUsedAt = Timestamp(*key.LastUsedAt)
+	}
+	if key.RotatedAt != nil {
+		response.RotatedAt = Timestamp(*key.RotatedAt)
+	}
+	if key.RevokedAt != nil {
+		response.RevokedAt = Timestamp(*key.RevokedAt)
+	}
+	return response
+}
+
+func ToIssuedAPIKeyDTO(key *domain.APIKey, plaintext string) dtos.IssuedAPIKeyDTO {
+	return dtos.IssuedAPIKeyDTO{APIKeyDTO: ToAPIKeyDTO(key), Key: plaintext}
+}
@@ -0,0 +1,49 @@
+package mapper
+
+import (
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+func ToCategoryDTO(category *domain.Category) dtos.CategoryDTO {
+	response := dtos.CategoryDTO{
+		ID:        category.ID,
+		Name:      category.Name,
+		ParentID:  category.ParentID,
+		CreatedAt: Timestamp(category.CreatedAt),
+	}
+	if category.UpdatedAt != nil {
+		response.UpdatedAt = Timestamp(*category.UpdatedAt)
+	}
+	if category.DeletedAt.Valid {
+		response.DeletedAt = Timestamp(category.DeletedAt.Time)
+	}
+	return response
+}
+
+func ToCategoryTreeDTOs(nodes []*domain.CategoryNode) []dtos.CategoryTreeDTO {
+	tree := make([]dtos.CategoryTreeDTO, 0, len(nodes))
+	for _, n := range nodes {
+		tree = append(tree, dtos.CategoryTreeDTO{
+			ID:       n.Category.ID,
+			Name:     n.Category.Name,
+			ParentID: n.Category.ParentID,
+			Children: ToCategoryTreeDTOs(n.Children),
+		})
+	}
+	return tree
+}
+
+func ToCategoryInUseDTO(err *domain.CategoryInUseError) dtos.CategoryInUseDTO {
+	response := dtos.CategoryInUseDTO{
+		Products:      make([]dtos.CategoryReferenceDTO, 0, len(err.Products)),
+		Subcategories: make([]dtos.CategoryReferenceDTO, 0, len(err.Subcategories)),
+	}
+	for _, p := range err.Products {
+		response.Products = append(response.Products, dtos.CategoryReferenceDTO{ID: p.ID, Name: p.Name})
+	}
+	for _, s := range err.Subcategories {
+		response.Subcategories = append(response.Subcategories, dtos.CategoryReferenceDTO{ID: s.ID, Name: s.Name})
+	}
+	return response
+}
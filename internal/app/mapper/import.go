@@ -0,0 +1,33 @@
+package mapper
+
+import (
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+func ToImportJobDTO(job *domain.ImportJob) dtos.ImportJobDTO {
+	dto := dtos.ImportJobDTO{
+		ID:                job.ID,
+		Status:            job.Status,
+		Format:            job.Format,
+		DryRun:            job.DryRun,
+		TotalRows:         job.TotalRows,
+		ProcessedRows:     job.ProcessedRows,
+		CreatedProducts:   job.CreatedProducts,
+		CreatedCategories: job.CreatedCategories,
+		ErrorCount:        job.ErrorCount,
+		Errors:            make([]dtos.ImportRowErrorDTO, 0, len(job.Errors)),
+		Failure:           job.Failure,
+		CreatedAt:         Timestamp(job.CreatedAt),
+	}
+	for _, e := range job.Errors {
+		dto.Errors = append(dto.Errors, dtos.ImportRowErrorDTO(e))
+	}
+	if job.StartedAt != nil {
+		dto.StartedAt = Timestamp(*job.StartedAt)
+	}
+	if job.FinishedAt != nil {
+		dto.FinishedAt = Timestamp(*job.FinishedAt)
+	}
+	return dto
+}
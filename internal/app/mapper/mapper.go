@@ -0,0 +1,52 @@
+// Package mapper converts domain entities into the DTOs exposed over HTTP.
+// Handlers never serialize domain structs directly, so the JSON contract
+// stays independent from the domain model.
+package mapper
+
+import (
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+// Timestamp formats t as an RFC 3339 timestamp in UTC.
+func Timestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// optionalTimestamp formats t, or returns "" when it is zero so that the
+// field is omitted.
+func optionalTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return Timestamp(t)
+}
+
+// ToPageDTO wraps a page of entities in the list envelope, mapping each item
+// with mapItem.
+func ToPageDTO[T, D any](page *domain.Page[T], mapItem func(T) D) dtos.PageDTO {
+	data := make([]D, 0, len(page.Items))
+	for _, item := range page.Items {
+		data = append(data, mapItem(item))
+	}
+	return dtos.PageDTO{
+		Data: data,
+		Pagination: dtos.PaginationDTO{
+			Total:      page.Total,
+			Limit:      page.Limit,
+			Offset:     page.Offset,
+			NextCursor: page.NextCursor,
+		},
+	}
+}
+
+// ToSlice maps every item of items.
+func ToSlice[T, D any](items []T, mapItem func(T) D) []D {
+	result := make([]D, 0, len(items))
+	for _, item := range items {
+		result = append(result, mapItem(item))
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package mapper
+
+import (
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+func ToProductDTO(product *domain.Product) dtos.ProductDTO {
+	response := dtos.ProductDTO{
+		ID:          product.ID,
+		Name:        product.Name,
+		SKU:         product.SKU,
+		Description: product.Description,
+		Price:       product.Price,
+		CategoryID:  product.CategoryID,
+		CreatedAt:   Timestamp(product.CreatedAt),
+		UpdatedAt:   optionalTimestamp(product.UpdatedAt),
+	}
+	if product.DeletedAt.Valid {
+		response.DeletedAt = Timestamp(product.DeletedAt.Time)
+	}
+	return response
+}
+
+func ToProductSearchResultDTO(result *domain.ProductSearchResult) dtos.ProductSearchResultDTO {
+	response := dtos.ProductSearchResultDTO{
+		Data: make([]dtos.ProductSearchHitDTO, 0, len(result.Hits)),
+		Facets: dtos.ProductSearchFacetsDTO{
+			Categories:  make([]dtos.CategoryFacetDTO, 0, len(result.CategoryFacets)),
+			PriceRanges: make([]dtos.PriceRangeFacetDTO, 0, len(result.PriceFacets)),
+		},
+		Pagination: dtos.PaginationDTO{
+			Total:  result.Total,
+			Limit:  result.Limit,
+			Offset: result.Offset,
+		},
+	}
+	for _, hit := range result.Hits {
+		response.Data = append(response.Data, dtos.ProductSearchHitDTO{
+			ProductDTO: ToProductDTO(hit.Product),
+			Rank:       hit.Rank,
+		})
+	}
+	for _, facet := range result.CategoryFacets {
+		response.Facets.Categories = append(response.Facets.Categories, dtos.CategoryFacetDTO(facet))
+	}
+	for _, facet := range result.PriceFacets {
+		response.Facets.PriceRanges = append(response.Facets.PriceRanges, dtos.PriceRangeFacetDTO(facet))
+	}
+	return response
+}
@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	"math"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+// ToValuationReportDTO rounds values to cents and unit costs to four
+// decimals.
+func ToValuationReportDTO(report *domain.ValuationReport) dtos.ValuationReportDTO {
+	response := dtos.ValuationReportDTO{
+		Method:     string(report.Method),
+		AsOf:       Timestamp(report.AsOf),
+		TotalValue: roundMoney(report.TotalValue),
+		Categories: make([]dtos.CategoryValuationDTO, 0, len(report.Categories)),
+	}
+
+	for _, category := range report.Categories {
+		categoryDTO := dtos.CategoryValuationDTO{
+			CategoryID:   category.CategoryID,
+			CategoryName: category.CategoryName,
+			Quantity:     category.Quantity,
+			TotalValue:   roundMoney(category.TotalValue),
+			Products:     make([]dtos.ProductValuationDTO, 0, len(category.Products)),
+		}
+		for _, product := range category.Products {
+			categoryDTO.Products = append(categoryDTO.Products, dtos.ProductValuationDTO{
+				ProductID:   product.ProductID,
+				ProductName: product.ProductName,
+				Quantity:    product.Quantity,
+				UnitCost:    math.Round(product.UnitCost()*10000) / 10000,
+				TotalValue:  roundMoney(product.TotalValue),
+			})
+		}
+		response.Categories = append(response.Categories, categoryDTO)
+	}
+
+	return response
+}
+
+func roundMoney(value float64) float64 {
+	return math.Round(value*100) / 100
+}
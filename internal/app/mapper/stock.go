@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+func ToStockMovementDTO(movement *domain.StockMovement) dtos.StockMovementDTO {
+	return dtos.StockMovementDTO{
+		ID:           movement.ID,
+		ProductID:    movement.ProductID,
+		MovementType: movement.MovementType,
+		Quantity:     movement.Quantity,
+		UnitCost:     movement.UnitCost,
+		Reason:       movement.Reason,
+		UserID:       movement.UserID,
+		CreatedAt:    Timestamp(movement.CreatedAt),
+	}
+}
+
+func ToStockBalanceDTO(balance domain.StockBalance) dtos.StockBalanceDTO {
+	return dtos.StockBalanceDTO{
+		ProductID:   balance.ProductID,
+		ProductName: balance.ProductName,
+		OnHand:      balance.OnHand,
+		Reserved:    balance.Reserved,
+		Available:   balance.Available(),
+	}
+}
+
+func ToStockReservationDTO(reservation *domain.StockReservation) dtos.StockReservationDTO {
+	dto := dtos.StockReservationDTO{
+		ID:          reservation.ID,
+		ProductID:   reservation.ProductID,
+		ReservedQty: reservation.ReservedQty,
+		ReferenceID: reservation.ReferenceID,
+		ReservedAt:  Timestamp(reservation.ReservedAt),
+		Status:      reservation.Status,
+		UserID:      reservation.UserID,
+	}
+	if reservation.ExpiresAt != nil {
+		dto.ExpiresAt = Timestamp(*reservation.ExpiresAt)
+	}
+	return dto
+}
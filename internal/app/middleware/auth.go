@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/apperror"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// UserResolver finds or provisions the user behind a token subject.
+type UserResolver interface {
+	ResolveUser(ctx context.Context, identity domain.User) (*domain.User, error)
+}
+
+// APIKeyAuthenticator finds the active API key matching a plaintext key.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, plaintext string) (*domain.APIKey, error)
+}
+
+// Authenticate requires a valid bearer token or X-API-Key header and stores
+// the resulting auth.Principal in the request context. A token's subject is
+// resolved to a user, whose permissions are those policy grants to the
+// token's roles; a key's permissions come from its scopes. Requests without
+// valid credentials are rejected with 401.
+func Authenticate(verifier *auth.Verifier, users UserResolver, keys APIKeyAuthenticator, policy *auth.Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+			authenticateAPIKey(c, keys, apiKey)
+			return
+		}
+
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			rejectUnauthenticated(c, auth.ErrMissingToken, "")
+			return
+		}
+
+		claims, err := verifier.Verify(token)
+		if err != nil {
+			rejectUnauthenticated(c, err, "invalid_token")
+			return
+		}
+
+		ctx := c.Request.Context()
+		user, err := users.ResolveUser(ctx, domain.User{
+			Subject:  claims.Subject,
+			UserName: claims.PreferredUsername,
+			FullName: claims.Name,
+			Email:    claims.Email,
+		})
+		if err != nil {
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+
+		ctx = auth.WithPrincipal(ctx, auth.NewPrincipal(*claims, user.ID, policy))
+		ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With(zap.String("userId", user.ID)))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func authenticateAPIKey(c *gin.Context, keys APIKeyAuthenticator, plaintext string) {
+	ctx := c.Request.Context()
+	key, err := keys.AuthenticateAPIKey(ctx, plaintext)
+	if errors.Is(err, auth.ErrInvalidAPIKey) {
+		rejectUnauthenticated(c, err, "")
+		return
+	}
+	if err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+
+	ctx = auth.WithPrincipal(ctx, auth.NewAPIKeyPrincipal(key.ID, key.TenantID, key.Scopes))
+	ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With(zap.String("apiKeyId", key.ID)))
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// rejectUnauthenticated answers with 401 and the WWW-Authenticate challenge
+// of RFC 6750.
+func rejectUnauthenticated(c *gin.Context, err error, errorCode string) {
+	challenge := `Bearer realm="inventory"`
+	if errorCode != "" {
+		challenge += `, error="` + errorCode + `"`
+	}
+	c.Header("WWW-Authenticate", challenge)
+	_ = c.Error(apperror.ErrUnauthorized.Wrap(err))
+	c.Abort()
+}
+
+// RequirePermission rejects requests whose principal lacks perm with 403.
+// It must run after Authenticate; without a principal the request passes.
+func RequirePermission(perm auth.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := auth.Authorize(c.Request.Context(), perm); err != nil {
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
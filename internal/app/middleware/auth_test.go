@@ -0,0 +1,217 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+type stubResolver struct {
+	err      error
+	identity domain.User
+}
+
+func (r *stubResolver) ResolveUser(ctx context.Context, identity domain.User) (*domain.User, error) {
+	r.identity = identity
+	if r.err != nil {
+		return nil, r.err
+	}
+	identity.ID = "7f7a3c9e-0d7c-4f7e-9a53-5f0a2b2d6a10"
+	return &identity, nil
+}
+
+// stubKeys accepts testAPIKey, granting it catalog:read.
+type stubKeys struct {
+	err error
+}
+
+const testAPIKey = "inv_0a1b2c3d4e5f_c2VjcmV0"
+
+func (k *stubKeys) AuthenticateAPIKey(ctx context.Context, plaintext string) (*domain.APIKey, error) {
+	if k.err != nil {
+		return nil, k.err
+	}
+	if plaintext != testAPIKey {
+		return nil, auth.ErrInvalidAPIKey
+	}
+	return &domain.APIKey{ID: "5d0c1f0e-8a3b-4d8e-9c51-2f5e7a9b1c3d", Scopes: []string{"catalog:read"}}, nil
+}
+
+func signedToken(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuthenticate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	verifier, err := auth.NewVerifier(auth.VerifierOptions{HMACSecret: []byte(testSecret)})
+	require.NoError(t, err)
+
+	valid := signedToken(t, jwt.MapClaims{
+		"sub":   "auth0|42",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"email": "ana@nexusmarket.test",
+		"roles": []string{"clerk"},
+	})
+	expired := signedToken(t, jwt.MapClaims{"sub": "auth0|42", "exp": time.Now().Add(-time.Hour).Unix()})
+
+	tests := []struct {
+		name              string
+		header            string
+		resolverErr       error
+		expectedStatus    int
+		expectedCode      string
+		expectedChallenge string
+	}{
+		{name: "valid token", header: "Bearer " + valid, expectedStatus: http.StatusOK},
+		{name: "scheme is case insensitive", header: "bearer " + valid, expectedStatus: http.StatusOK},
+		{name: "missing header", expectedStatus: http.StatusUnauthorized, expectedCode: "unauthorized", expectedChallenge: `Bearer realm="inventory"`},
+		{name: "basic credentials", header: "Basic dXNlcjpwYXNz", expectedStatus: http.StatusUnauthorized, expectedCode: "unauthorized", expectedChallenge: `Bearer realm="inventory"`},
+		{name: "expired token", header: "Bearer " + expired, expectedStatus: http.StatusUnauthorized, expectedCode: "unauthorized", expectedChallenge: `Bearer realm="inventory", error="invalid_token"`},
+		{name: "user lookup fails", header: "Bearer " + valid, resolverErr: errors.New("connection refused"), expectedStatus: http.StatusInternalServerError, expectedCode: "internal_error"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := &stubResolver{err: tc.resolverErr}
+			var principal *auth.Principal
+
+			r := gin.New()
+			r.Use(RequestID(), ErrorHandler(), Authenticate(verifier, resolver, &stubKeys{}, auth.DefaultPolicy()))
+			r.GET("/categories", func(c *gin.Context) {
+				principal, _ = auth.PrincipalFromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedCode != "" {
+				assert.Contains(t, w.Body.String(), `"code":"`+tc.expectedCode+`"`)
+				assert.Nil(t, principal, "the handler is not reached")
+			}
+			assert.Equal(t, tc.expectedChallenge, w.Header().Get("WWW-Authenticate"))
+
+			if tc.expectedStatus == http.StatusOK {
+				require.NotNil(t, principal)
+				assert.Equal(t, "auth0|42", principal.Subject)
+				assert.Equal(t, "7f7a3c9e-0d7c-4f7e-9a53-5f0a2b2d6a10", principal.UserID)
+				assert.Equal(t, "ana@nexusmarket.test", resolver.identity.Email)
+				assert.True(t, principal.Can(auth.PermStockWrite), "permissions follow the roles claim")
+				assert.False(t, principal.Can(auth.PermCategoriesDelete))
+			}
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	verifier, err := auth.NewVerifier(auth.VerifierOptions{HMACSecret: []byte(testSecret)})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		roles          []string
+		expectedStatus int
+	}{
+		{name: "granted", roles: []string{"manager"}, expectedStatus: http.StatusNoContent},
+		{name: "denied", roles: []string{"clerk"}, expectedStatus: http.StatusForbidden},
+		{name: "no roles", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			token := signedToken(t, jwt.MapClaims{"sub": "auth0|42", "exp": time.Now().Add(time.Hour).Unix(), "roles": tc.roles})
+
+			r := gin.New()
+			r.Use(RequestID(), ErrorHandler(), Authenticate(verifier, &stubResolver{}, &stubKeys{}, auth.DefaultPolicy()))
+			r.DELETE("/categories/:id", RequirePermission(auth.PermCategoriesDelete), func(c *gin.Context) {
+				c.Status(http.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/categories/1", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedStatus == http.StatusForbidden {
+				assert.Contains(t, w.Body.String(), `"code":"forbidden"`)
+				assert.Contains(t, w.Body.String(), `"details":{"permission":"categories:delete"}`)
+			}
+		})
+	}
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	verifier, err := auth.NewVerifier(auth.VerifierOptions{HMACSecret: []byte(testSecret)})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		key            string
+		keysErr        error
+		route          string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "valid key within its scopes", key: testAPIKey, route: "/products", expectedStatus: http.StatusOK},
+		{name: "valid key outside its scopes", key: testAPIKey, route: "/stock/movements", expectedStatus: http.StatusForbidden, expectedCode: "forbidden"},
+		{name: "unknown key", key: "inv_ffffffffffff_bm9wZQ", route: "/products", expectedStatus: http.StatusUnauthorized, expectedCode: "unauthorized"},
+		{name: "key lookup fails", key: testAPIKey, keysErr: errors.New("connection refused"), route: "/products", expectedStatus: http.StatusInternalServerError, expectedCode: "internal_error"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var principal *auth.Principal
+
+			r := gin.New()
+			r.Use(RequestID(), ErrorHandler(), Authenticate(verifier, &stubResolver{}, &stubKeys{err: tc.keysErr}, auth.DefaultPolicy()))
+			r.GET("/products", RequirePermission(auth.PermProductsRead), func(c *gin.Context) {
+				principal, _ = auth.PrincipalFromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+			r.GET("/stock/movements", RequirePermission(auth.PermStockWrite), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tc.route, nil)
+			req.Header.Set("X-API-Key", tc.key)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedCode != "" {
+				assert.Contains(t, w.Body.String(), `"code":"`+tc.expectedCode+`"`)
+			}
+			if tc.expectedStatus == http.StatusOK {
+				require.NotNil(t, principal)
+				assert.Equal(t, "apikey:5d0c1f0e-8a3b-4d8e-9c51-2f5e7a9b1c3d", principal.Subject)
+				assert.Empty(t, principal.UserID, "API keys are not users")
+			}
+		})
+	}
+}
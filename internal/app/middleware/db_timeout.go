@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DBTimeout puts a deadline on the request context. Repositories run their
+// queries with that context, so a slow query is cancelled once timeout has
+// elapsed and the request fails with a 504 instead of holding a connection.
+// A zero timeout disables the middleware. Routes under one of the exempt
+// path prefixes, such as streaming exports, set their own deadline.
+func DBTimeout(timeout time.Duration, exempt ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 || isExempt(c.FullPath(), exempt) {
+			c.Next()
+			return
+		}
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+func isExempt(route string, exempt []string) bool {
+	for _, prefix := range exempt {
+		if strings.HasPrefix(route, prefix) {
+			return true
+		}
+	}
+	return false
+}
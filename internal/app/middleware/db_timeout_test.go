@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("slow request times out", func(t *testing.T) {
+		r := gin.New()
+		r.Use(RequestID(), ErrorHandler(), DBTimeout(10*time.Millisecond))
+		r.GET("/slow", func(c *gin.Context) {
+			// Stands in for a query that honours the context.
+			<-c.Request.Context().Done()
+			_ = c.Error(c.Request.Context().Err())
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"timeout"`)
+	})
+
+	t.Run("zero timeout leaves the request without deadline", func(t *testing.T) {
+		r := gin.New()
+		r.Use(DBTimeout(0))
+		r.GET("/fast", func(c *gin.Context) {
+			_, hasDeadline := c.Request.Context().Deadline()
+			assert.False(t, hasDeadline)
+			c.Status(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("exempt routes keep the request context", func(t *testing.T) {
+		r := gin.New()
+		r.Use(DBTimeout(10*time.Millisecond, "/exports"))
+		r.GET("/exports/products", func(c *gin.Context) {
+			_, hasDeadline := c.Request.Context().Deadline()
+			assert.False(t, hasDeadline)
+			c.Status(http.StatusOK)
+		})
+		r.GET("/products", func(c *gin.Context) {
+			_, hasDeadline := c.Request.Context().Deadline()
+			assert.True(t, hasDeadline)
+			c.Status(http.StatusOK)
+		})
+
+		for _, path := range []string{"/exports/products", "/products"} {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+			assert.Equal(t, http.StatusOK, w.Code, path)
+		}
+	})
+}
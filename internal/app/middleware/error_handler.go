@@ -0,0 +1,74 @@
+// Package middleware provides the Gin middleware shared by every route.
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/apperror"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	// RequestIDHeader carries the ID correlating a request with its logs.
+	RequestIDHeader = "X-Request-ID"
+)
+
+// ErrorHandler renders the last error attached with c.Error as an RFC 7807
+// problem document, unless the handler already wrote a response. Client
+// errors are logged at info level and server errors at error level, through
+// the request logger.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		appErr := apperror.FromError(err)
+
+		fields := []zap.Field{
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", appErr.Status),
+			zap.String("code", appErr.Code),
+			zap.Error(err),
+		}
+		logger := logging.FromContext(c.Request.Context())
+		if appErr.Status >= http.StatusInternalServerError {
+			logger.Error("Request failed", fields...)
+		} else {
+			logger.Info("Request rejected", fields...)
+		}
+
+		abortWithProblem(c, appErr)
+	}
+}
+
+// NotFound reports unknown routes through the error handler.
+func NotFound(c *gin.Context) {
+	_ = c.Error(apperror.ErrRouteNotFound)
+}
+
+func abortWithProblem(c *gin.Context, appErr *apperror.Error) {
+	c.Header("Content-Type", problemContentType)
+	c.AbortWithStatusJSON(appErr.Status, toProblemDTO(c, appErr))
+}
+
+func toProblemDTO(c *gin.Context, appErr *apperror.Error) dtos.ProblemDTO {
+	return dtos.ProblemDTO{
+		Type:      "about:blank",
+		Title:     http.StatusText(appErr.Status),
+		Status:    appErr.Status,
+		Detail:    appErr.Message,
+		Instance:  c.Request.URL.Path,
+		Code:      appErr.Code,
+		RequestID: GetRequestID(c),
+		Details:   appErr.Details,
+	}
+}
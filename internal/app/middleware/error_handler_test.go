@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/apperror"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+		expectedDetail string
+	}{
+		{
+			name:           "domain error",
+			err:            domain.ErrCategoryNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "category_not_found",
+			expectedDetail: domain.ErrCategoryNotFound.Error(),
+		},
+		{
+			name:           "wrapped domain error",
+			err:            fmt.Errorf("updating category: %w", domain.ErrCategoryCycle),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "category_cycle",
+			expectedDetail: domain.ErrCategoryCycle.Error(),
+		},
+		{
+			name:           "api error",
+			err:            apperror.ErrInvalidID.Wrap(errors.New("strconv.Atoi: parsing \"abc\": invalid syntax")),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "invalid_id",
+			expectedDetail: "Invalid ID format",
+		},
+		{
+			name:           "deadline exceeded",
+			err:            fmt.Errorf("listing categories: %w", context.DeadlineExceeded),
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedCode:   "timeout",
+			expectedDetail: "The request did not complete in time",
+		},
+		{
+			name:           "unknown error is not exposed",
+			err:            errors.New("connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "internal_error",
+			expectedDetail: "Internal Server Error",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(RequestID(), ErrorHandler())
+			r.GET("/things/:id", func(c *gin.Context) {
+				_ = c.Error(tc.err)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/things/abc", nil)
+			req.Header.Set(RequestIDHeader, "req-1")
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			assert.Equal(t, problemContentType, w.Header().Get("Content-Type"))
+
+			var body map[string]any
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, "about:blank", body["type"])
+			assert.Equal(t, http.StatusText(tc.expectedStatus), body["title"])
+			assert.EqualValues(t, tc.expectedStatus, body["status"])
+			assert.Equal(t, tc.expectedCode, body["code"])
+			assert.Equal(t, tc.expectedDetail, body["detail"])
+			assert.Equal(t, "/things/abc", body["instance"])
+			assert.Equal(t, "req-1", body["requestId"])
+		})
+	}
+}
+
+func TestErrorHandlerCategoryInUseDetails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(RequestID(), ErrorHandler())
+	r.DELETE("/categories/1", func(c *gin.Context) {
+		_ = c.Error(&domain.CategoryInUseError{
+			CategoryID: 1,
+			Products:   []*domain.Product{{ID: 4, Name: "Lamp"}},
+		})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/categories/1", nil))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.JSONEq(t, `{"products":[{"id":4,"name":"Lamp"}],"subcategories":[]}`, extractDetails(t, w))
+}
+
+func TestErrorHandlerKeepsWrittenResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(RequestID(), ErrorHandler())
+	r.GET("/ok", func(c *gin.Context) {
+		_ = c.Error(errors.New("logged only"))
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	r.NoRoute(NotFound)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ok", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"route_not_found"`)
+}
+
+func extractDetails(t *testing.T, w *httptest.ResponseRecorder) string {
+	var body struct {
+		Details json.RawMessage `json:"details"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return string(body.Details)
+}
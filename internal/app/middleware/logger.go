@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Logger stores a logger carrying the request ID, and the trace ID when the
+// request is traced, in the request context, then writes one access log
+// line per request. Register it after RequestID and the tracing middleware.
+func Logger(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		fields := []zap.Field{zap.String("requestId", GetRequestID(c))}
+		if span := trace.SpanContextFromContext(c.Request.Context()); span.IsValid() {
+			fields = append(fields, zap.String("traceId", span.TraceID().String()))
+		}
+		requestLogger := logger.With(fields...)
+		c.Request = c.Request.WithContext(logging.WithLogger(c.Request.Context(), requestLogger))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		accessFields := []zap.Field{
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.String("route", c.FullPath()),
+			zap.Int("status", status),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("clientIP", c.ClientIP()),
+			zap.Int("bytes", c.Writer.Size()),
+		}
+		if status >= http.StatusInternalServerError {
+			requestLogger.Warn("Request completed", accessFields...)
+		} else {
+			requestLogger.Info("Request completed", accessFields...)
+		}
+	}
+}
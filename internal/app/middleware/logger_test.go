@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRequestIDAndLogger(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		header     string
+		keepHeader bool
+	}{
+		{name: "caller ID is kept", header: "checkout-42", keepHeader: true},
+		{name: "missing ID is generated"},
+		{name: "malformed ID is replaced", header: "bad id\n"},
+		{name: "oversized ID is replaced", header: strings.Repeat("a", maxRequestIDLength+1)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			core, logs := observer.New(zap.DebugLevel)
+
+			r := gin.New()
+			r.Use(RequestID(), Logger(zap.New(core)))
+			r.GET("/things/:id", func(c *gin.Context) {
+				logging.FromContext(c.Request.Context()).Info("Handling")
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/things/1", nil)
+			if tc.header != "" {
+				req.Header.Set(RequestIDHeader, tc.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			id := w.Header().Get(RequestIDHeader)
+			if tc.keepHeader {
+				assert.Equal(t, tc.header, id)
+			} else {
+				assert.Len(t, id, 36, "a UUID is generated")
+			}
+
+			entries := logs.All()
+			require.Len(t, entries, 2)
+			assert.Equal(t, "Handling", entries[0].Message)
+			assert.Equal(t, id, entries[0].ContextMap()["requestId"], "handlers log with the request ID")
+
+			access := entries[1].ContextMap()
+			assert.Equal(t, "Request completed", entries[1].Message)
+			assert.Equal(t, id, access["requestId"])
+			assert.Equal(t, "/things/:id", access["route"])
+			assert.EqualValues(t, http.StatusOK, access["status"])
+		})
+	}
+}
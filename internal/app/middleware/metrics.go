@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests that hit no route, so scanning unknown
+// paths cannot grow the number of series.
+const unmatchedRoute = "unmatched"
+
+// Metrics records the count and latency of every request by method, route
+// template and status, and counts requests rejected for insufficient stock.
+// Register it before ErrorHandler so it sees the final status.
+func Metrics(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(c.Writer.Status())
+		m.HTTPRequests.WithLabelValues(c.Request.Method, route, status).Inc()
+		m.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+
+		for _, ginErr := range c.Errors {
+			if errors.Is(ginErr.Err, domain.ErrInsufficientStock) {
+				m.InsufficientStockRejected.Inc()
+				break
+			}
+		}
+	}
+}
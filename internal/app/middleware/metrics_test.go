@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := metrics.New()
+	r := gin.New()
+	r.Use(Metrics(m), ErrorHandler())
+	r.NoRoute(NotFound)
+	r.GET("/products/:id", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	r.POST("/stock/movements", func(c *gin.Context) {
+		_ = c.Error(domain.ErrInsufficientStock)
+	})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/products/1", nil),
+		httptest.NewRequest(http.MethodGet, "/products/2", nil),
+		httptest.NewRequest(http.MethodPost, "/stock/movements", nil),
+		httptest.NewRequest(http.MethodGet, "/does/not/exist", nil),
+	} {
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.HTTPRequests.WithLabelValues("GET", "/products/:id", "200")), "routes are labelled by template")
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.HTTPRequests.WithLabelValues("POST", "/stock/movements", "409")), "status is the one written by the error handler")
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.HTTPRequests.WithLabelValues("GET", unmatchedRoute, "404")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.InsufficientStockRejected))
+	assert.Equal(t, 3, testutil.CollectAndCount(m.HTTPRequestDuration))
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/ratelimit"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rules := ratelimit.Rules{
+		Default: ratelimit.Limit{Requests: 100, Window: time.Minute},
+		Routes:  map[string]ratelimit.Limit{"POST /stock/movements": {Requests: 1, Window: 10 * time.Second, Burst: 2}},
+	}
+	// withPrincipal stands in for Authenticate.
+	withPrincipal := func(c *gin.Context) {
+		if key := c.GetHeader("X-Test-Key"); key != "" {
+			c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), auth.NewAPIKeyPrincipal(key, "default", nil)))
+		}
+	}
+
+	r := gin.New()
+	r.Use(RequestID(), ErrorHandler(), withPrincipal, RateLimit(ratelimit.NewMemoryStore(), rules))
+	r.POST("/stock/movements", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	r.GET("/products", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	send := func(method, path, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = "192.0.2.10:40000"
+		if key != "" {
+			req.Header.Set("X-Test-Key", key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(http.MethodPost, "/stock/movements", "orders")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "10", w.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "1;w=10;burst=2", w.Header().Get("RateLimit-Policy"))
+
+	assert.Equal(t, http.StatusCreated, send(http.MethodPost, "/stock/movements", "orders").Code)
+
+	w = send(http.MethodPost, "/stock/movements", "orders")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"rate_limited"`)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "10", w.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusCreated, send(http.MethodPost, "/stock/movements", "cart").Code, "keys have their own buckets")
+	assert.Equal(t, http.StatusCreated, send(http.MethodPost, "/stock/movements", "").Code, "anonymous clients are limited by IP")
+
+	w = send(http.MethodGet, "/products", "orders")
+	assert.Equal(t, http.StatusOK, w.Code, "other routes use the default bucket")
+	assert.Equal(t, "100", w.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimitAllowsRequestsWhenTheStoreFails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(RateLimit(failingStore{}, ratelimit.Rules{Default: ratelimit.Limit{Requests: 1, Window: time.Second}}))
+	r.GET("/products", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimitByIPLimitsRejectedCredentials(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	verifier, err := auth.NewVerifier(auth.VerifierOptions{HMACSecret: []byte(testSecret)})
+	require.NoError(t, err)
+	store := ratelimit.NewMemoryStore()
+
+	r := gin.New()
+	r.Use(
+		RequestID(), ErrorHandler(),
+		RateLimitByIP(store, ratelimit.Limit{Requests: 1, Window: time.Minute, Burst: 3}),
+		Authenticate(verifier, &stubResolver{}, &stubKeys{}, auth.DefaultPolicy()),
+		RateLimit(store, ratelimit.Rules{Default: ratelimit.Limit{Requests: 100, Window: time.Minute}}),
+	)
+	r.GET("/products", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	send := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/products", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-API-Key", "inv_guessed_key")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	var codes []int
+	for range 5 {
+		codes = append(codes, send("192.0.2.10:40000").Code)
+	}
+	assert.Equal(t, []int{
+		http.StatusUnauthorized, http.StatusUnauthorized, http.StatusUnauthorized,
+		http.StatusTooManyRequests, http.StatusTooManyRequests,
+	}, codes, "bad credentials use up the bucket of their address")
+	assert.Equal(t, http.StatusUnauthorized, send("198.51.100.7:40000").Code, "other addresses have their own buckets")
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/apperror"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Recovery turns a panic in a later handler into a 500 problem response and
+// logs it with its stack trace through the request logger. Register it
+// first so it covers every other middleware.
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// The client went away; net/http suppresses the stack trace for
+			// this value, so let it do so.
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			err, ok := recovered.(error)
+			if !ok {
+				err = fmt.Errorf("%v", recovered)
+			}
+			logging.FromContext(c.Request.Context()).Error(
+				"Panic recovered",
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.Request.URL.Path),
+				zap.Error(err),
+				zap.Stack("stack"),
+			)
+
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			appErr := apperror.ErrInternal.Wrap(err)
+			_ = c.Error(appErr)
+			abortWithProblem(c, appErr)
+		}()
+		c.Next()
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRecovery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	core, logs := observer.New(zap.DebugLevel)
+
+	r := gin.New()
+	r.Use(Recovery(), RequestID(), Logger(zap.New(core)), ErrorHandler())
+	r.GET("/boom", func(c *gin.Context) {
+		panic("nil map write")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/boom", nil)
+	req.Header.Set(RequestIDHeader, "req-9")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, problemContentType, w.Header().Get("Content-Type"))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "internal_error", body["code"])
+	assert.Equal(t, "Internal Server Error", body["detail"], "the panic value is not exposed")
+	assert.Equal(t, "req-9", body["requestId"])
+
+	panics := logs.FilterMessage("Panic recovered").All()
+	require.Len(t, panics, 1)
+	assert.Equal(t, zapcore.ErrorLevel, panics[0].Level)
+	assert.Equal(t, "req-9", panics[0].ContextMap()["requestId"])
+	assert.Contains(t, panics[0].ContextMap()["stack"], "TestRecovery")
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/apperror"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/tenant"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// TenantHeader names the tenant of unauthenticated requests.
+const TenantHeader = "X-Tenant-ID"
+
+// Tenant stores the tenant the request acts for in its context. It must run
+// after Authenticate.
+//
+// Authenticated requests act for the tenant of their credentials: the
+// tenant_id claim of the token or the tenant of the API key, else
+// defaultTenant. Without authentication the X-Tenant-ID header picks the
+// tenant, falling back to defaultTenant. A header naming another tenant than
+// the credentials is rejected with 403, so that a caller cannot reach the
+// data of another seller; requests left without a tenant get a 400.
+func Tenant(defaultTenant string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		header := c.GetHeader(TenantHeader)
+
+		id := header
+		if p, ok := auth.PrincipalFromContext(ctx); ok {
+			id = p.TenantID
+		}
+		if id == "" {
+			id = defaultTenant
+		}
+
+		var err error
+		switch {
+		case id == "":
+			err = apperror.ErrTenantRequired
+		case !tenant.Valid(id):
+			err = apperror.ErrInvalidTenant.Wrap(tenant.ErrInvalid)
+		case header != "" && header != id:
+			err = apperror.ErrTenantMismatch
+		}
+		if err != nil {
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+
+		ctx = tenant.WithID(ctx, id)
+		ctx = logging.WithLogger(ctx, logging.FromContext(ctx).With(zap.String("tenantId", id)))
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
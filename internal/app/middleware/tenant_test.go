@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/tenant"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		defaultTenant  string
+		principal      *auth.Principal
+		header         string
+		expectedStatus int
+		expectedCode   string
+		expectedTenant string
+	}{
+		{name: "header picks the tenant without authentication", defaultTenant: "default", header: "seller-a", expectedStatus: http.StatusOK, expectedTenant: "seller-a"},
+		{name: "default tenant without header", defaultTenant: "default", expectedStatus: http.StatusOK, expectedTenant: "default"},
+		{name: "no tenant at all", expectedStatus: http.StatusBadRequest, expectedCode: "tenant_required"},
+		{name: "malformed header", defaultTenant: "default", header: "Seller A", expectedStatus: http.StatusBadRequest, expectedCode: "invalid_tenant"},
+		{
+			name:           "token claim",
+			defaultTenant:  "default",
+			principal:      auth.NewPrincipal(auth.Claims{TenantID: "seller-a"}, "user-1", auth.DefaultPolicy()),
+			expectedStatus: http.StatusOK,
+			expectedTenant: "seller-a",
+		},
+		{
+			name:           "header matching the token claim",
+			principal:      auth.NewPrincipal(auth.Claims{TenantID: "seller-a"}, "user-1", auth.DefaultPolicy()),
+			header:         "seller-a",
+			expectedStatus: http.StatusOK,
+			expectedTenant: "seller-a",
+		},
+		{
+			name:           "header cannot switch the tenant of a token",
+			principal:      auth.NewPrincipal(auth.Claims{TenantID: "seller-a"}, "user-1", auth.DefaultPolicy()),
+			header:         "seller-b",
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "tenant_mismatch",
+		},
+		{
+			name:           "header cannot pick the tenant of a token without claim",
+			defaultTenant:  "default",
+			principal:      auth.NewPrincipal(auth.Claims{}, "user-1", auth.DefaultPolicy()),
+			header:         "seller-b",
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   "tenant_mismatch",
+		},
+		{
+			name:           "API key tenant",
+			principal:      auth.NewAPIKeyPrincipal("key-1", "seller-b", nil),
+			expectedStatus: http.StatusOK,
+			expectedTenant: "seller-b",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var resolved string
+			r := gin.New()
+			r.Use(RequestID(), ErrorHandler(), func(c *gin.Context) {
+				if tc.principal != nil {
+					c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), tc.principal))
+				}
+			}, Tenant(tc.defaultTenant))
+			r.GET("/products", func(c *gin.Context) {
+				resolved, _ = tenant.FromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/products", nil)
+			if tc.header != "" {
+				req.Header.Set(TenantHeader, tc.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedCode != "" {
+				assert.Contains(t, w.Body.String(), `"code":"`+tc.expectedCode+`"`)
+			}
+			assert.Equal(t, tc.expectedTenant, resolved)
+		})
+	}
+}
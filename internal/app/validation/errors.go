@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldErrors lists the failed rules of a validation error. It returns false
+// when err did not come from the validator.
+func FieldErrors(err error) ([]dtos.FieldErrorDTO, bool) {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil, false
+	}
+
+	fieldErrors := make([]dtos.FieldErrorDTO, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		fieldErrors = append(fieldErrors, dtos.FieldErrorDTO{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Message: message(fe),
+		})
+	}
+	return fieldErrors, true
+}
+
+// fieldPath drops the DTO type name from the namespace, so
+// "CreateCategoryDTO.name" becomes "name".
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
+
+func message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "max":
+		return fmt.Sprintf("must be at most %s characters long", fe.Param())
+	case "min":
+		return fmt.Sprintf("must be at least %s characters long", fe.Param())
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "gte":
+		return "must be greater than or equal to " + fe.Param()
+	case "positive":
+		return "must be greater than zero"
+	case "sku":
+		return "must be 3 to 64 upper-case letters or digits in dash separated groups, e.g. CHAIR-OAK-01"
+	case "future":
+		return "must be in the future"
+	default:
+		return "failed the " + fe.Tag() + " rule"
+	}
+}
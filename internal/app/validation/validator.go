@@ -0,0 +1,143 @@
+// Package validation checks request DTOs against their `validate` struct
+// tags and normalizes their string fields before handlers see them.
+package validation
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/go-playground/validator/v10"
+)
+
+// StructValidator implements gin's binding.StructValidator so that
+// ShouldBindJSON and ShouldBindQuery normalize and validate every DTO.
+type StructValidator struct {
+	validate *validator.Validate
+}
+
+func NewStructValidator() *StructValidator {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.SetTagName("validate")
+	v.RegisterTagNameFunc(fieldName)
+
+	_ = v.RegisterValidation("sku", validateSKU)
+	_ = v.RegisterValidation("positive", validatePositive)
+	_ = v.RegisterValidation("future", validateFuture)
+	v.RegisterStructValidation(validateStockMovement, dtos.CreateStockMovementDTO{})
+
+	return &StructValidator{validate: v}
+}
+
+// ValidateStruct trims string fields, applies `normalize` tags and then runs
+// the `validate` rules. Values other than structs, pointers to structs and
+// slices of them are accepted as is.
+func (s *StructValidator) ValidateStruct(obj any) error {
+	value := reflect.ValueOf(obj)
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil
+		}
+		normalize(value.Elem())
+		if value.Elem().Kind() != reflect.Struct {
+			return s.ValidateStruct(value.Elem().Interface())
+		}
+		return s.validate.Struct(obj)
+	case reflect.Struct:
+		return s.validate.Struct(obj)
+	case reflect.Slice:
+		for i := range value.Len() {
+			if err := s.ValidateStruct(value.Index(i).Addr().Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *StructValidator) Engine() any {
+	return s.validate
+}
+
+// fieldName reports fields under their JSON or query parameter name so that
+// error lists match what clients sent.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// normalize trims every string reachable from v and lower-cases those tagged
+// `normalize:"lower"`.
+func normalize(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			normalize(v.Elem())
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := range v.NumField() {
+			field := v.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			normalize(field)
+			if t.Field(i).Tag.Get("normalize") == "lower" && field.Kind() == reflect.String {
+				field.SetString(strings.ToLower(field.String()))
+			}
+		}
+	case reflect.Slice:
+		for i := range v.Len() {
+			normalize(v.Index(i))
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(strings.TrimSpace(v.String()))
+		}
+	}
+}
+
+// validateSKU applies the rule imports check SKUs against.
+func validateSKU(fl validator.FieldLevel) bool {
+	return domain.ValidSKU(fl.Field().String())
+}
+
+func validatePositive(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return field.Int() > 0
+	case reflect.Float32, reflect.Float64:
+		return field.Float() > 0
+	}
+	return false
+}
+
+func validateFuture(fl validator.FieldLevel) bool {
+	t, ok := fl.Field().Interface().(time.Time)
+	return ok && t.After(time.Now())
+}
+
+// validateStockMovement requires a positive quantity for receipts and
+// issues. Adjustments carry their own sign, so only zero is rejected for
+// them, which the required tag already covers.
+func validateStockMovement(sl validator.StructLevel) {
+	movement := sl.Current().Interface().(dtos.CreateStockMovementDTO)
+	if movement.MovementType == domain.MovementTypeAdjustment {
+		return
+	}
+	if err := sl.Validator().Var(movement.Quantity, "positive"); err != nil {
+		sl.ReportError(movement.Quantity, "quantity", "Quantity", "positive", "")
+	}
+}
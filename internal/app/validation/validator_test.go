@@ -0,0 +1,148 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateStruct(t *testing.T) {
+	floatPtr := func(f float64) *float64 { return &f }
+
+	tests := []struct {
+		name           string
+		obj            any
+		expectedErrors []dtos.FieldErrorDTO
+	}{
+		{
+			name: "valid category",
+			obj:  &dtos.CreateCategoryDTO{Name: "Furniture"},
+		},
+		{
+			name: "blank category name",
+			obj:  &dtos.CreateCategoryDTO{Name: "   "},
+			expectedErrors: []dtos.FieldErrorDTO{
+				{Field: "name", Rule: "required", Message: "is required"},
+			},
+		},
+		{
+			name: "receipt",
+			obj:  &dtos.CreateStockMovementDTO{ProductID: 1, MovementType: " Receipt ", Quantity: 5, UnitCost: floatPtr(2)},
+		},
+		{
+			name: "negative adjustment",
+			obj:  &dtos.CreateStockMovementDTO{ProductID: 1, MovementType: "adjustment", Quantity: -3},
+		},
+		{
+			name: "negative issue",
+			obj:  &dtos.CreateStockMovementDTO{ProductID: 1, MovementType: "issue", Quantity: -3},
+			expectedErrors: []dtos.FieldErrorDTO{
+				{Field: "quantity", Rule: "positive", Message: "must be greater than zero"},
+			},
+		},
+		{
+			name: "several invalid fields",
+			obj:  &dtos.CreateStockMovementDTO{MovementType: "transfer", Quantity: 1, UnitCost: floatPtr(-1)},
+			expectedErrors: []dtos.FieldErrorDTO{
+				{Field: "productId", Rule: "required", Message: "is required"},
+				{Field: "movementType", Rule: "oneof", Message: "must be one of: receipt, issue, adjustment"},
+				{Field: "unitCost", Rule: "gte", Message: "must be greater than or equal to 0"},
+			},
+		},
+		{
+			name: "invalid delete policy",
+			obj:  &dtos.DeleteCategoryDTO{Policy: "cascade"},
+			expectedErrors: []dtos.FieldErrorDTO{
+				{Field: "policy", Rule: "oneof", Message: "must be one of: restrict, reassign, soft"},
+			},
+		},
+	}
+
+	v := NewStructValidator()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v.ValidateStruct(tc.obj)
+			if tc.expectedErrors == nil {
+				assert.NoError(t, err)
+				return
+			}
+			fieldErrors, ok := FieldErrors(err)
+			assert.True(t, ok)
+			assert.Equal(t, tc.expectedErrors, fieldErrors)
+		})
+	}
+}
+
+func TestValidateStructNormalizesStrings(t *testing.T) {
+	dto := &dtos.CreateStockMovementDTO{ProductID: 1, MovementType: "  ISSUE ", Quantity: 2, Reason: "  damaged\t"}
+
+	assert.NoError(t, NewStructValidator().ValidateStruct(dto))
+	assert.Equal(t, "issue", dto.MovementType)
+	assert.Equal(t, "damaged", dto.Reason)
+}
+
+func TestSKURule(t *testing.T) {
+	tests := []struct {
+		name  string
+		obj   any
+		valid bool
+	}{
+		{name: "created with a SKU", obj: &dtos.CreateProductDTO{Name: "Oak chair", SKU: "CHAIR-OAK-01", CategoryID: 1}, valid: true},
+		{name: "created with a short SKU", obj: &dtos.CreateProductDTO{Name: "Oak chair", SKU: "A12", CategoryID: 1}, valid: true},
+		{name: "created with a lower-case SKU", obj: &dtos.CreateProductDTO{Name: "Oak chair", SKU: "chair-oak", CategoryID: 1}},
+		{name: "created with an empty group", obj: &dtos.CreateProductDTO{Name: "Oak chair", SKU: "CHAIR--OAK", CategoryID: 1}},
+		{name: "created with a SKU too short", obj: &dtos.CreateProductDTO{Name: "Oak chair", SKU: "AB", CategoryID: 1}},
+		{name: "updated without a SKU", obj: &dtos.UpdateProductDTO{Name: "Oak chair", CategoryID: 1}, valid: true},
+		{name: "updated with a SKU", obj: &dtos.UpdateProductDTO{Name: "Oak chair", SKU: "CHAIR-OAK-02", CategoryID: 1}, valid: true},
+		{name: "updated with a trailing dash", obj: &dtos.UpdateProductDTO{Name: "Oak chair", SKU: "CHAIR-", CategoryID: 1}},
+	}
+
+	v := NewStructValidator()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v.ValidateStruct(tc.obj)
+			if tc.valid {
+				assert.NoError(t, err)
+				return
+			}
+			fieldErrors, ok := FieldErrors(err)
+			assert.True(t, ok)
+			assert.Equal(t, []dtos.FieldErrorDTO{{
+				Field:   "sku",
+				Rule:    "sku",
+				Message: "must be 3 to 64 upper-case letters or digits in dash separated groups, e.g. CHAIR-OAK-01",
+			}}, fieldErrors)
+		})
+	}
+}
+
+func TestFutureRule(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		expiresAt *time.Time
+		valid     bool
+	}{
+		{name: "no expiry", valid: true},
+		{name: "future expiry", expiresAt: &future, valid: true},
+		{name: "past expiry", expiresAt: &past},
+	}
+
+	v := NewStructValidator()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := v.ValidateStruct(&dtos.CreateReservationDTO{ProductID: 1, Quantity: 2, ExpiresAt: tc.expiresAt})
+			if tc.valid {
+				assert.NoError(t, err)
+				return
+			}
+			fieldErrors, ok := FieldErrors(err)
+			assert.True(t, ok)
+			assert.Equal(t, []dtos.FieldErrorDTO{{Field: "expiresAt", Rule: "future", Message: "must be in the future"}}, fieldErrors)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, prefix, secretHash, err := GenerateAPIKey()
+	require.NoError(t, err)
+
+	parsedPrefix, secret, err := ParseAPIKey(key)
+	require.NoError(t, err)
+	assert.Equal(t, prefix, parsedPrefix)
+	assert.True(t, SecretMatches(secret, secretHash))
+	assert.NotContains(t, secretHash, secret, "only the hash is stored")
+
+	other, _, _, err := GenerateAPIKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestParseAPIKey(t *testing.T) {
+	for _, key := range []string{"", "inv_", "inv_abc", "inv__secret", "sk_abc_secret", "Bearer inv_abc_secret"} {
+		_, _, err := ParseAPIKey(key)
+		assert.ErrorIs(t, err, ErrInvalidAPIKey, key)
+	}
+
+	prefix, secret, err := ParseAPIKey("inv_abc_se_cret")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", prefix)
+	assert.Equal(t, "se_cret", secret, "base64url secrets may contain underscores")
+}
+
+func TestAPIKeyPrincipal(t *testing.T) {
+	p := NewAPIKeyPrincipal("key-1", "seller-42", []string{"catalog:read", "stock:reserve", "unknown"})
+
+	assert.Equal(t, "apikey:key-1", p.Subject)
+	assert.Equal(t, "seller-42", p.TenantID)
+	assert.True(t, p.Can(PermCategoriesRead))
+	assert.True(t, p.Can(PermProductsRead))
+	assert.True(t, p.Can(PermStockReserve))
+	assert.False(t, p.Can(PermStockWrite))
+	assert.False(t, p.Can(PermAPIKeysManage))
+}
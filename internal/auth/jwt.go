@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrMissingToken = errors.New("missing bearer token")
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// Claims are the token claims the service reads.
+type Claims struct {
+	jwt.RegisteredClaims
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Name              string `json:"name,omitempty"`
+	Email             string `json:"email,omitempty"`
+	// Roles are matched against the roles of the Policy.
+	Roles []string `json:"roles,omitempty"`
+	// TenantID is the seller the token acts for.
+	TenantID string `json:"tenant_id,omitempty"`
+}
+
+// VerifierOptions configures a Verifier. At least one of HMACSecret and
+// RSAKeys must be set.
+type VerifierOptions struct {
+	// HMACSecret verifies HS256 tokens.
+	HMACSecret []byte
+	// RSAKeys verifies RS256 tokens, by key ID.
+	RSAKeys map[string]*rsa.PublicKey
+	// Issuer and Audience are checked when not empty.
+	Issuer   string
+	Audience string
+	// Leeway tolerates clock skew on exp, nbf and iat.
+	Leeway time.Duration
+}
+
+// Verifier validates signed JWTs.
+type Verifier struct {
+	opts   VerifierOptions
+	parser *jwt.Parser
+}
+
+func NewVerifier(opts VerifierOptions) (*Verifier, error) {
+	var methods []string
+	if len(opts.HMACSecret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if len(opts.RSAKeys) > 0 {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if len(methods) == 0 {
+		return nil, errors.New("no HS256 secret or RS256 key configured")
+	}
+
+	parserOpts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithLeeway(opts.Leeway),
+		jwt.WithExpirationRequired(),
+	}
+	if opts.Issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOpts = append(parserOpts, jwt.WithAudience(opts.Audience))
+	}
+	return &Verifier{opts: opts, parser: jwt.NewParser(parserOpts...)}, nil
+}
+
+// Verify checks the signature and registered claims of token and returns
+// its claims. Every failure wraps ErrInvalidToken.
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	var claims Claims
+	if _, err := v.parser.ParseWithClaims(token, &claims, v.key); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing sub claim", ErrInvalidToken)
+	}
+	return &claims, nil
+}
+
+func (v *Verifier) key(token *jwt.Token) (any, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return v.opts.HMACSecret, nil
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := v.opts.RSAKeys[kid]; ok {
+			return key, nil
+		}
+		// A key set with a single key may be used by tokens without kid.
+		if kid == "" && len(v.opts.RSAKeys) == 1 {
+			for _, key := range v.opts.RSAKeys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
+
+type jsonWebKeySet struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		Alg string `json:"alg"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// LoadJWKS reads the RSA signing keys of a JSON Web Key Set file. Keys of
+// other types or meant for encryption are skipped.
+func LoadJWKS(path string) (map[string]*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var set jsonWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("parsing JWKS %s: %w", path, err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") || (k.Alg != "" && k.Alg != "RS256") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: invalid modulus: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: invalid exponent: %w", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS %s has no RS256 signing key", path)
+	}
+	return keys, nil
+}
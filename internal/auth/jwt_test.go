@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":                "auth0|42",
+		"iss":                "https://id.nexusmarket.test/",
+		"aud":                "inventory",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"preferred_username": "ana",
+	}
+}
+
+func writeJWKS(t *testing.T, kid string, key *rsa.PublicKey) string {
+	t.Helper()
+	set := map[string]any{"keys": []map[string]string{
+		{"kty": "EC", "kid": "ignored", "crv": "P-256"},
+		{
+			"kty": "RSA", "kid": kid, "use": "sig", "alg": "RS256",
+			"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		},
+	}}
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestVerifier(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keys, err := LoadJWKS(writeJWKS(t, "key-1", &rsaKey.PublicKey))
+	require.NoError(t, err)
+	require.Len(t, keys, 1, "non RSA keys are skipped")
+
+	verifier, err := NewVerifier(VerifierOptions{
+		HMACSecret: []byte(testSecret),
+		RSAKeys:    keys,
+		Issuer:     "https://id.nexusmarket.test/",
+		Audience:   "inventory",
+	})
+	require.NoError(t, err)
+
+	expired := validClaims()
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	wrongAudience := validClaims()
+	wrongAudience["aud"] = "billing"
+	noSubject := validClaims()
+	delete(noSubject, "sub")
+	noExpiry := validClaims()
+	delete(noExpiry, "exp")
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{name: "HS256", token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", validClaims()), valid: true},
+		{name: "RS256 with key ID", token: sign(t, jwt.SigningMethodRS256, rsaKey, "key-1", validClaims()), valid: true},
+		{name: "RS256 without key ID uses the only key", token: sign(t, jwt.SigningMethodRS256, rsaKey, "", validClaims()), valid: true},
+		{name: "RS256 signed by another key", token: sign(t, jwt.SigningMethodRS256, otherKey, "key-1", validClaims())},
+		{name: "unknown key ID", token: sign(t, jwt.SigningMethodRS256, rsaKey, "key-2", validClaims())},
+		{name: "HS256 with the wrong secret", token: sign(t, jwt.SigningMethodHS256, []byte("another-secret-another-secret-xx"), "", validClaims())},
+		{name: "disallowed algorithm", token: sign(t, jwt.SigningMethodHS512, []byte(testSecret), "", validClaims())},
+		{name: "unsigned", token: sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", validClaims())},
+		{name: "expired", token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", expired)},
+		{name: "without expiry", token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", noExpiry)},
+		{name: "wrong audience", token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", wrongAudience)},
+		{name: "without subject", token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", noSubject)},
+		{name: "malformed", token: "not.a.token"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			claims, err := verifier.Verify(tc.token)
+			if !tc.valid {
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "auth0|42", claims.Subject)
+			assert.Equal(t, "ana", claims.PreferredUsername)
+		})
+	}
+}
+
+func TestNewVerifierRequiresAKey(t *testing.T) {
+	_, err := NewVerifier(VerifierOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+// Package auth verifies the JWTs and API keys presented by API clients and
+// carries the authenticated principal in context.Context.
+package auth
+
+import "context"
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Subject is the sub claim of the token, or apikey:<id> for API keys.
+	Subject string
+	// UserID is the ID of the users row provisioned for Subject. It is empty
+	// for API keys.
+	UserID string
+	// APIKeyID is set when the caller authenticated with an API key.
+	APIKeyID string
+	// TenantID is the seller the token or API key acts for. It is empty for
+	// tokens without a tenant_id claim.
+	TenantID string
+	Roles    []string
+	Claims   Claims
+
+	policy      *Policy
+	permissions map[Permission]bool
+}
+
+// NewPrincipal returns the principal of a verified token. Its permissions
+// are those that policy grants to the roles claim.
+func NewPrincipal(claims Claims, userID string, policy *Policy) *Principal {
+	return &Principal{
+		Subject:     claims.Subject,
+		UserID:      userID,
+		TenantID:    claims.TenantID,
+		Roles:       claims.Roles,
+		Claims:      claims,
+		policy:      policy,
+		permissions: policy.permissionsOf(claims.Roles),
+	}
+}
+
+// NewJobPrincipal returns the principal of a background job acting on behalf
+// of userID in tenantID, e.g. a queued upload. Its subject is "job:<name>"
+// and it is granted only permissions.
+func NewJobPrincipal(name, userID, tenantID string, permissions ...Permission) *Principal {
+	granted := make(map[Permission]bool, len(permissions))
+	for _, perm := range permissions {
+		granted[perm] = true
+	}
+	return &Principal{
+		Subject:     "job:" + name,
+		UserID:      userID,
+		TenantID:    tenantID,
+		permissions: granted,
+	}
+}
+
+// Can reports whether the principal was granted perm.
+func (p *Principal) Can(perm Permission) bool {
+	return p.permissions[PermAll] || p.permissions[perm]
+}
+
+type contextKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying p.
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// PrincipalFromContext returns the principal of the request, if any.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(contextKey{}).(*Principal)
+	return p, ok
+}
+
+// UserID returns the ID of the authenticated user, or an empty string for
+// unauthenticated calls such as background jobs.
+func UserID(ctx context.Context) string {
+	if p, ok := PrincipalFromContext(ctx); ok {
+		return p.UserID
+	}
+	return ""
+}
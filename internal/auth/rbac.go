@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+type Permission string
+
+const (
+	PermCategoriesRead   Permission = "categories:read"
+	PermCategoriesWrite  Permission = "categories:write"
+	PermCategoriesDelete Permission = "categories:delete"
+	PermProductsRead     Permission = "products:read"
+	PermProductsWrite    Permission = "products:write"
+	PermStockRead        Permission = "stock:read"
+	PermStockWrite       Permission = "stock:write"
+	PermStockAdjust      Permission = "stock:adjust"
+	// PermStockAdjustLarge allows adjustments above the policy's
+	// LargeAdjustmentQuantity.
+	PermStockAdjustLarge Permission = "stock:adjust:large"
+	PermStockReserve     Permission = "stock:reserve"
+	PermReportsRead      Permission = "reports:read"
+	PermAPIKeysManage    Permission = "apikeys:manage"
+
+	// PermAll grants every permission.
+	PermAll Permission = "*"
+)
+
+var permissions = []Permission{
+	PermCategoriesRead, PermCategoriesWrite, PermCategoriesDelete,
+	PermProductsRead, PermProductsWrite,
+	PermStockRead, PermStockWrite, PermStockAdjust, PermStockAdjustLarge, PermStockReserve,
+	PermReportsRead, PermAPIKeysManage,
+	PermAll,
+}
+
+const (
+	RoleViewer  = "viewer"
+	RoleClerk   = "clerk"
+	RoleManager = "manager"
+	RoleAdmin   = "admin"
+	RoleService = "service"
+)
+
+var roles = []string{RoleViewer, RoleClerk, RoleManager, RoleAdmin, RoleService}
+
+var (
+	ErrForbidden = errors.New("permission denied")
+)
+
+// ForbiddenError reports the permission a caller lacked.
+type ForbiddenError struct {
+	Permission Permission
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("%s: %s required", ErrForbidden, e.Permission)
+}
+
+func (e *ForbiddenError) Unwrap() error {
+	return ErrForbidden
+}
+
+// Policy is the permission matrix of the roles.
+type Policy struct {
+	Roles map[string][]Permission `yaml:"roles"`
+	// LargeAdjustmentQuantity is the absolute adjustment quantity above
+	// which PermStockAdjustLarge is required. 0 disables the limit.
+	LargeAdjustmentQuantity int `yaml:"largeAdjustmentQuantity"`
+}
+
+// DefaultPolicy is used when no policy file is configured.
+func DefaultPolicy() *Policy {
+	viewer := []Permission{PermCategoriesRead, PermProductsRead, PermStockRead, PermReportsRead}
+	clerk := append(slices.Clone(viewer), PermStockWrite, PermStockAdjust)
+	manager := append(slices.Clone(clerk), PermStockAdjustLarge, PermCategoriesWrite, PermCategoriesDelete, PermProductsWrite)
+	return &Policy{
+		Roles: map[string][]Permission{
+			RoleViewer:  viewer,
+			RoleClerk:   clerk,
+			RoleManager: manager,
+			RoleAdmin:   {PermAll},
+			// Other services read the catalogue and move stock, e.g. on
+			// checkout, but never adjust it.
+			RoleService: {PermCategoriesRead, PermProductsRead, PermStockRead, PermStockWrite, PermStockReserve},
+		},
+		LargeAdjustmentQuantity: 100,
+	}
+}
+
+// LoadPolicy reads a policy file. Unknown roles and permissions are
+// rejected so that typos do not silently grant nothing.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var policy Policy
+	if err := decoder.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("parsing policy %s: %w", path, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("policy %s: %w", path, err)
+	}
+	return &policy, nil
+}
+
+func (p *Policy) validate() error {
+	var problems []error
+	for _, role := range slices.Sorted(maps.Keys(p.Roles)) {
+		granted := p.Roles[role]
+		if !slices.Contains(roles, role) {
+			problems = append(problems, fmt.Errorf("unknown role %q", role))
+		}
+		for _, perm := range granted {
+			if !slices.Contains(permissions, perm) {
+				problems = append(problems, fmt.Errorf("role %q: unknown permission %q", role, perm))
+			}
+		}
+	}
+	if p.LargeAdjustmentQuantity < 0 {
+		problems = append(problems, errors.New("largeAdjustmentQuantity cannot be negative"))
+	}
+	return errors.Join(problems...)
+}
+
+// permissionsOf returns the permissions granted to any of roles. Roles the
+// policy does not know grant nothing.
+func (p *Policy) permissionsOf(roles []string) map[Permission]bool {
+	granted := make(map[Permission]bool)
+	for _, role := range roles {
+		for _, perm := range p.Roles[role] {
+			granted[perm] = true
+		}
+	}
+	return granted
+}
+
+// Authorize returns a *ForbiddenError when the principal in ctx lacks perm,
+// and records the denial in the audit log. Calls without a principal, such
+// as background jobs or a service running with authentication disabled,
+// are allowed.
+func Authorize(ctx context.Context, perm Permission) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || p.Can(perm) {
+		return nil
+	}
+
+	roles := slices.Clone(p.Roles)
+	sort.Strings(roles)
+	logging.FromContext(ctx).Named("audit").Warn(
+		"Access denied",
+		zap.String("subject", p.Subject),
+		zap.Strings("roles", roles),
+		zap.String("permission", string(perm)),
+	)
+	return &ForbiddenError{Permission: perm}
+}
+
+// AuthorizeAdjustment checks that the principal in ctx may adjust stock by
+// quantity units, which needs PermStockAdjustLarge above the policy limit.
+func AuthorizeAdjustment(ctx context.Context, quantity int) error {
+	if err := Authorize(ctx, PermStockAdjust); err != nil {
+		return err
+	}
+	p, ok := PrincipalFromContext(ctx)
+	if !ok || p.policy == nil {
+		return nil
+	}
+	limit := p.policy.LargeAdjustmentQuantity
+	if quantity < 0 {
+		quantity = -quantity
+	}
+	if limit > 0 && quantity > limit {
+		return Authorize(ctx, PermStockAdjustLarge)
+	}
+	return nil
+}
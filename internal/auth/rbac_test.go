@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func principalWithRoles(roles ...string) context.Context {
+	claims := Claims{Roles: roles}
+	claims.Subject = "auth0|42"
+	return WithPrincipal(context.Background(), NewPrincipal(claims, "user-1", DefaultPolicy()))
+}
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		perm    Permission
+		allowed bool
+	}{
+		{name: "viewer reads", ctx: principalWithRoles(RoleViewer), perm: PermCategoriesRead, allowed: true},
+		{name: "viewer cannot move stock", ctx: principalWithRoles(RoleViewer), perm: PermStockWrite},
+		{name: "clerk cannot delete categories", ctx: principalWithRoles(RoleClerk), perm: PermCategoriesDelete},
+		{name: "manager deletes categories", ctx: principalWithRoles(RoleManager), perm: PermCategoriesDelete, allowed: true},
+		{name: "admin has every permission", ctx: principalWithRoles(RoleAdmin), perm: PermStockAdjustLarge, allowed: true},
+		{name: "roles are combined", ctx: principalWithRoles(RoleService, RoleViewer), perm: PermReportsRead, allowed: true},
+		{name: "unknown roles grant nothing", ctx: principalWithRoles("root"), perm: PermCategoriesRead},
+		{name: "no roles", ctx: principalWithRoles(), perm: PermCategoriesRead},
+		{name: "no principal", ctx: context.Background(), perm: PermCategoriesDelete, allowed: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Authorize(tc.ctx, tc.perm)
+			if tc.allowed {
+				assert.NoError(t, err)
+				return
+			}
+			var forbiddenErr *ForbiddenError
+			require.ErrorAs(t, err, &forbiddenErr)
+			assert.ErrorIs(t, err, ErrForbidden)
+			assert.Equal(t, tc.perm, forbiddenErr.Permission)
+		})
+	}
+}
+
+func TestAuthorizeAdjustment(t *testing.T) {
+	tests := []struct {
+		name     string
+		role     string
+		quantity int
+		expected Permission
+	}{
+		{name: "clerk adjusts up to the limit", role: RoleClerk, quantity: 100},
+		{name: "clerk cannot write off more than the limit", role: RoleClerk, quantity: -101, expected: PermStockAdjustLarge},
+		{name: "manager makes large adjustments", role: RoleManager, quantity: 5000},
+		{name: "service cannot adjust", role: RoleService, quantity: 1, expected: PermStockAdjust},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := AuthorizeAdjustment(principalWithRoles(tc.role), tc.quantity)
+			if tc.expected == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var forbiddenErr *ForbiddenError
+			require.ErrorAs(t, err, &forbiddenErr)
+			assert.Equal(t, tc.expected, forbiddenErr.Permission)
+		})
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	policy, err := LoadPolicy("../../configs/policy.example.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPolicy().LargeAdjustmentQuantity, policy.LargeAdjustmentQuantity)
+	for role, granted := range DefaultPolicy().Roles {
+		assert.ElementsMatch(t, granted, policy.Roles[role], "the example mirrors the built-in %s role", role)
+	}
+
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("roles:\n  auditor: [reports:read]\n  clerk: [stock:delete]\n"), 0o600))
+	_, err = LoadPolicy(path)
+	assert.ErrorContains(t, err, `unknown role "auditor"`)
+	assert.ErrorContains(t, err, `role "clerk": unknown permission "stock:delete"`)
+}
@@ -0,0 +1,381 @@
+// Package config loads the service configuration from an optional YAML file
+// and environment variables. Values are resolved in this order, later ones
+// winning: built-in defaults, the YAML file, environment variables.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Server       ServerConfig       `yaml:"server"`
+	Database     DatabaseConfig     `yaml:"database"`
+	Log          LogConfig          `yaml:"log"`
+	Auth         AuthConfig         `yaml:"auth"`
+	Tenancy      TenancyConfig      `yaml:"tenancy"`
+	Health       HealthConfig       `yaml:"health"`
+	Metrics      MetricsConfig      `yaml:"metrics"`
+	Tracing      TracingConfig      `yaml:"tracing"`
+	RateLimit    RateLimitConfig    `yaml:"rateLimit"`
+	Imports      ImportsConfig      `yaml:"imports"`
+	Exports      ExportsConfig      `yaml:"exports"`
+	Reservations ReservationsConfig `yaml:"reservations"`
+	Features     FeatureConfig      `yaml:"features"`
+	Kafka        KafkaConfig        `yaml:"kafka"`
+	Mongo        MongoConfig        `yaml:"mongo"`
+	Redis        RedisConfig        `yaml:"redis"`
+}
+
+type ServerConfig struct {
+	Addr              string        `yaml:"addr" env:"SERVER_ADDR"`
+	ReadHeaderTimeout time.Duration `yaml:"readHeaderTimeout" env:"SERVER_READ_HEADER_TIMEOUT"`
+	ReadTimeout       time.Duration `yaml:"readTimeout" env:"SERVER_READ_TIMEOUT"`
+	WriteTimeout      time.Duration `yaml:"writeTimeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout       time.Duration `yaml:"idleTimeout" env:"SERVER_IDLE_TIMEOUT"`
+	// ShutdownTimeout bounds draining requests, stopping workers and closing
+	// resources once SIGTERM is received.
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+	// TrustedProxies are the addresses or CIDRs whose X-Forwarded-For header
+	// is believed when finding the client IP. Empty trusts none.
+	TrustedProxies []string `yaml:"trustedProxies" env:"SERVER_TRUSTED_PROXIES"`
+}
+
+type DatabaseConfig struct {
+	Host     string `yaml:"host" env:"DB_HOST"`
+	Port     int    `yaml:"port" env:"DB_PORT"`
+	User     string `yaml:"user" env:"DB_USER"`
+	Password string `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name     string `yaml:"name" env:"DB_NAME"`
+	// SSLMode is one of the libpq sslmode values: disable, allow, prefer,
+	// require, verify-ca or verify-full.
+	SSLMode         string        `yaml:"sslMode" env:"DB_SSLMODE"`
+	MaxOpenConns    int           `yaml:"maxOpenConns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"maxIdleConns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `yaml:"connMaxIdleTime" env:"DB_CONN_MAX_IDLE_TIME"`
+	// QueryTimeout bounds the database work of each HTTP request; 0 leaves
+	// requests without a deadline.
+	QueryTimeout time.Duration `yaml:"queryTimeout" env:"DB_QUERY_TIMEOUT"`
+	// AutoMigrate applies pending migrations at startup. Replicas starting
+	// together take turns through a Postgres advisory lock.
+	AutoMigrate bool `yaml:"autoMigrate" env:"DB_AUTO_MIGRATE"`
+	// MigrationTimeout bounds waiting for the migration lock and applying
+	// migrations, at startup and with the migrate command.
+	MigrationTimeout time.Duration `yaml:"migrationTimeout" env:"DB_MIGRATION_TIMEOUT"`
+}
+
+// DSN returns the connection string in libpq key/value form.
+func (c DatabaseConfig) DSN() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		quoteDSNValue(c.Host), c.Port, quoteDSNValue(c.User), quoteDSNValue(c.Password),
+		quoteDSNValue(c.Name), quoteDSNValue(c.SSLMode))
+}
+
+type LogConfig struct {
+	// Level is debug, info, warn or error.
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+	// Format is json for production or console for local development.
+	Format string `yaml:"format" env:"LOG_FORMAT"`
+}
+
+// AuthConfig configures JWT authentication of the API routes. Health,
+// metrics and Swagger routes stay public.
+type AuthConfig struct {
+	Enabled bool `yaml:"enabled" env:"AUTH_ENABLED"`
+	// HS256Secret verifies HS256 tokens.
+	HS256Secret string `yaml:"hs256Secret" env:"AUTH_HS256_SECRET" secret:"true"`
+	// JWKSFile is a local JSON Web Key Set whose RSA keys verify RS256
+	// tokens.
+	JWKSFile string `yaml:"jwksFile" env:"AUTH_JWKS_FILE"`
+	// Issuer and Audience, when set, must match the iss and aud claims.
+	Issuer   string        `yaml:"issuer" env:"AUTH_ISSUER"`
+	Audience string        `yaml:"audience" env:"AUTH_AUDIENCE"`
+	Leeway   time.Duration `yaml:"leeway" env:"AUTH_LEEWAY"`
+	// PolicyFile holds the role permission matrix. The built-in policy is
+	// used when it is empty.
+	PolicyFile string `yaml:"policyFile" env:"AUTH_POLICY_FILE"`
+}
+
+// TenancyConfig configures how requests are mapped to the seller whose data
+// they read and write.
+type TenancyConfig struct {
+	// DefaultTenant serves requests that name no tenant: tokens without a
+	// tenant_id claim and unauthenticated requests without X-Tenant-ID.
+	// Empty rejects them.
+	DefaultTenant string `yaml:"defaultTenant" env:"TENANCY_DEFAULT_TENANT"`
+}
+
+type HealthConfig struct {
+	// CheckTimeout bounds each dependency check of the readiness probe.
+	CheckTimeout time.Duration `yaml:"checkTimeout" env:"HEALTH_CHECK_TIMEOUT"`
+}
+
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled" env:"METRICS_ENABLED"`
+	Path    string `yaml:"path" env:"METRICS_PATH"`
+	// WatchedProducts are the product IDs whose stock level is exported as a
+	// gauge. Each one adds a time series, so keep the list short.
+	WatchedProducts []int `yaml:"watchedProducts" env:"METRICS_WATCHED_PRODUCTS"`
+}
+
+type TracingConfig struct {
+	Enabled     bool   `yaml:"enabled" env:"TRACING_ENABLED"`
+	ServiceName string `yaml:"serviceName" env:"OTEL_SERVICE_NAME"`
+	// Exporter is otlp, stdout, or auto to use OTLP when an endpoint is set
+	// and stdout otherwise.
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER"`
+	// Endpoint is the host:port of the OTLP/HTTP collector.
+	Endpoint    string  `yaml:"endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	Insecure    bool    `yaml:"insecure" env:"OTEL_EXPORTER_OTLP_INSECURE"`
+	SampleRatio float64 `yaml:"sampleRatio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+// RateLimitConfig limits the API requests of each client with a token
+// bucket: an API key, else a user, else an IP address.
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	// Store is memory, which limits each instance on its own, or redis,
+	// which shares the buckets between instances.
+	Store string `yaml:"store" env:"RATE_LIMIT_STORE"`
+	// Requests per Window refill a client's bucket, which holds up to Burst
+	// requests. Routes without their own limit share this bucket.
+	Requests int           `yaml:"requests" env:"RATE_LIMIT_REQUESTS"`
+	Window   time.Duration `yaml:"window" env:"RATE_LIMIT_WINDOW"`
+	Burst    int           `yaml:"burst" env:"RATE_LIMIT_BURST"`
+	// Routes get a bucket of their own per client. They can only be set in
+	// the config file.
+	Routes []RouteRateLimit `yaml:"routes"`
+	// IP limits every address before authentication, so that requests
+	// with bad credentials are limited too.
+	IP IPRateLimit `yaml:"ip"`
+}
+
+// IPRateLimit is the bucket of an IP address. Clients behind the same proxy
+// share it, so it should be looser than the per-client limit.
+type IPRateLimit struct {
+	Requests int           `yaml:"requests" env:"RATE_LIMIT_IP_REQUESTS"`
+	Window   time.Duration `yaml:"window" env:"RATE_LIMIT_IP_WINDOW"`
+	Burst    int           `yaml:"burst" env:"RATE_LIMIT_IP_BURST"`
+}
+
+// RouteRateLimit limits one route, given as its method and gin path, such
+// as "DELETE /categories/:id".
+type RouteRateLimit struct {
+	Route    string        `yaml:"route"`
+	Requests int           `yaml:"requests"`
+	Window   time.Duration `yaml:"window"`
+	Burst    int           `yaml:"burst"`
+}
+
+// ImportsConfig limits the product imports and tunes the worker running
+// them.
+type ImportsConfig struct {
+	// MaxBytes caps the size of an uploaded file.
+	MaxBytes int `yaml:"maxBytes" env:"IMPORTS_MAX_BYTES"`
+	// MaxRows caps the rows of a file; larger files fail.
+	MaxRows int `yaml:"maxRows" env:"IMPORTS_MAX_ROWS"`
+	// PollInterval is how often the worker looks for queued imports.
+	PollInterval time.Duration `yaml:"pollInterval" env:"IMPORTS_POLL_INTERVAL"`
+	// JobTimeout bounds running an import. Imports running for longer, e.g.
+	// because their instance died, are failed.
+	JobTimeout time.Duration `yaml:"jobTimeout" env:"IMPORTS_JOB_TIMEOUT"`
+}
+
+// ExportsConfig tunes the bulk exports.
+type ExportsConfig struct {
+	// Timeout bounds streaming an export. It replaces the query timeout of
+	// the database and the write timeout of the server for the exports.
+	Timeout time.Duration `yaml:"timeout" env:"EXPORTS_TIMEOUT"`
+}
+
+// ReservationsConfig tunes the worker expiring reservations.
+type ReservationsConfig struct {
+	// ExpiryInterval is how often the worker expires the reservations past
+	// their expiresAt.
+	ExpiryInterval time.Duration `yaml:"expiryInterval" env:"RESERVATIONS_EXPIRY_INTERVAL"`
+}
+
+// FeatureConfig switches optional parts of the API on or off.
+type FeatureConfig struct {
+	Swagger       bool `yaml:"swagger" env:"FEATURE_SWAGGER"`
+	ProductSearch bool `yaml:"productSearch" env:"FEATURE_PRODUCT_SEARCH"`
+}
+
+type KafkaConfig struct {
+	Enabled bool `yaml:"enabled" env:"KAFKA_ENABLED"`
+	// Required makes the service unready while no broker is reachable.
+	Required bool     `yaml:"required" env:"KAFKA_REQUIRED"`
+	Brokers  []string `yaml:"brokers" env:"KAFKA_BROKERS"`
+	Topic    string   `yaml:"topic" env:"KAFKA_TOPIC"`
+	ClientID string   `yaml:"clientId" env:"KAFKA_CLIENT_ID"`
+}
+
+type RedisConfig struct {
+	Addr     string `yaml:"addr" env:"REDIS_ADDR"`
+	Password string `yaml:"password" env:"REDIS_PASSWORD" secret:"true"`
+	DB       int    `yaml:"db" env:"REDIS_DB"`
+}
+
+type MongoConfig struct {
+	Enabled bool `yaml:"enabled" env:"MONGO_ENABLED"`
+	// Required makes the service unready while Mongo is unreachable.
+	Required bool   `yaml:"required" env:"MONGO_REQUIRED"`
+	URI      string `yaml:"uri" env:"MONGO_URI" secret:"true"`
+	Database string `yaml:"database" env:"MONGO_DATABASE"`
+}
+
+// Default returns the configuration used when nothing overrides it.
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Addr:              ":8090",
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       60 * time.Second,
+			ShutdownTimeout:   20 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			SSLMode:         "prefer",
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			QueryTimeout:    5 * time.Second,
+			// Five minutes leaves room for migrations that rewrite large
+			// tables.
+			MigrationTimeout: 5 * time.Minute,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Auth: AuthConfig{
+			Enabled: true,
+			Leeway:  30 * time.Second,
+		},
+		Tenancy: TenancyConfig{
+			// Rows that predate tenancy were moved to this tenant.
+			DefaultTenant: "default",
+		},
+		Health: HealthConfig{
+			CheckTimeout: 2 * time.Second,
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+			Path:    "/metrics",
+		},
+		Tracing: TracingConfig{
+			ServiceName: "ms-nexusmarket-inventory",
+			Exporter:    "auto",
+			SampleRatio: 1,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:  true,
+			Store:    "memory",
+			Requests: 50,
+			Window:   time.Second,
+			Burst:    100,
+			IP: IPRateLimit{
+				Requests: 100,
+				Window:   time.Second,
+				Burst:    200,
+			},
+		},
+		Imports: ImportsConfig{
+			MaxBytes:     10 << 20,
+			MaxRows:      10000,
+			PollInterval: 2 * time.Second,
+			JobTimeout:   15 * time.Minute,
+		},
+		Exports: ExportsConfig{
+			Timeout: 30 * time.Minute,
+		},
+		Reservations: ReservationsConfig{
+			ExpiryInterval: time.Minute,
+		},
+		Features: FeatureConfig{
+			Swagger:       true,
+			ProductSearch: true,
+		},
+		Kafka: KafkaConfig{
+			Required: true,
+			Topic:    "inventory-events",
+			ClientID: "ms-nexusmarket-inventory",
+		},
+		Mongo: MongoConfig{
+			Required: true,
+			Database: "ms_nexusmarket_inventory",
+		},
+	}
+}
+
+// Load builds the configuration from path, when not empty, and the
+// environment. The returned error lists every invalid setting.
+func Load(path string) (Config, error) {
+	return load(path, os.LookupEnv)
+}
+
+// LoadDatabase is Load for commands that only talk to the database: only the
+// database and log settings are validated, so that, for instance, a
+// migration job needs no auth secret.
+func LoadDatabase(path string) (Config, error) {
+	return loadDatabase(path, os.LookupEnv)
+}
+
+func load(path string, lookupEnv func(string) (string, bool)) (Config, error) {
+	cfg, err := read(path, lookupEnv)
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.Validate()
+}
+
+func loadDatabase(path string, lookupEnv func(string) (string, bool)) (Config, error) {
+	cfg, err := read(path, lookupEnv)
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.ValidateDatabase()
+}
+
+// read builds the configuration from the defaults, the file at path and the
+// environment, without validating it.
+func read(path string, lookupEnv func(string) (string, bool)) (Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return cfg, fmt.Errorf("reading config file: %w", err)
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return cfg, fmt.Errorf("parsing config file %s: %w", path, err)
+		}
+	}
+
+	if err := applyEnv(&cfg, lookupEnv); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// quoteDSNValue quotes v as required by the libpq key/value syntax.
+func quoteDSNValue(v string) string {
+	if v != "" && !strings.ContainsAny(v, ` '\`) {
+		return v
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(v) + "'"
+}
@@ -0,0 +1,16 @@
+package config
+
+import "reflect"
+
+const redacted = "[REDACTED]"
+
+// Redacted returns a copy of c with the fields tagged `secret` masked, safe
+// to log.
+func (c Config) Redacted() Config {
+	walkFields(reflect.ValueOf(&c).Elem(), func(field reflect.Value, tag reflect.StructTag) {
+		if tag.Get("secret") == "true" && field.Kind() == reflect.String && field.String() != "" {
+			field.SetString(redacted)
+		}
+	})
+	return c
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/ratelimit"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/tenant"
+)
+
+var (
+	sslModes   = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	logLevels  = []string{"debug", "info", "warn", "error"}
+	logFormats = []string{"json", "console"}
+	exporters  = []string{"auto", "otlp", "stdout"}
+	rateStores = []string{"memory", "redis"}
+)
+
+// ValidationError lists every invalid setting found in a configuration.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// checkFunc records a problem described by format and args unless ok.
+type checkFunc func(ok bool, format string, args ...any)
+
+// problemCollector returns a checkFunc appending to problems.
+func problemCollector(problems *[]string) checkFunc {
+	return func(ok bool, format string, args ...any) {
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf(format, args...))
+		}
+	}
+}
+
+// ValidateDatabase is Validate restricted to the database and log settings,
+// for commands that only talk to the database, such as migrations.
+func (c Config) ValidateDatabase() error {
+	var problems []string
+	c.checkDatabase(problemCollector(&problems))
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+func (c Config) checkDatabase(check checkFunc) {
+	db := c.Database
+	check(db.Host != "", "database.host is required")
+	check(db.Port > 0 && db.Port <= 65535, "database.port must be between 1 and 65535")
+	check(db.User != "", "database.user is required")
+	check(db.Name != "", "database.name is required")
+	check(slices.Contains(sslModes, db.SSLMode), "database.sslMode must be one of %s", strings.Join(sslModes, ", "))
+	check(db.MaxOpenConns >= 0, "database.maxOpenConns cannot be negative")
+	check(db.MaxIdleConns >= 0, "database.maxIdleConns cannot be negative")
+	check(db.MaxOpenConns == 0 || db.MaxIdleConns <= db.MaxOpenConns, "database.maxIdleConns cannot exceed database.maxOpenConns")
+	check(db.ConnMaxLifetime >= 0, "database.connMaxLifetime cannot be negative")
+	check(db.ConnMaxIdleTime >= 0, "database.connMaxIdleTime cannot be negative")
+	check(db.QueryTimeout >= 0, "database.queryTimeout cannot be negative")
+	check(db.MigrationTimeout > 0, "database.migrationTimeout must be positive")
+
+	check(slices.Contains(logLevels, c.Log.Level), "log.level must be one of %s", strings.Join(logLevels, ", "))
+	check(slices.Contains(logFormats, c.Log.Format), "log.format must be one of %s", strings.Join(logFormats, ", "))
+}
+
+// Validate returns a *ValidationError describing all invalid settings, or
+// nil.
+func (c Config) Validate() error {
+	var problems []string
+	check := problemCollector(&problems)
+
+	s := c.Server
+	check(s.Addr != "", "server.addr is required")
+	check(s.ReadHeaderTimeout > 0, "server.readHeaderTimeout must be positive")
+	check(s.ReadTimeout > 0, "server.readTimeout must be positive")
+	check(s.WriteTimeout > 0, "server.writeTimeout must be positive")
+	check(s.IdleTimeout > 0, "server.idleTimeout must be positive")
+	check(s.ShutdownTimeout > 0, "server.shutdownTimeout must be positive")
+
+	c.checkDatabase(check)
+
+	if c.Auth.Enabled {
+		check(c.Auth.HS256Secret != "" || c.Auth.JWKSFile != "", "auth.hs256Secret or auth.jwksFile is required when auth is enabled")
+		check(c.Auth.HS256Secret == "" || len(c.Auth.HS256Secret) >= 32, "auth.hs256Secret must be at least 32 bytes")
+		check(c.Auth.Leeway >= 0, "auth.leeway cannot be negative")
+	}
+
+	check(c.Tenancy.DefaultTenant == "" || tenant.Valid(c.Tenancy.DefaultTenant),
+		"tenancy.defaultTenant must be lowercase letters, digits, '-' and '_', up to 64 characters")
+
+	check(c.Health.CheckTimeout > 0, "health.checkTimeout must be positive")
+
+	if c.Metrics.Enabled {
+		check(strings.HasPrefix(c.Metrics.Path, "/"), "metrics.path must start with /")
+	}
+	for _, id := range c.Metrics.WatchedProducts {
+		check(id > 0, "metrics.watchedProducts must contain positive product IDs")
+	}
+
+	if c.Tracing.Enabled {
+		t := c.Tracing
+		check(t.ServiceName != "", "tracing.serviceName is required when tracing is enabled")
+		check(slices.Contains(exporters, t.Exporter), "tracing.exporter must be one of %s", strings.Join(exporters, ", "))
+		check(t.Exporter != "otlp" || t.Endpoint != "", "tracing.endpoint is required with the otlp exporter")
+		check(t.SampleRatio >= 0 && t.SampleRatio <= 1, "tracing.sampleRatio must be between 0 and 1")
+	}
+
+	if c.RateLimit.Enabled {
+		rl := c.RateLimit
+		check(slices.Contains(rateStores, rl.Store), "rateLimit.store must be one of %s", strings.Join(rateStores, ", "))
+		check(rl.Store != "redis" || c.Redis.Addr != "", "redis.addr is required with the redis rate limit store")
+		checkLimit := func(name string, requests int, window time.Duration, burst int) {
+			check(requests > 0, "%s.requests must be positive", name)
+			check(window >= time.Second, "%s.window must be at least 1s", name)
+			check(burst >= 0, "%s.burst cannot be negative", name)
+		}
+		checkLimit("rateLimit", rl.Requests, rl.Window, rl.Burst)
+		checkLimit("rateLimit.ip", rl.IP.Requests, rl.IP.Window, rl.IP.Burst)
+		for i, route := range rl.Routes {
+			name := fmt.Sprintf("rateLimit.routes[%d]", i)
+			_, ok := ratelimit.ParseRoute(route.Route)
+			check(ok, "%s.route must be a method and a path, such as \"POST /stock/movements\"", name)
+			checkLimit(name, route.Requests, route.Window, route.Burst)
+		}
+	}
+
+	check(c.Imports.MaxBytes > 0, "imports.maxBytes must be positive")
+	check(c.Imports.MaxRows > 0, "imports.maxRows must be positive")
+	check(c.Imports.PollInterval > 0, "imports.pollInterval must be positive")
+	check(c.Imports.JobTimeout > 0, "imports.jobTimeout must be positive")
+	check(c.Exports.Timeout > 0, "exports.timeout must be positive")
+	check(c.Reservations.ExpiryInterval > 0, "reservations.expiryInterval must be positive")
+
+	if c.Kafka.Enabled {
+		check(len(c.Kafka.Brokers) > 0, "kafka.brokers is required when kafka is enabled")
+		check(c.Kafka.Topic != "", "kafka.topic is required when kafka is enabled")
+	}
+	if c.Mongo.Enabled {
+		check(c.Mongo.URI != "", "mongo.uri is required when mongo is enabled")
+		check(c.Mongo.Database != "", "mongo.database is required when mongo is enabled")
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// APIKey authenticates another service. Only the SHA-256 of the secret part
+// is stored; the full key is shown once, when it is created or rotated.
+type APIKey struct {
+	ID string
+	// TenantID is the seller the key acts for.
+	TenantID string
+	Name     string
+	// Prefix is the public part of the key, used to look it up.
+	Prefix     string
+	SecretHash string
+	Scopes     []string
+	// CreatedBy is the user who created the key.
+	CreatedBy  string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	LastUsedAt *time.Time
+	RotatedAt  *time.Time
+	RevokedAt  *time.Time
+}
+
+func (k *APIKey) Revoked() bool {
+	return k.RevokedAt != nil
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *APIKey) error
+	GetByID(ctx context.Context, id string) (*APIKey, error)
+	GetByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+	// List returns every key, revoked ones included, newest first.
+	List(ctx context.Context) ([]*APIKey, error)
+	Revoke(ctx context.Context, id string, at time.Time) error
+	// UpdateSecret replaces the key's prefix and secret hash.
+	UpdateSecret(ctx context.Context, id, prefix, secretHash string, at time.Time) error
+	// TouchLastUsed records at as the last use unless a use less than
+	// interval before it was already recorded.
+	TouchLastUsed(ctx context.Context, id string, at time.Time, interval time.Duration) error
+}
@@ -1,10 +1,58 @@
 package domain
 
-import "time"
+import (
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Category struct {
 	ID        int
+	TenantID  string
 	Name      string
+	ParentID  *int
 	CreatedAt time.Time
 	UpdatedAt *time.Time
+	DeletedAt gorm.DeletedAt
+}
+
+// CategoryNode is a category together with its direct subcategories.
+type CategoryNode struct {
+	Category *Category
+	Children []*CategoryNode
+}
+
+// BuildCategoryTree arranges a flat list of categories into a forest rooted
+// at the categories without a parent. Categories whose parent is not in the
+// list are treated as roots. Siblings are sorted by name.
+func BuildCategoryTree(categories []*Category) []*CategoryNode {
+	nodes := make(map[int]*CategoryNode, len(categories))
+	for _, c := range categories {
+		nodes[c.ID] = &CategoryNode{Category: c}
+	}
+
+	var roots []*CategoryNode
+	for _, c := range categories {
+		node := nodes[c.ID]
+		if c.ParentID != nil {
+			if parent, ok := nodes[*c.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	sortCategoryNodes(roots)
+	return roots
+}
+
+func sortCategoryNodes(nodes []*CategoryNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Category.Name < nodes[j].Category.Name
+	})
+	for _, n := range nodes {
+		sortCategoryNodes(n.Children)
+	}
 }
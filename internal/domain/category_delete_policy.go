@@ -0,0 +1,49 @@
+package domain
+
+import "fmt"
+
+// CategoryDeletePolicy decides what happens to the products and subcategories
+// of a category being deleted.
+type CategoryDeletePolicy string
+
+const (
+	// CategoryDeleteRestrict refuses to delete a category that still has
+	// products or subcategories.
+	CategoryDeleteRestrict CategoryDeletePolicy = "restrict"
+	// CategoryDeleteReassign moves products and subcategories to another
+	// category before deleting.
+	CategoryDeleteReassign CategoryDeletePolicy = "reassign"
+	// CategoryDeleteSoft hides the category but keeps the row, so products
+	// keep their reference. Subcategories still block the deletion.
+	CategoryDeleteSoft CategoryDeletePolicy = "soft"
+)
+
+// ParseCategoryDeletePolicy validates a policy name, defaulting to restrict.
+func ParseCategoryDeletePolicy(s string) (CategoryDeletePolicy, error) {
+	switch CategoryDeletePolicy(s) {
+	case "", CategoryDeleteRestrict:
+		return CategoryDeleteRestrict, nil
+	case CategoryDeleteReassign:
+		return CategoryDeleteReassign, nil
+	case CategoryDeleteSoft:
+		return CategoryDeleteSoft, nil
+	default:
+		return "", ErrInvalidDeletePolicy
+	}
+}
+
+// CategoryInUseError lists what prevents a category from being deleted.
+type CategoryInUseError struct {
+	CategoryID    int
+	Products      []*Product
+	Subcategories []*Category
+}
+
+func (e *CategoryInUseError) Error() string {
+	return fmt.Sprintf("category %d is referenced by %d products and %d subcategories",
+		e.CategoryID, len(e.Products), len(e.Subcategories))
+}
+
+func (e *CategoryInUseError) Is(target error) bool {
+	return target == ErrCategoryInUse
+}
@@ -1,9 +1,22 @@
 package domain
 
+import "context"
+
 type CategoryRepository interface {
-	Create(category *Category) error
-	GetByID(id int) (*Category, error)
-	ListAll() ([]*Category, error)
-	Update(category *Category) error
-	Delete(id int) error
+	Create(ctx context.Context, category *Category) error
+	GetByID(ctx context.Context, id int) (*Category, error)
+	GetDeletedByID(ctx context.Context, id int) (*Category, error)
+	ListAll(ctx context.Context, includeDeleted bool) ([]*Category, error)
+	List(ctx context.Context, query ListQuery) (*Page[*Category], error)
+	// ListDescendants returns the subcategories of a category at any depth,
+	// including the soft-deleted ones and their own subcategories when
+	// includeDeleted is set.
+	ListDescendants(ctx context.Context, id int, includeDeleted bool) ([]*Category, error)
+	Update(ctx context.Context, category *Category) error
+	ReassignChildren(ctx context.Context, fromParentID, toParentID int) error
+	Delete(ctx context.Context, id int) error
+	SoftDelete(ctx context.Context, id int) error
+	// Restore fails with ErrCategoryNameTaken when an active category took
+	// the name in the meantime.
+	Restore(ctx context.Context, id int) error
 }
@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+// CostLayer is a batch of units received at the same unit cost. Layers are
+// consumed oldest first as stock leaves the warehouse.
+type CostLayer struct {
+	ID           int
+	TenantID     string
+	ProductID    int
+	MovementID   int
+	Quantity     int
+	RemainingQty int
+	UnitCost     float64
+	ReceivedAt   time.Time
+}
+
+// ConsumeLayers removes qty units from the open layers in FIFO order and
+// returns the layers whose remaining quantity changed. The input layers must
+// be sorted by ReceivedAt ascending.
+func ConsumeLayers(layers []*CostLayer, qty int) ([]*CostLayer, error) {
+	available := 0
+	for _, layer := range layers {
+		available += layer.RemainingQty
+	}
+	if available < qty {
+		return nil, ErrInsufficientStock
+	}
+
+	var touched []*CostLayer
+	for _, layer := range layers {
+		if qty == 0 {
+			break
+		}
+		if layer.RemainingQty == 0 {
+			continue
+		}
+		taken := min(layer.RemainingQty, qty)
+		layer.RemainingQty -= taken
+		qty -= taken
+		touched = append(touched, layer)
+	}
+	return touched, nil
+}
+
+// AverageLayerCost returns the weighted average unit cost of the units still
+// held in the given layers, or zero when they are empty.
+func AverageLayerCost(layers []*CostLayer) float64 {
+	var qty int
+	var value float64
+	for _, layer := range layers {
+		qty += layer.RemainingQty
+		value += float64(layer.RemainingQty) * layer.UnitCost
+	}
+	if qty == 0 {
+		return 0
+	}
+	return value / float64(qty)
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+type CostLayerRepository interface {
+	Create(ctx context.Context, layer *CostLayer) error
+	// ListOpenByProductID returns the layers of a product with stock left,
+	// oldest first, and locks them until the transaction the context runs
+	// in ends.
+	ListOpenByProductID(ctx context.Context, productID int) ([]*CostLayer, error)
+	// ListOpening returns the layers no movement received: the stock carried
+	// over from before cost tracking, by product and age.
+	ListOpening(ctx context.Context) ([]*CostLayer, error)
+	UpdateRemainingQty(ctx context.Context, id int, remainingQty int) error
+}
@@ -3,6 +3,49 @@ package domain
 import "errors"
 
 var (
-	ErrInvalidCategoryName = errors.New("category name cannot be empty")
-	ErrCategoryNotFound    = errors.New("category not found")
+	ErrInvalidPageLimit  = errors.New("limit must be between 1 and 100")
+	ErrInvalidPageOffset = errors.New("offset cannot be negative")
+	ErrInvalidSortField  = errors.New("invalid sort field")
+	ErrInvalidSortOrder  = errors.New("order must be asc or desc")
+	ErrInvalidCursor     = errors.New("invalid cursor")
+
+	ErrCategoryNotFound       = errors.New("category not found")
+	ErrParentCategoryNotFound = errors.New("parent category not found")
+	ErrParentCategoryDeleted  = errors.New("parent category is deleted")
+	ErrCategoryCycle          = errors.New("category cannot be moved under itself or one of its subcategories")
+	ErrCategoryInUse          = errors.New("category is still referenced")
+	ErrInvalidDeletePolicy    = errors.New("invalid delete policy")
+	ErrCategoryNotDeleted     = errors.New("category is not deleted")
+	ErrCategoryNameTaken      = errors.New("an active category already has this name")
+	ErrReassignTargetRequired = errors.New("reassign policy requires a target category")
+	ErrInvalidReassignTarget  = errors.New("reassign target must be an existing category outside the deleted subtree")
+
+	ErrProductNotFound   = errors.New("product not found")
+	ErrProductNotDeleted = errors.New("product is not deleted")
+	ErrProductSKUTaken   = errors.New("an active product already has this SKU")
+	ErrEmptySearchQuery  = errors.New("search text cannot be empty")
+	ErrInvalidPriceRange = errors.New("minPrice cannot be greater than maxPrice")
+
+	ErrStockLevelNotFound     = errors.New("stock level not found")
+	ErrInsufficientStock      = errors.New("insufficient stock")
+	ErrInvalidMovementType    = errors.New("invalid movement type")
+	ErrInvalidMovementQty     = errors.New("invalid movement quantity")
+	ErrMissingUnitCost        = errors.New("receipt movements require a non-negative unit cost")
+	ErrInvalidValuationMethod = errors.New("invalid valuation method")
+
+	ErrReservationNotFound  = errors.New("reservation not found")
+	ErrReservationNotActive = errors.New("reservation is not active")
+
+	ErrImportJobNotFound       = errors.New("import job not found")
+	ErrUnsupportedImportFormat = errors.New("unsupported import format, send text/csv or application/x-ndjson")
+	ErrEmptyImport             = errors.New("import file is empty")
+
+	ErrInvalidExportRange = errors.New("from cannot be after to")
+
+	ErrUserNotFound = errors.New("user not found")
+
+	ErrAPIKeyNotFound     = errors.New("api key not found")
+	ErrAPIKeyRevoked      = errors.New("api key is revoked")
+	ErrInvalidAPIKeyName  = errors.New("api key name cannot be empty")
+	ErrInvalidAPIKeyScope = errors.New("invalid api key scope")
 )
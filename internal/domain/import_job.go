@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+)
+
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusSucceeded = "succeeded"
+	ImportStatusFailed    = "failed"
+)
+
+// MaxReportedImportErrors caps the row errors kept on a job; ErrorCount
+// still counts them all.
+const MaxReportedImportErrors = 100
+
+// ImportJob creates products, their categories and their opening stock from
+// an uploaded CSV or NDJSON file, in the background. A dry run only
+// validates the file.
+type ImportJob struct {
+	ID       string
+	TenantID string
+	Format   string
+	DryRun   bool
+	Status   string
+	// Payload is the uploaded file. It is dropped once the job finishes.
+	Payload           []byte
+	TotalRows         int
+	ProcessedRows     int
+	CreatedProducts   int
+	CreatedCategories int
+	Errors            []ImportRowError
+	ErrorCount        int
+	// Failure explains a failure that is not due to invalid rows.
+	Failure string
+	// UserID is the user who uploaded the file.
+	UserID     string
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+}
+
+// AddError records a row error, keeping the first MaxReportedImportErrors.
+func (j *ImportJob) AddError(err ImportRowError) {
+	j.ErrorCount++
+	if len(j.Errors) < MaxReportedImportErrors {
+		j.Errors = append(j.Errors, err)
+	}
+}
+
+// ImportRow is one product of an import file.
+type ImportRow struct {
+	// Line is the line of the row in the file, starting at 1.
+	Line            int
+	Name            string
+	SKU             string
+	CategoryName    string
+	Price           float64
+	OpeningQuantity int
+	UnitCost        *float64
+}
+
+// ImportRowError explains why a row of an import file is invalid. Field is
+// empty when the whole row is.
+type ImportRowError struct {
+	Line    int
+	Field   string
+	Message string
+}
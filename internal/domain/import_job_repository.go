@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *ImportJob) error
+	// GetByID returns the job without its payload.
+	GetByID(ctx context.Context, id string) (*ImportJob, error)
+	// ClaimNext marks the oldest pending job of any tenant running and
+	// returns it with its payload, or nil when no job is pending. Concurrent
+	// callers never claim the same job.
+	ClaimNext(ctx context.Context) (*ImportJob, error)
+	// FailStale fails the jobs of every tenant that have been running since
+	// before startedBefore and returns how many there were.
+	FailStale(ctx context.Context, startedBefore time.Time, failure string) (int, error)
+	UpdateProgress(ctx context.Context, id string, processedRows int) error
+	// Finish saves the outcome of the job and drops its payload.
+	Finish(ctx context.Context, job *ImportJob) error
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// ListQuery describes how a list endpoint pages, sorts and filters its
+// results. Search matches anywhere in the name, Prefix only at its start.
+// When Cursor is set it takes precedence over Offset.
+type ListQuery struct {
+	Limit          int
+	Offset         int
+	Cursor         string
+	SortBy         string
+	Order          SortOrder
+	Search         string
+	Prefix         string
+	IncludeDeleted bool
+}
+
+// Normalize applies defaults and checks the query against the sort fields
+// supported by a resource. The first allowed field is the default sort.
+func (q ListQuery) Normalize(sortFields ...string) (ListQuery, error) {
+	if q.Limit == 0 {
+		q.Limit = DefaultPageLimit
+	}
+	if q.Limit < 0 || q.Limit > MaxPageLimit {
+		return q, ErrInvalidPageLimit
+	}
+	if q.Offset < 0 {
+		return q, ErrInvalidPageOffset
+	}
+
+	if q.SortBy == "" {
+		q.SortBy = sortFields[0]
+	}
+	if !slices.Contains(sortFields, q.SortBy) {
+		return q, ErrInvalidSortField
+	}
+
+	switch q.Order {
+	case "":
+		q.Order = SortAsc
+	case SortAsc, SortDesc:
+	default:
+		return q, ErrInvalidSortOrder
+	}
+
+	if q.Cursor != "" {
+		if _, err := DecodeCursor(q.Cursor); err != nil {
+			return q, err
+		}
+		q.Offset = 0
+	}
+
+	return q, nil
+}
+
+// Page is one slice of a listed collection. NextCursor is empty on the last
+// page.
+type Page[T any] struct {
+	Items      []T
+	Total      int64
+	Limit      int
+	Offset     int
+	NextCursor string
+}
+
+// Cursor points just past the last item of a page, identified by the value
+// of its sort field and its ID as a tie-breaker.
+type Cursor struct {
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+func EncodeCursor(c Cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(s string) (Cursor, error) {
+	var c Cursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID <= 0 {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
@@ -1,13 +1,33 @@
 package domain
 
-import "time"
+import (
+	"regexp"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// skuPattern accepts upper-case alphanumeric groups separated by single
+// dashes, e.g. "CHAIR-OAK-01".
+var skuPattern = regexp.MustCompile(`^[A-Z0-9]+(-[A-Z0-9]+)*$`)
+
+// ValidSKU tells whether sku is 3 to 64 upper-case letters or digits in
+// dash separated groups.
+func ValidSKU(sku string) bool {
+	return len(sku) >= 3 && len(sku) <= 64 && skuPattern.MatchString(sku)
+}
 
 type Product struct {
-	ID          int
-	Name        string
+	ID       int
+	TenantID string
+	Name     string
+	// SKU identifies the product within its tenant. Products created before
+	// SKUs were introduced have none.
+	SKU         string
 	Description string
 	Price       float64
 	CategoryID  int
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	DeletedAt   gorm.DeletedAt
 }
@@ -1,8 +1,24 @@
 package domain
 
+import "context"
+
 type ProductRepository interface {
-	Create(product *Product) error
-	GetByID(id int) (*Product, error)
-	Update(product *Product) error
-	Delete(id int) error
+	// Create and Update fail with ErrProductSKUTaken when another active
+	// product has the SKU.
+	Create(ctx context.Context, product *Product) error
+	GetByID(ctx context.Context, id int) (*Product, error)
+	GetDeletedByID(ctx context.Context, id int) (*Product, error)
+	ListAll(ctx context.Context, includeDeleted bool) ([]*Product, error)
+	ListByCategoryIDs(ctx context.Context, categoryIDs []int, includeDeleted bool) ([]*Product, error)
+	List(ctx context.Context, categoryIDs []int, query ListQuery) (*Page[*Product], error)
+	Search(ctx context.Context, query ProductSearchQuery) (*ProductSearchResult, error)
+	// Stream calls fn with every product by ID, reading them from the
+	// database as fn consumes them. It stops at the first error of fn.
+	Stream(ctx context.Context, includeDeleted bool, fn func(*Product) error) error
+	Update(ctx context.Context, product *Product) error
+	ReassignCategory(ctx context.Context, fromCategoryID, toCategoryID int) error
+	Delete(ctx context.Context, id int) error
+	// Restore fails with ErrProductSKUTaken when an active product took the
+	// SKU in the meantime.
+	Restore(ctx context.Context, id int) error
 }
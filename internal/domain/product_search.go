@@ -0,0 +1,86 @@
+package domain
+
+import "strings"
+
+// PriceFacetBounds splits prices into the ranges reported by product search:
+// below 10, 10 to 50, 50 to 100, 100 to 500 and 500 or more.
+var PriceFacetBounds = []float64{10, 50, 100, 500}
+
+// ProductSearchQuery is a full-text search over product names and
+// descriptions. Every word of Text must match, the last letters of each word
+// being optional so that partial words match as prefixes.
+type ProductSearchQuery struct {
+	Text        string
+	CategoryIDs []int
+	MinPrice    *float64
+	MaxPrice    *float64
+	InStockOnly bool
+	Limit       int
+	Offset      int
+}
+
+type ProductSearchHit struct {
+	Product *Product
+	Rank    float64
+}
+
+type CategoryFacet struct {
+	CategoryID   int
+	CategoryName string
+	Count        int64
+}
+
+// PriceRangeFacet counts the matches whose price is in [Min, Max). A nil
+// bound means the range is open on that side.
+type PriceRangeFacet struct {
+	Min   *float64
+	Max   *float64
+	Count int64
+}
+
+// ProductSearchResult holds one page of hits ordered by relevance together
+// with facet counts over all matches. Each facet ignores its own filter so
+// that clients can show how many results the other choices would give.
+type ProductSearchResult struct {
+	Hits           []ProductSearchHit
+	Total          int64
+	Limit          int
+	Offset         int
+	CategoryFacets []CategoryFacet
+	PriceFacets    []PriceRangeFacet
+}
+
+// PriceRangeFacets returns an empty facet for each range in PriceFacetBounds.
+func PriceRangeFacets() []PriceRangeFacet {
+	facets := make([]PriceRangeFacet, len(PriceFacetBounds)+1)
+	for i := range facets {
+		if i > 0 {
+			facets[i].Min = &PriceFacetBounds[i-1]
+		}
+		if i < len(PriceFacetBounds) {
+			facets[i].Max = &PriceFacetBounds[i]
+		}
+	}
+	return facets
+}
+
+// Normalize applies the default page size and checks the query.
+func (q ProductSearchQuery) Normalize() (ProductSearchQuery, error) {
+	q.Text = strings.TrimSpace(q.Text)
+	if q.Text == "" {
+		return q, ErrEmptySearchQuery
+	}
+	if q.Limit == 0 {
+		q.Limit = DefaultPageLimit
+	}
+	if q.Limit < 0 || q.Limit > MaxPageLimit {
+		return q, ErrInvalidPageLimit
+	}
+	if q.Offset < 0 {
+		return q, ErrInvalidPageOffset
+	}
+	if q.MinPrice != nil && q.MaxPrice != nil && *q.MinPrice > *q.MaxPrice {
+		return q, ErrInvalidPriceRange
+	}
+	return q, nil
+}
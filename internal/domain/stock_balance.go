@@ -0,0 +1,15 @@
+package domain
+
+// StockBalance is the stock of a product: what is on hand and how much of it
+// active reservations hold.
+type StockBalance struct {
+	ProductID   int
+	ProductName string
+	OnHand      int
+	Reserved    int
+}
+
+// Available returns the quantity that can still be reserved or issued.
+func (b StockBalance) Available() int {
+	return b.OnHand - b.Reserved
+}
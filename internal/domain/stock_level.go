@@ -3,6 +3,7 @@ package domain
 import "time"
 
 type StockLevel struct {
+	TenantID  string
 	ProductID int
 	Quantity  int
 	UpdatedAt time.Time
@@ -1,7 +1,20 @@
 package domain
 
+import "context"
+
 type StockLevelRepository interface {
-	Create(stockLevel *StockLevel) error
-	GetByProductID(productID int) (*StockLevel, error)
-	UpdateQuantity(stockLevel *StockLevel) error
+	Create(ctx context.Context, stockLevel *StockLevel) error
+	GetByProductID(ctx context.Context, productID int) (*StockLevel, error)
+	// LockByProductID returns the stock level of a product, creating it with
+	// a quantity of 0 when missing, and locks it until the transaction the
+	// context runs in ends.
+	LockByProductID(ctx context.Context, productID int) (*StockLevel, error)
+	// ListAll returns the stock level of every product that has one, by
+	// product ID.
+	ListAll(ctx context.Context) ([]*StockLevel, error)
+	// Stream calls fn with the stock level of every product by product ID,
+	// reading them from the database as fn consumes them. It stops at the
+	// first error of fn.
+	Stream(ctx context.Context, fn func(*StockLevel) error) error
+	UpdateQuantity(ctx context.Context, stockLevel *StockLevel) error
 }
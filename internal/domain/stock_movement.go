@@ -2,12 +2,29 @@ package domain
 
 import "time"
 
+const (
+	MovementTypeReceipt    = "receipt"
+	MovementTypeIssue      = "issue"
+	MovementTypeAdjustment = "adjustment"
+)
+
 type StockMovement struct {
 	ID           int
+	TenantID     string
 	ProductID    int
 	MovementType string
 	Quantity     int
+	UnitCost     *float64
 	Reason       string
 	UserID       string
 	CreatedAt    time.Time
 }
+
+// SignedQuantity returns the quantity as a stock delta: receipts add units,
+// issues remove them and adjustments carry their own sign.
+func (m *StockMovement) SignedQuantity() int {
+	if m.MovementType == MovementTypeIssue {
+		return -m.Quantity
+	}
+	return m.Quantity
+}
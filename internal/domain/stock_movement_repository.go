@@ -1,6 +1,17 @@
 package domain
 
+import (
+	"context"
+	"time"
+)
+
 type StockMovementRepository interface {
-	Create(movement *StockMovement) error
-	ListByProductID(productID int) ([]*StockMovement, error)
+	Create(ctx context.Context, movement *StockMovement) error
+	ListByProductID(ctx context.Context, productID int, query ListQuery) (*Page[*StockMovement], error)
+	ListUntil(ctx context.Context, asOf time.Time) ([]*StockMovement, error)
+	// StreamBetween calls fn with the movements recorded from from to to,
+	// both included, oldest first. A productID of 0 means every product.
+	// Movements are read from the database as fn consumes them, and the
+	// first error of fn stops the stream.
+	StreamBetween(ctx context.Context, from, to time.Time, productID int, fn func(*StockMovement) error) error
 }
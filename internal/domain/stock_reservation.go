@@ -2,8 +2,15 @@ package domain
 
 import "time"
 
+const (
+	ReservationStatusActive   = "active"
+	ReservationStatusReleased = "released"
+	ReservationStatusExpired  = "expired"
+)
+
 type StockReservation struct {
 	ID          int
+	TenantID    string
 	ProductID   int
 	ReservedQty int
 	ReferenceID string
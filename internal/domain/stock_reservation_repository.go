@@ -1,8 +1,22 @@
 package domain
 
+import (
+	"context"
+	"time"
+)
+
 type StockReservationRepository interface {
-	Create(reservation *StockReservation) error
-	GetByID(id int) (*StockReservation, error)
-	ListActiveByProduct(productID int) ([]*StockReservation, error)
-	UpdateStatus(id int, status string) error
+	Create(ctx context.Context, reservation *StockReservation) error
+	GetByID(ctx context.Context, id int) (*StockReservation, error)
+	ListActiveByProduct(ctx context.Context, productID int) ([]*StockReservation, error)
+	// ListActive returns the active reservations of every product, oldest
+	// first.
+	ListActive(ctx context.Context) ([]*StockReservation, error)
+	// Release marks an active reservation as released and returns it. It
+	// fails with ErrReservationNotActive when the reservation was already
+	// released or expired, even by a concurrent call.
+	Release(ctx context.Context, id int) (*StockReservation, error)
+	// ExpireDue marks the active reservations of every tenant whose expiry
+	// is not after now as expired, and returns them.
+	ExpireDue(ctx context.Context, now time.Time) ([]*StockReservation, error)
 }
@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+// Transactor runs a unit of work atomically. Repositories called with the
+// context handed to fn take part in the unit of work, which is committed
+// when fn returns nil and rolled back otherwise. Nested calls join the
+// outer unit of work.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -4,7 +4,10 @@ package domain
 import "time"
 
 type User struct {
-	ID        string
+	ID string
+	// Subject is the identity provider's ID for the user, the sub claim of
+	// their tokens.
+	Subject   string
 	UserName  string
 	FullName  string
 	Email     string
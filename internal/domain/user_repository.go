@@ -1,6 +1,12 @@
 // Package domain contains domain entities and repository contracts for the inventory microservice.
 package domain
 
+import "context"
+
 type UserRepository interface {
-	GetByID(id string) (*User, error)
+	GetByID(ctx context.Context, id string) (*User, error)
+	GetBySubject(ctx context.Context, subject string) (*User, error)
+	// CreateIfMissing inserts user unless a user with the same subject
+	// exists, and returns the stored user either way.
+	CreateIfMissing(ctx context.Context, user *User) (*User, error)
 }
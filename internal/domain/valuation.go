@@ -0,0 +1,150 @@
+package domain
+
+import "time"
+
+type ValuationMethod string
+
+const (
+	ValuationMethodFIFO            ValuationMethod = "fifo"
+	ValuationMethodWeightedAverage ValuationMethod = "weighted_average"
+)
+
+// ParseValuationMethod validates a method name, defaulting to FIFO when empty.
+func ParseValuationMethod(s string) (ValuationMethod, error) {
+	switch ValuationMethod(s) {
+	case "", ValuationMethodFIFO:
+		return ValuationMethodFIFO, nil
+	case ValuationMethodWeightedAverage:
+		return ValuationMethodWeightedAverage, nil
+	default:
+		return "", ErrInvalidValuationMethod
+	}
+}
+
+// ProductValuation is the quantity and value on hand of one product.
+type ProductValuation struct {
+	ProductID    int
+	ProductName  string
+	CategoryID   int
+	CategoryName string
+	Quantity     int
+	TotalValue   float64
+}
+
+// UnitCost returns the average cost of the units on hand.
+func (v ProductValuation) UnitCost() float64 {
+	if v.Quantity <= 0 {
+		return 0
+	}
+	return v.TotalValue / float64(v.Quantity)
+}
+
+// CategoryValuation aggregates the valuation of every product in a category.
+type CategoryValuation struct {
+	CategoryID   int
+	CategoryName string
+	Quantity     int
+	TotalValue   float64
+	Products     []ProductValuation
+}
+
+type ValuationReport struct {
+	Method     ValuationMethod
+	AsOf       time.Time
+	TotalValue float64
+	Categories []CategoryValuation
+}
+
+// ValueMovements replays the chronologically ordered movements of a single
+// product and returns the quantity and value on hand after the last one.
+// Inbound movements without a unit cost are valued at zero.
+func ValueMovements(method ValuationMethod, movements []*StockMovement) (int, float64) {
+	if method == ValuationMethodWeightedAverage {
+		return valueWeightedAverage(movements)
+	}
+	return valueFIFO(movements)
+}
+
+// OpeningMovement returns a movement for the stock of an opening layer, one
+// carried over from before cost tracking, that the movements recorded until
+// the layer was created do not account for, or nil when they account for
+// all of it. The movements must be those of the layer's product in
+// chronological order; the opening movement predates them.
+func OpeningMovement(layer *CostLayer, movements []*StockMovement) *StockMovement {
+	recorded := 0
+	for _, m := range movements {
+		if m.CreatedAt.After(layer.ReceivedAt) {
+			break
+		}
+		recorded += m.SignedQuantity()
+	}
+	if layer.Quantity <= recorded {
+		return nil
+	}
+
+	createdAt := layer.ReceivedAt
+	if len(movements) > 0 && movements[0].CreatedAt.Before(createdAt) {
+		createdAt = movements[0].CreatedAt
+	}
+	unitCost := layer.UnitCost
+	return &StockMovement{
+		ProductID:    layer.ProductID,
+		MovementType: MovementTypeAdjustment,
+		Quantity:     layer.Quantity - recorded,
+		UnitCost:     &unitCost,
+		Reason:       "opening stock",
+		CreatedAt:    createdAt,
+	}
+}
+
+func valueFIFO(movements []*StockMovement) (int, float64) {
+	var layers []*CostLayer
+	for _, m := range movements {
+		delta := m.SignedQuantity()
+		switch {
+		case delta > 0:
+			layers = append(layers, &CostLayer{Quantity: delta, RemainingQty: delta, UnitCost: unitCostOf(m)})
+		case delta < 0:
+			remaining := -delta
+			for len(layers) > 0 && remaining > 0 {
+				taken := min(layers[0].RemainingQty, remaining)
+				layers[0].RemainingQty -= taken
+				remaining -= taken
+				if layers[0].RemainingQty == 0 {
+					layers = layers[1:]
+				}
+			}
+		}
+	}
+
+	var qty int
+	var value float64
+	for _, layer := range layers {
+		qty += layer.RemainingQty
+		value += float64(layer.RemainingQty) * layer.UnitCost
+	}
+	return qty, value
+}
+
+func valueWeightedAverage(movements []*StockMovement) (int, float64) {
+	var qty int
+	var avg float64
+	for _, m := range movements {
+		delta := m.SignedQuantity()
+		switch {
+		case delta > 0:
+			avg = (float64(qty)*avg + float64(delta)*unitCostOf(m)) / float64(qty+delta)
+			qty += delta
+		case delta < 0:
+			qty = max(qty+delta, 0)
+		}
+	}
+	return qty, float64(qty) * avg
+}
+
+func unitCostOf(m *StockMovement) float64 {
+	if m.UnitCost == nil {
+		return 0
+	}
+	return *m.UnitCost
+}
@@ -0,0 +1,155 @@
+// Package health probes the dependencies the service needs to serve
+// traffic.
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Check probes one dependency. A failing required check makes the service
+// unready; optional checks are only reported.
+type Check struct {
+	Name     string
+	Required bool
+	Probe    func(ctx context.Context) error
+}
+
+type Result struct {
+	Name     string
+	Required bool
+	Healthy  bool
+	Latency  time.Duration
+	Err      error
+}
+
+type Report struct {
+	Ready   bool
+	Results []Result
+}
+
+// Checker runs all checks concurrently, each bounded by timeout.
+type Checker struct {
+	checks  []Check
+	timeout time.Duration
+}
+
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{checks: checks, timeout: timeout}
+}
+
+// Run probes every dependency. Results keep the order the checks were
+// registered in.
+func (c *Checker) Run(ctx context.Context) Report {
+	results := make([]Result, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check.Probe(checkCtx)
+			results[i] = Result{
+				Name:     check.Name,
+				Required: check.Required,
+				Healthy:  err == nil,
+				Latency:  time.Since(start),
+				Err:      err,
+			}
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Ready: true, Results: results}
+	for _, r := range results {
+		if r.Required && !r.Healthy {
+			report.Ready = false
+		}
+	}
+	return report
+}
+
+// Postgres pings the database pool.
+func Postgres(db *sql.DB) Check {
+	return Check{
+		Name:     "postgres",
+		Required: true,
+		Probe:    db.PingContext,
+	}
+}
+
+// TCP succeeds when at least one of addrs accepts a connection. It suits
+// dependencies such as Kafka brokers that are reached through a list of
+// seed addresses.
+func TCP(name string, required bool, addrs []string) Check {
+	return Check{
+		Name:     name,
+		Required: required,
+		Probe: func(ctx context.Context) error {
+			if len(addrs) == 0 {
+				return errors.New("no address configured")
+			}
+			var dialer net.Dialer
+			var errs []error
+			for _, addr := range addrs {
+				conn, err := dialer.DialContext(ctx, "tcp", addr)
+				if err == nil {
+					return conn.Close()
+				}
+				errs = append(errs, err)
+			}
+			return errors.Join(errs...)
+		},
+	}
+}
+
+// MongoAddrs extracts the host:port pairs of a mongodb:// connection string.
+// mongodb+srv:// strings are resolved through their SRV record. net/url
+// cannot be used since the host part may list several hosts.
+func MongoAddrs(uri string) ([]string, error) {
+	scheme, rest, found := strings.Cut(uri, "://")
+	if !found {
+		return nil, errors.New("mongo uri has no scheme")
+	}
+	hosts, _, _ := strings.Cut(rest, "/")
+	hosts, _, _ = strings.Cut(hosts, "?")
+	if at := strings.LastIndex(hosts, "@"); at >= 0 {
+		hosts = hosts[at+1:]
+	}
+	if hosts == "" {
+		return nil, errors.New("mongo uri has no host")
+	}
+
+	switch scheme {
+	case "mongodb":
+		var addrs []string
+		for _, host := range strings.Split(hosts, ",") {
+			if _, _, err := net.SplitHostPort(host); err != nil {
+				host = net.JoinHostPort(host, "27017")
+			}
+			addrs = append(addrs, host)
+		}
+		return addrs, nil
+	case "mongodb+srv":
+		_, records, err := net.LookupSRV("mongodb", "tcp", hosts)
+		if err != nil {
+			return nil, fmt.Errorf("resolving mongo srv record: %w", err)
+		}
+		addrs := make([]string, 0, len(records))
+		for _, r := range records {
+			addrs = append(addrs, net.JoinHostPort(strings.TrimSuffix(r.Target, "."), fmt.Sprint(r.Port)))
+		}
+		return addrs, nil
+	default:
+		return nil, fmt.Errorf("unsupported mongo uri scheme %q", scheme)
+	}
+}
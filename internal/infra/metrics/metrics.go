@@ -0,0 +1,121 @@
+// Package metrics defines the Prometheus metrics of the service and the
+// GORM plugin, repository decorator and gauge that record them.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "inventory"
+
+// Metrics holds every collector of the service in its own registry, so tests
+// can build independent instances.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	HTTPRequests        *prometheus.CounterVec
+	HTTPRequestDuration *prometheus.HistogramVec
+	DBQueryDuration     *prometheus.HistogramVec
+
+	ReservationsCreated       prometheus.Counter
+	ReservationsReleased      prometheus.Counter
+	ReservationsExpired       prometheus.Counter
+	InsufficientStockRejected prometheus.Counter
+	StockLevel                *prometheus.GaugeVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		HTTPRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		HTTPRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route template and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		DBQueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "db_query_duration_seconds",
+			Help:      "Duration of GORM operations by operation and table.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table"}),
+		ReservationsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reservations_created_total",
+			Help:      "Stock reservations created.",
+		}),
+		ReservationsReleased: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reservations_released_total",
+			Help:      "Stock reservations released before expiring.",
+		}),
+		ReservationsExpired: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reservations_expired_total",
+			Help:      "Stock reservations that expired.",
+		}),
+		InsufficientStockRejected: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "insufficient_stock_rejections_total",
+			Help:      "Requests rejected because stock on hand was insufficient.",
+		}),
+		StockLevel: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "stock_level",
+			Help:      "Units on hand of the watched products.",
+		}, []string{"product_id"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.HTTPRequests,
+		m.HTTPRequestDuration,
+		m.DBQueryDuration,
+		m.ReservationsCreated,
+		m.ReservationsReleased,
+		m.ReservationsExpired,
+		m.InsufficientStockRejected,
+		m.StockLevel,
+	)
+	return m
+}
+
+// RegisterDBStats exports the connection pool statistics of db.
+func (m *Metrics) RegisterDBStats(db *sql.DB, name string) {
+	m.registry.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// CountReservations adds n to the counter of reservations entering status:
+// active for created reservations, released or expired afterwards.
+func (m *Metrics) CountReservations(status string, n int) {
+	switch status {
+	case domain.ReservationStatusActive:
+		m.ReservationsCreated.Add(float64(n))
+	case domain.ReservationStatusReleased:
+		m.ReservationsReleased.Add(float64(n))
+	case domain.ReservationStatusExpired:
+		m.ReservationsExpired.Add(float64(n))
+	}
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Registry exposes the registry, mainly for tests.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+// StockLevelGauge sets the stock level gauge of the watched products.
+type StockLevelGauge struct {
+	metrics *Metrics
+	watched map[int]struct{}
+}
+
+func NewStockLevelGauge(m *Metrics, watchedProducts []int) *StockLevelGauge {
+	watched := make(map[int]struct{}, len(watchedProducts))
+	for _, id := range watchedProducts {
+		watched[id] = struct{}{}
+	}
+	return &StockLevelGauge{metrics: m, watched: watched}
+}
+
+// ObserveStockLevel sets the gauge of the product of stockLevel when it is
+// watched. It must only be given committed levels.
+func (g *StockLevelGauge) ObserveStockLevel(stockLevel *domain.StockLevel) {
+	if _, ok := g.watched[stockLevel.ProductID]; !ok {
+		return
+	}
+	g.metrics.StockLevel.WithLabelValues(strconv.Itoa(stockLevel.ProductID)).Set(float64(stockLevel.Quantity))
+}
+
+// StockLevelRepository sets the gauge from the stock levels read outside of
+// movements, so that it is known before the first movement of a watched
+// product. Levels written by movements are observed by the use case once
+// their transaction commits.
+type StockLevelRepository struct {
+	domain.StockLevelRepository
+	gauge *StockLevelGauge
+}
+
+func NewStockLevelRepository(next domain.StockLevelRepository, gauge *StockLevelGauge) *StockLevelRepository {
+	return &StockLevelRepository{StockLevelRepository: next, gauge: gauge}
+}
+
+func (r *StockLevelRepository) GetByProductID(ctx context.Context, productID int) (*domain.StockLevel, error) {
+	stockLevel, err := r.StockLevelRepository.GetByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	r.gauge.ObserveStockLevel(stockLevel)
+	return stockLevel, nil
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	stockLevelRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockLevelRepository"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockLevelRepositoryTracksWatchedProducts(t *testing.T) {
+	ctx := context.Background()
+	m := New()
+	gauge := NewStockLevelGauge(m, []int{7})
+	next := stockLevelRepositoryMock.NewMockStockLevelRepository(t)
+	repo := NewStockLevelRepository(next, gauge)
+
+	next.EXPECT().GetByProductID(mock.Anything, 7).Return(&domain.StockLevel{ProductID: 7, Quantity: 12}, nil)
+	next.EXPECT().UpdateQuantity(mock.Anything, mock.Anything).Return(nil)
+
+	_, err := repo.GetByProductID(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 12.0, testutil.ToFloat64(m.StockLevel.WithLabelValues("7")))
+
+	require.NoError(t, repo.UpdateQuantity(ctx, &domain.StockLevel{ProductID: 7, Quantity: 5}))
+	assert.Equal(t, 12.0, testutil.ToFloat64(m.StockLevel.WithLabelValues("7")), "writes may still be rolled back")
+
+	gauge.ObserveStockLevel(&domain.StockLevel{ProductID: 7, Quantity: 5})
+	gauge.ObserveStockLevel(&domain.StockLevel{ProductID: 8, Quantity: 3})
+	assert.Equal(t, 5.0, testutil.ToFloat64(m.StockLevel.WithLabelValues("7")))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.StockLevel), "unwatched products are not exported")
+}
+
+func TestCountReservations(t *testing.T) {
+	m := New()
+
+	m.CountReservations(domain.ReservationStatusActive, 2)
+	m.CountReservations(domain.ReservationStatusReleased, 1)
+	m.CountReservations(domain.ReservationStatusExpired, 3)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.ReservationsCreated))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.ReservationsReleased))
+	assert.Equal(t, 3.0, testutil.ToFloat64(m.ReservationsExpired))
+}
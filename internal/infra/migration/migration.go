@@ -0,0 +1,130 @@
+// Package migration applies the SQL migrations in scripts/migrations to
+// Postgres. Its state lives in the schema_migrations table of the
+// migrate/migrate tool, so the tool and the service can migrate the same
+// database.
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrDirty          = errors.New("database is dirty")
+	ErrUnknownVersion = errors.New("unknown migration version")
+)
+
+// Migration is one schema change and the SQL undoing it.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Load reads the migrations of fsys, sorted by version. Every migration
+// needs an up and a down file.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration %s: file name must look like 0001_name.up.sql", entry.Name())
+		}
+		version, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("migration %s: version must be a positive number", entry.Name())
+		}
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[uint(version)]
+		if !ok {
+			m = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[m.Version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %s: version %d is already used by %s", entry.Name(), version, m.Name)
+		}
+		if match[3] == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s: both an up and a down file are required", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// step runs one migration in one direction, leaving the database at
+// version.
+type step struct {
+	migration Migration
+	down      bool
+	version   uint
+}
+
+// plan returns the steps moving the database from version current to
+// target; 0 stands for an empty schema.
+func plan(migrations []Migration, current, target uint) ([]step, error) {
+	// index maps a version to its position among migrations, 0 to -1.
+	index := func(version uint) (int, error) {
+		if version == 0 {
+			return -1, nil
+		}
+		for i, m := range migrations {
+			if m.Version == version {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("%w %d", ErrUnknownVersion, version)
+	}
+	from, err := index(current)
+	if err != nil {
+		return nil, err
+	}
+	to, err := index(target)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []step
+	for i := from + 1; i <= to; i++ {
+		steps = append(steps, step{migration: migrations[i], version: migrations[i].Version})
+	}
+	for i := from; i > to; i-- {
+		s := step{migration: migrations[i], down: true}
+		if i > 0 {
+			s.version = migrations[i-1].Version
+		}
+		steps = append(steps, s)
+	}
+	return steps, nil
+}
@@ -0,0 +1,117 @@
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/scripts/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func file(sql string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(sql)}
+}
+
+func TestLoad(t *testing.T) {
+	loaded, err := Load(fstest.MapFS{
+		"0002_products.down.sql":   file("DROP TABLE products;"),
+		"0002_products.up.sql":     file("CREATE TABLE products ();"),
+		"0001_categories.up.sql":   file("CREATE TABLE categories ();"),
+		"0001_categories.down.sql": file("DROP TABLE categories;"),
+		"migrations.go":            file("package migrations"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Migration{
+		{Version: 1, Name: "categories", Up: "CREATE TABLE categories ();", Down: "DROP TABLE categories;"},
+		{Version: 2, Name: "products", Up: "CREATE TABLE products ();", Down: "DROP TABLE products;"},
+	}, loaded)
+}
+
+func TestLoadRejectsInvalidSets(t *testing.T) {
+	tests := []struct {
+		name        string
+		fsys        fstest.MapFS
+		expectedErr string
+	}{
+		{
+			name:        "malformed name",
+			fsys:        fstest.MapFS{"init.sql": file("")},
+			expectedErr: "migration init.sql: file name must look like 0001_name.up.sql",
+		},
+		{
+			name:        "version zero",
+			fsys:        fstest.MapFS{"0000_init.up.sql": file("SELECT 1;")},
+			expectedErr: "migration 0000_init.up.sql: version must be a positive number",
+		},
+		{
+			name:        "missing down file",
+			fsys:        fstest.MapFS{"0001_init.up.sql": file("SELECT 1;")},
+			expectedErr: "migration 0001_init: both an up and a down file are required",
+		},
+		{
+			name: "version used twice",
+			fsys: fstest.MapFS{
+				"0001_init.up.sql":   file("SELECT 1;"),
+				"0001_seed.down.sql": file("SELECT 1;"),
+			},
+			expectedErr: "migration 0001_seed.down.sql: version 1 is already used by init",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Load(tc.fsys)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	loaded, err := Load(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded)
+	for i, m := range loaded {
+		assert.Equal(t, uint(i+1), m.Version, "migration versions must not leave gaps")
+	}
+}
+
+func TestPlan(t *testing.T) {
+	set := []Migration{{Version: 1, Name: "a"}, {Version: 2, Name: "b"}, {Version: 5, Name: "c"}}
+
+	type planned struct {
+		name    string
+		down    bool
+		version uint
+	}
+	tests := []struct {
+		name            string
+		current, target uint
+		expected        []planned
+		expectedErr     error
+	}{
+		{name: "up from empty", current: 0, target: 5, expected: []planned{{"a", false, 1}, {"b", false, 2}, {"c", false, 5}}},
+		{name: "up one", current: 2, target: 5, expected: []planned{{"c", false, 5}}},
+		{name: "down one", current: 5, target: 2, expected: []planned{{"c", true, 2}}},
+		{name: "down to empty", current: 2, target: 0, expected: []planned{{"b", true, 1}, {"a", true, 0}}},
+		{name: "nothing to do", current: 2, target: 2},
+		{name: "unknown target", current: 2, target: 3, expectedErr: ErrUnknownVersion},
+		{name: "unknown current", current: 7, target: 5, expectedErr: ErrUnknownVersion},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			steps, err := plan(set, tc.current, tc.target)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			var got []planned
+			for _, s := range steps {
+				got = append(got, planned{s.migration.Name, s.down, s.version})
+			}
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
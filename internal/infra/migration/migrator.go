@@ -0,0 +1,181 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// lockID is the Postgres advisory lock held while migrating, so that
+// replicas starting together apply each migration once.
+var lockID = int64(crc32.ChecksumIEEE([]byte("ms-nexusMarket-inventory:schema_migrations")))
+
+// Migrator moves a database between the versions of a set of migrations.
+// Each migration runs in a transaction together with the update of
+// schema_migrations, so a failed migration leaves no trace.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	logger     *zap.Logger
+}
+
+func New(db *sql.DB, migrations []Migration, logger *zap.Logger) *Migrator {
+	return &Migrator{db: db, migrations: migrations, logger: logger}
+}
+
+// Migrations returns the known migrations, sorted by version.
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Latest returns the version of the last known migration, or 0.
+func (m *Migrator) Latest() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the version of the database, 0 when no migration was
+// applied, and whether a migration failed halfway. The migrate/migrate tool
+// leaves the database dirty when that happens.
+func (m *Migrator) Version(ctx context.Context) (version uint, dirty bool, err error) {
+	var exists bool
+	if err := m.db.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists); err != nil {
+		return 0, false, err
+	}
+	if !exists {
+		return 0, false, nil
+	}
+	return readVersion(ctx, m.db)
+}
+
+// Up applies every pending migration. A database migrated by a newer
+// release is left alone, so that older replicas still start during a
+// rolling update.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.migrate(ctx, func(current uint) uint { return max(current, m.Latest()) })
+}
+
+// Down rolls back the last applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.migrate(ctx, func(current uint) uint {
+		target := uint(0)
+		for _, mig := range m.migrations {
+			if mig.Version < current {
+				target = mig.Version
+			}
+		}
+		return target
+	})
+}
+
+// To migrates up or down to version; 0 rolls back every migration.
+func (m *Migrator) To(ctx context.Context, version uint) error {
+	return m.migrate(ctx, func(uint) uint { return version })
+}
+
+// migrate moves the database to the version target returns for its current
+// version, holding the migration lock.
+func (m *Migrator) migrate(ctx context.Context, target func(current uint) uint) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	m.logger.Debug("Waiting for the migration lock")
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+		return fmt.Errorf("taking the migration lock: %w", err)
+	}
+	defer func() {
+		// Unlock even when ctx is done; the lock would otherwise live as long
+		// as the pooled connection.
+		_, unlockErr := conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", lockID)
+		err = errors.Join(err, unlockErr)
+	}()
+
+	if _, err := conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)"); err != nil {
+		return err
+	}
+	// Read the version under the lock: another replica may just have
+	// migrated.
+	current, dirty, err := readVersion(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("%w at version %d: repair the schema by hand, then set the version in schema_migrations and clear dirty", ErrDirty, current)
+	}
+
+	to := target(current)
+	if to == current {
+		m.logger.Info("Database schema is up to date", zap.Uint("version", current))
+		return nil
+	}
+	steps, err := plan(m.migrations, current, to)
+	if err != nil {
+		return err
+	}
+	for _, s := range steps {
+		if err := m.apply(ctx, conn, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, s step) error {
+	direction, query := "up", s.migration.Up
+	if s.down {
+		direction, query = "down", s.migration.Down
+	}
+	logger := m.logger.With(zap.Uint("version", s.migration.Version), zap.String("name", s.migration.Name), zap.String("direction", direction))
+	start := time.Now()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("migration %04d_%s %s: %w", s.migration.Version, s.migration.Name, direction, err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return err
+	}
+	if s.version > 0 {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)", s.version); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Info("Migration applied", zap.Duration("duration", time.Since(start)))
+	return nil
+}
+
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func readVersion(ctx context.Context, db queryRower) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(version), dirty, nil
+}
@@ -0,0 +1,91 @@
+package parquet
+
+import "encoding/binary"
+
+// Element types of the Thrift compact protocol, in which the page headers
+// and the footer are encoded.
+const (
+	compactI32    = 5
+	compactI64    = 6
+	compactBinary = 8
+	compactList   = 9
+	compactStruct = 12
+)
+
+// compactWriter encodes structs with the Thrift compact protocol. Only the
+// types used by the Parquet metadata are supported.
+type compactWriter struct {
+	buf []byte
+	// last is the ID of the previous field of the current struct, from which
+	// field headers are delta-encoded; lastStack keeps it for the enclosing
+	// structs.
+	last      int16
+	lastStack []int16
+}
+
+func (c *compactWriter) beginStruct() {
+	c.lastStack = append(c.lastStack, c.last)
+	c.last = 0
+}
+
+func (c *compactWriter) endStruct() {
+	c.buf = append(c.buf, 0)
+	c.last = c.lastStack[len(c.lastStack)-1]
+	c.lastStack = c.lastStack[:len(c.lastStack)-1]
+}
+
+func (c *compactWriter) field(id int16, typ byte) {
+	if delta := id - c.last; delta > 0 && delta <= 15 {
+		c.buf = append(c.buf, byte(delta)<<4|typ)
+	} else {
+		c.buf = append(c.buf, typ)
+		c.varint(int64(id))
+	}
+	c.last = id
+}
+
+// varint appends n zigzag encoded, as i16, i32 and i64 values are.
+func (c *compactWriter) varint(n int64) {
+	c.buf = binary.AppendUvarint(c.buf, uint64(n<<1^n>>63))
+}
+
+func (c *compactWriter) i32(n int32) {
+	c.varint(int64(n))
+}
+
+func (c *compactWriter) string(s string) {
+	c.buf = binary.AppendUvarint(c.buf, uint64(len(s)))
+	c.buf = append(c.buf, s...)
+}
+
+func (c *compactWriter) i32Field(id int16, n int32) {
+	c.field(id, compactI32)
+	c.i32(n)
+}
+
+func (c *compactWriter) i64Field(id int16, n int64) {
+	c.field(id, compactI64)
+	c.varint(n)
+}
+
+func (c *compactWriter) stringField(id int16, s string) {
+	c.field(id, compactBinary)
+	c.string(s)
+}
+
+// structField starts a struct field; its fields follow, then endStruct.
+func (c *compactWriter) structField(id int16) {
+	c.field(id, compactStruct)
+	c.beginStruct()
+}
+
+// listField starts a list field of size elements of type elem, which follow.
+func (c *compactWriter) listField(id int16, elem byte, size int) {
+	c.field(id, compactList)
+	if size < 15 {
+		c.buf = append(c.buf, byte(size)<<4|elem)
+		return
+	}
+	c.buf = append(c.buf, 0xf0|elem)
+	c.buf = binary.AppendUvarint(c.buf, uint64(size))
+}
@@ -0,0 +1,6 @@
+package parquet
+
+// SetRowGroupSize lets the tests outside the package write small row groups.
+func SetRowGroupSize(w *Writer, rows int) {
+	w.rowGroupSize = rows
+}
@@ -0,0 +1,114 @@
+package parquet_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/infra/parquet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The files in testdata are the expected output of the writer. Run the tests
+// with -update to rewrite them after a deliberate change of the output, and
+// open the rewritten files with another Parquet reader, such as
+// pyarrow.parquet.read_table, before committing them.
+var update = flag.Bool("update", false, "rewrite the files in testdata")
+
+var fixtureColumns = []parquet.Column{
+	{Name: "id", Type: parquet.Int64},
+	{Name: "name", Type: parquet.String},
+	{Name: "price", Type: parquet.Double},
+	{Name: "note", Type: parquet.String, Optional: true},
+	{Name: "createdAt", Type: parquet.Timestamp},
+	{Name: "deletedAt", Type: parquet.Timestamp, Optional: true},
+}
+
+var fixtureCreated = time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+
+// fixtureRows are the rows as readParquet returns them, with INT64 values as
+// int64; writerRow turns them into what the writer takes.
+var fixtureRows = [][]any{
+	{int64(1), "Boot", 59.9, nil, fixtureCreated, nil},
+	{int64(2), "Sandal", 19.5, "summer", fixtureCreated.Add(time.Hour), nil},
+	{int64(3), "Clog", 0.0, nil, fixtureCreated, fixtureCreated.Add(48 * time.Hour)},
+	{int64(4), "Café ☕", -1.25, "", fixtureCreated.Add(-time.Millisecond), nil},
+	{int64(5), "", 1e9, "last", time.UnixMilli(0).UTC(), nil},
+}
+
+func TestWriterMatchesFixtures(t *testing.T) {
+	tests := []struct {
+		file         string
+		codec        parquet.Codec
+		rowGroupSize int
+		rows         [][]any
+		rowGroups    int
+		// sameBytes is false for gzip, whose output may change between Go
+		// releases without the file changing meaning.
+		sameBytes bool
+	}{
+		{file: "products.parquet", codec: parquet.Uncompressed, rowGroupSize: 2, rows: fixtureRows, rowGroups: 3, sameBytes: true},
+		{file: "products_gzip.parquet", codec: parquet.Gzip, rowGroupSize: 2, rows: fixtureRows, rowGroups: 3},
+		{file: "empty.parquet", codec: parquet.Uncompressed, rowGroupSize: 2, rowGroups: 0, sameBytes: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := parquet.NewWriter(&buf, fixtureColumns, tt.codec)
+			parquet.SetRowGroupSize(w, tt.rowGroupSize)
+			for _, row := range tt.rows {
+				require.NoError(t, w.Write(writerRow(row)))
+			}
+			require.NoError(t, w.Close())
+
+			path := filepath.Join("testdata", tt.file)
+			if *update {
+				require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+			}
+			fixture, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			want := readParquet(t, fixture)
+			assert.Equal(t, []schemaColumn{
+				{Name: "id", Type: typeInt64, Repetition: repetitionRequired, Converted: -1},
+				{Name: "name", Type: typeByteArray, Repetition: repetitionRequired, Converted: convertedUTF8},
+				{Name: "price", Type: typeDouble, Repetition: repetitionRequired, Converted: -1},
+				{Name: "note", Type: typeByteArray, Repetition: repetitionOptional, Converted: convertedUTF8},
+				{Name: "createdAt", Type: typeInt64, Repetition: repetitionRequired, Converted: convertedTimestampMillis},
+				{Name: "deletedAt", Type: typeInt64, Repetition: repetitionOptional, Converted: convertedTimestampMillis},
+			}, want.Columns)
+			assert.EqualValues(t, len(tt.rows), want.NumRows)
+			assert.Equal(t, tt.rowGroups, want.RowGroups)
+			assert.Equal(t, "ms-nexusMarket-inventory", want.CreatedBy)
+			assert.Equal(t, tt.rows, want.Rows)
+
+			if tt.sameBytes {
+				assert.Equal(t, fixture, buf.Bytes(), "the output differs from %s", path)
+			} else {
+				assert.Equal(t, want, readParquet(t, buf.Bytes()))
+			}
+		})
+	}
+}
+
+func writerRow(row []any) []any {
+	converted := make([]any, len(row))
+	for i, value := range row {
+		if n, ok := value.(int64); ok {
+			value = int(n)
+		}
+		converted[i] = value
+	}
+	return converted
+}
+
+func TestReadLevelsDecodesBitPackedRuns(t *testing.T) {
+	// Other writers pack levels in groups of eight rather than in runs, so
+	// the reader has to decode both to stand in for them.
+	levels := readLevels(t, []byte{0x03, 0b10100101, 0x04, 1}, 10)
+	assert.Equal(t, []byte{1, 0, 1, 0, 0, 1, 0, 1, 1, 1}, levels)
+}
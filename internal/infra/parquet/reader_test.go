@@ -0,0 +1,422 @@
+package parquet_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// This file reads Parquet files the way a consumer does, from the format
+// specification (parquet.thrift and Encodings.md of apache/parquet-format)
+// alone. It lives outside the package so that it cannot lean on the
+// writer's constants or encoders: field IDs, enum values and encodings are
+// spelled out again here, and a file is decoded from its own footer rather
+// than from the columns it was written with.
+
+// Type IDs of the Thrift compact protocol.
+const (
+	thriftTrue   = 1
+	thriftFalse  = 2
+	thriftByte   = 3
+	thriftI16    = 4
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftDouble = 7
+	thriftBinary = 8
+	thriftList   = 9
+	thriftSet    = 10
+	thriftMap    = 11
+	thriftStruct = 12
+)
+
+// thriftDecoder decodes any value of the Thrift compact protocol. Structs
+// are returned as maps of field ID to value.
+type thriftDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *thriftDecoder) next(n int) []byte {
+	if n < 0 || d.pos+n > len(d.data) {
+		panic(fmt.Sprintf("thrift: %d bytes wanted at offset %d of %d", n, d.pos, len(d.data)))
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b
+}
+
+func (d *thriftDecoder) uvarint() uint64 {
+	n, size := binary.Uvarint(d.data[d.pos:])
+	if size <= 0 {
+		panic(fmt.Sprintf("thrift: bad varint at offset %d", d.pos))
+	}
+	d.pos += size
+	return n
+}
+
+func (d *thriftDecoder) zigzag() int64 {
+	n := d.uvarint()
+	return int64(n>>1) ^ -int64(n&1)
+}
+
+func (d *thriftDecoder) value(typ byte) any {
+	switch typ {
+	case thriftTrue:
+		return true
+	case thriftFalse:
+		return false
+	case thriftByte:
+		return int8(d.next(1)[0])
+	case thriftI16:
+		return int16(d.zigzag())
+	case thriftI32:
+		return int32(d.zigzag())
+	case thriftI64:
+		return d.zigzag()
+	case thriftDouble:
+		return math.Float64frombits(binary.LittleEndian.Uint64(d.next(8)))
+	case thriftBinary:
+		return d.next(int(d.uvarint()))
+	case thriftList, thriftSet:
+		header := d.next(1)[0]
+		size, elem := int(header>>4), header&0x0f
+		if size == 15 {
+			size = int(d.uvarint())
+		}
+		list := make([]any, size)
+		for i := range list {
+			if elem == thriftTrue {
+				// Booleans of a collection take a byte each.
+				list[i] = d.next(1)[0] == thriftTrue
+				continue
+			}
+			list[i] = d.value(elem)
+		}
+		return list
+	case thriftMap:
+		size := int(d.uvarint())
+		m := make(map[any]any, size)
+		if size == 0 {
+			return m
+		}
+		types := d.next(1)[0]
+		for range size {
+			key := d.value(types >> 4)
+			if b, ok := key.([]byte); ok {
+				key = string(b)
+			}
+			m[key] = d.value(types & 0x0f)
+		}
+		return m
+	case thriftStruct:
+		return d.structure()
+	}
+	panic(fmt.Sprintf("thrift: unknown type %d at offset %d", typ, d.pos))
+}
+
+func (d *thriftDecoder) structure() thriftFields {
+	fields := thriftFields{}
+	var last int16
+	for {
+		header := d.next(1)[0]
+		if header == 0 {
+			return fields
+		}
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(d.zigzag())
+		}
+		if _, ok := fields[id]; ok {
+			panic(fmt.Sprintf("thrift: field %d repeated", id))
+		}
+		fields[id] = d.value(header & 0x0f)
+		last = id
+	}
+}
+
+type thriftFields map[int16]any
+
+// field returns the value of a required field, failing the test when it is
+// missing or of another type.
+func field[T any](t *testing.T, s thriftFields, id int16, name string) T {
+	t.Helper()
+	v, ok := s[id]
+	require.True(t, ok, "required field %s (%d) is missing", name, id)
+	typed, ok := v.(T)
+	require.True(t, ok, "field %s (%d) is a %T", name, id, v)
+	return typed
+}
+
+// optionalField returns the value of an optional field and whether it is
+// set.
+func optionalField[T any](t *testing.T, s thriftFields, id int16, name string) (T, bool) {
+	t.Helper()
+	if _, ok := s[id]; !ok {
+		var zero T
+		return zero, false
+	}
+	return field[T](t, s, id, name), true
+}
+
+// Enum values of parquet.thrift.
+const (
+	typeInt64     = 2
+	typeDouble    = 5
+	typeByteArray = 6
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	convertedUTF8            = 0
+	convertedTimestampMillis = 9
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	codecUncompressed = 0
+	codecGzip         = 2
+
+	pageData = 0
+)
+
+// schemaColumn is a leaf of the schema of a file.
+type schemaColumn struct {
+	Name       string
+	Type       int32
+	Repetition int32
+	Converted  int32 // -1 when unset
+}
+
+// parquetFile is what readParquet makes of a file.
+type parquetFile struct {
+	Columns   []schemaColumn
+	NumRows   int64
+	RowGroups int
+	CreatedBy string
+	// Rows holds the values of every row, in schema order: int64 or
+	// time.Time for INT64 columns, float64 for DOUBLE, string for UTF8 byte
+	// arrays and nil for nulls.
+	Rows [][]any
+}
+
+func readParquet(t *testing.T, data []byte) parquetFile {
+	t.Helper()
+	require.GreaterOrEqual(t, len(data), 12, "a file holds two magic numbers and a footer length")
+	require.Equal(t, "PAR1", string(data[:4]), "leading magic number")
+	require.Equal(t, "PAR1", string(data[len(data)-4:]), "trailing magic number")
+
+	footerLength := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footerStart := len(data) - 8 - footerLength
+	require.GreaterOrEqual(t, footerStart, 4, "footer length")
+	footer := &thriftDecoder{data: data[footerStart : len(data)-8]}
+	meta := footer.structure()
+	require.Equal(t, footerLength, footer.pos, "the footer length covers the FileMetaData exactly")
+
+	var file parquetFile
+	require.EqualValues(t, 1, field[int32](t, meta, 1, "version"))
+	file.NumRows = field[int64](t, meta, 3, "num_rows")
+	if createdBy, ok := optionalField[[]byte](t, meta, 6, "created_by"); ok {
+		file.CreatedBy = string(createdBy)
+	}
+
+	schema := field[[]any](t, meta, 2, "schema")
+	require.NotEmpty(t, schema, "the schema holds at least its root")
+	root := schema[0].(thriftFields)
+	require.EqualValues(t, len(schema)-1, field[int32](t, root, 5, "num_children"), "a flat schema")
+	for _, element := range schema[1:] {
+		element := element.(thriftFields)
+		_, nested := element[5]
+		require.False(t, nested, "only flat schemas are read")
+		column := schemaColumn{
+			Name:       string(field[[]byte](t, element, 4, "name")),
+			Type:       field[int32](t, element, 1, "type"),
+			Repetition: field[int32](t, element, 3, "repetition_type"),
+			Converted:  -1,
+		}
+		if converted, ok := optionalField[int32](t, element, 6, "converted_type"); ok {
+			column.Converted = converted
+		}
+		file.Columns = append(file.Columns, column)
+	}
+
+	// Column chunks follow each other from the leading magic number to the
+	// footer.
+	next := int64(4)
+	for _, group := range field[[]any](t, meta, 4, "row_groups") {
+		group := group.(thriftFields)
+		numRows := field[int64](t, group, 3, "num_rows")
+		chunks := field[[]any](t, group, 1, "columns")
+		require.Len(t, chunks, len(file.Columns), "a chunk per column")
+
+		var byteSize int64
+		columns := make([][]any, len(chunks))
+		for i, chunk := range chunks {
+			chunkMeta := field[thriftFields](t, chunk.(thriftFields), 3, "meta_data")
+			path := field[[]any](t, chunkMeta, 3, "path_in_schema")
+			require.Equal(t, []any{[]byte(file.Columns[i].Name)}, path, "path_in_schema")
+			require.Equal(t, file.Columns[i].Type, field[int32](t, chunkMeta, 1, "type"), "chunk type")
+			require.NotEmpty(t, field[[]any](t, chunkMeta, 2, "encodings"))
+			numValues := field[int64](t, chunkMeta, 5, "num_values")
+			require.Equal(t, numRows, numValues, "a flat column has a value per row")
+
+			start := field[int64](t, chunkMeta, 9, "data_page_offset")
+			_, dictionary := chunkMeta[11]
+			require.False(t, dictionary, "no dictionary pages are expected")
+			require.Equal(t, next, start, "chunks are contiguous")
+			size := field[int64](t, chunkMeta, 7, "total_compressed_size")
+			next = start + size
+			require.LessOrEqual(t, next, int64(footerStart), "chunks end before the footer")
+
+			uncompressed := field[int64](t, chunkMeta, 6, "total_uncompressed_size")
+			byteSize += uncompressed
+			columns[i] = readChunk(t, data[start:next], file.Columns[i], field[int32](t, chunkMeta, 4, "codec"), numValues, uncompressed)
+		}
+		require.Equal(t, byteSize, field[int64](t, group, 2, "total_byte_size"), "total_byte_size")
+
+		for row := range int(numRows) {
+			values := make([]any, len(columns))
+			for i := range columns {
+				values[i] = columns[i][row]
+			}
+			file.Rows = append(file.Rows, values)
+		}
+		file.RowGroups++
+	}
+	require.Equal(t, int64(footerStart), next, "nothing lies between the last chunk and the footer")
+	require.EqualValues(t, file.NumRows, len(file.Rows), "num_rows is the sum of the row groups")
+	return file
+}
+
+// readChunk decodes the data pages of a column chunk.
+func readChunk(t *testing.T, chunk []byte, column schemaColumn, codec int32, numValues, uncompressedSize int64) []any {
+	t.Helper()
+	var values []any
+	var uncompressed int64
+	for pos := 0; pos < len(chunk); {
+		d := &thriftDecoder{data: chunk[pos:]}
+		header := d.structure()
+		require.EqualValues(t, pageData, field[int32](t, header, 1, "type"), "only data pages are expected")
+		pageSize := int(field[int32](t, header, 2, "uncompressed_page_size"))
+		compressedSize := int(field[int32](t, header, 3, "compressed_page_size"))
+		require.LessOrEqual(t, pos+d.pos+compressedSize, len(chunk), "the page ends within its chunk")
+		page := chunk[pos+d.pos : pos+d.pos+compressedSize]
+		uncompressed += int64(d.pos + pageSize)
+		pos += d.pos + compressedSize
+
+		switch codec {
+		case codecUncompressed:
+		case codecGzip:
+			zr, err := gzip.NewReader(bytes.NewReader(page))
+			require.NoError(t, err)
+			page, err = io.ReadAll(zr)
+			require.NoError(t, err)
+		default:
+			t.Fatalf("unexpected codec %d", codec)
+		}
+		require.Len(t, page, pageSize, "uncompressed_page_size")
+
+		dataHeader := field[thriftFields](t, header, 5, "data_page_header")
+		count := int(field[int32](t, dataHeader, 1, "num_values"))
+		require.EqualValues(t, encodingPlain, field[int32](t, dataHeader, 2, "encoding"))
+		require.EqualValues(t, encodingRLE, field[int32](t, dataHeader, 3, "definition_level_encoding"))
+		require.EqualValues(t, encodingRLE, field[int32](t, dataHeader, 4, "repetition_level_encoding"))
+		values = append(values, readDataPage(t, page, column, count)...)
+	}
+	require.EqualValues(t, numValues, len(values), "num_values")
+	require.Equal(t, uncompressedSize, uncompressed, "total_uncompressed_size")
+	return values
+}
+
+// readDataPage decodes a version 1 data page: the definition levels of an
+// optional column, then the non-null values with the plain encoding. A flat
+// schema has no repetition levels.
+func readDataPage(t *testing.T, page []byte, column schemaColumn, count int) []any {
+	t.Helper()
+	defined := make([]bool, count)
+	switch column.Repetition {
+	case repetitionRequired:
+		for i := range defined {
+			defined[i] = true
+		}
+	case repetitionOptional:
+		require.GreaterOrEqual(t, len(page), 4)
+		length := int(binary.LittleEndian.Uint32(page))
+		require.LessOrEqual(t, 4+length, len(page))
+		levels := readLevels(t, page[4:4+length], count)
+		for i, level := range levels {
+			defined[i] = level == 1
+		}
+		page = page[4+length:]
+	default:
+		t.Fatalf("unexpected repetition %d of column %s", column.Repetition, column.Name)
+	}
+
+	values := make([]any, count)
+	for i := range values {
+		if !defined[i] {
+			continue
+		}
+		switch column.Type {
+		case typeInt64:
+			require.GreaterOrEqual(t, len(page), 8)
+			n := int64(binary.LittleEndian.Uint64(page))
+			page = page[8:]
+			if column.Converted == convertedTimestampMillis {
+				values[i] = time.UnixMilli(n).UTC()
+			} else {
+				values[i] = n
+			}
+		case typeDouble:
+			require.GreaterOrEqual(t, len(page), 8)
+			values[i] = math.Float64frombits(binary.LittleEndian.Uint64(page))
+			page = page[8:]
+		case typeByteArray:
+			require.GreaterOrEqual(t, len(page), 4)
+			size := int(binary.LittleEndian.Uint32(page))
+			require.LessOrEqual(t, 4+size, len(page))
+			require.EqualValues(t, convertedUTF8, column.Converted, "byte arrays are read as strings")
+			values[i] = string(page[4 : 4+size])
+			page = page[4+size:]
+		default:
+			t.Fatalf("unexpected type %d of column %s", column.Type, column.Name)
+		}
+	}
+	require.Empty(t, page, "the page holds nothing after its values")
+	return values
+}
+
+// readLevels decodes count levels of bit width 1 written with the RLE /
+// bit-packing hybrid encoding.
+func readLevels(t *testing.T, data []byte, count int) []byte {
+	t.Helper()
+	d := &thriftDecoder{data: data}
+	var levels []byte
+	for d.pos < len(data) {
+		header := d.uvarint()
+		if header&1 == 1 {
+			// A bit-packed run of header>>1 groups of eight values,
+			// least significant bit first.
+			for _, b := range d.next(int(header >> 1)) {
+				for bit := range 8 {
+					levels = append(levels, b>>bit&1)
+				}
+			}
+			continue
+		}
+		level := d.next(1)[0]
+		require.LessOrEqual(t, level, byte(1), "level of bit width 1")
+		for range header >> 1 {
+			levels = append(levels, level)
+		}
+	}
+	require.GreaterOrEqual(t, len(levels), count, "a level per value")
+	// Bit-packed runs are padded to a multiple of eight.
+	return levels[:count]
+}
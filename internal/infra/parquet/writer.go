@@ -0,0 +1,428 @@
+// Package parquet writes Apache Parquet files. It only covers what the
+// exports write, not the format at large:
+//
+//   - flat schemas of required and optional columns, without nesting or
+//     repeated fields;
+//   - the four column types of the exports: Int64 from Go ints, Double,
+//     UTF-8 String and millisecond Timestamp;
+//   - plain encoding, one data page per column chunk, and no dictionaries,
+//     statistics or page indexes;
+//   - uncompressed or gzip pages.
+//
+// Anything else, such as decimals, dates or another codec, needs a complete
+// Parquet library rather than an extension of this package. Rows are
+// buffered a row group at a time, so memory depends on the row group size
+// and not on the number of rows written.
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// DefaultRowGroupSize is the number of rows buffered before a row group is
+// written.
+const DefaultRowGroupSize = 10_000
+
+const (
+	magic     = "PAR1"
+	createdBy = "ms-nexusMarket-inventory"
+)
+
+// Type is the type of the values of a column.
+type Type int
+
+const (
+	Int64 Type = iota
+	Double
+	// String values are written as UTF-8 byte arrays.
+	String
+	// Timestamp values are written as milliseconds since the Unix epoch, in
+	// UTC.
+	Timestamp
+)
+
+// Codec is the compression applied to the pages of a file.
+type Codec int
+
+const (
+	Uncompressed Codec = iota
+	Gzip
+)
+
+// Column describes a column of the file. Optional columns accept nil values.
+type Column struct {
+	Name     string
+	Type     Type
+	Optional bool
+}
+
+// Physical types, encodings and other enums of the Parquet format.
+const (
+	physicalInt64     = 2
+	physicalDouble    = 5
+	physicalByteArray = 6
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	convertedUTF8            = 0
+	convertedTimestampMillis = 9
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	codecUncompressed = 0
+	codecGzip         = 2
+
+	pageTypeData = 0
+)
+
+var ErrClosed = errors.New("parquet: writer is closed")
+
+// columnBuffer holds the values of a column for the current row group.
+type columnBuffer struct {
+	values bytes.Buffer
+	// levels holds the definition level of every row of an optional column:
+	// 0 for nil, 1 for a value.
+	levels []byte
+}
+
+type columnChunk struct {
+	offset           int64
+	numValues        int64
+	uncompressedSize int64
+	compressedSize   int64
+}
+
+type rowGroup struct {
+	columns  []columnChunk
+	numRows  int64
+	byteSize int64
+}
+
+// Writer writes rows to a Parquet file. Close must be called to write the
+// last row group and the footer.
+type Writer struct {
+	w            io.Writer
+	offset       int64
+	columns      []Column
+	codec        Codec
+	rowGroupSize int
+	buffers      []columnBuffer
+	rows         int
+	rowGroups    []rowGroup
+	numRows      int64
+	closed       bool
+}
+
+// NewWriter returns a writer of a file with the given columns to w. Nothing
+// is written to w before the first row group is full or the writer is
+// closed.
+func NewWriter(w io.Writer, columns []Column, codec Codec) *Writer {
+	return &Writer{
+		w:            w,
+		columns:      columns,
+		codec:        codec,
+		rowGroupSize: DefaultRowGroupSize,
+		buffers:      make([]columnBuffer, len(columns)),
+	}
+}
+
+// Write adds a row holding a value for every column, in order. Int64 columns
+// take an int, Double columns a float64, String columns a string and
+// Timestamp columns a time.Time; other Go types are rejected.
+func (w *Writer) Write(row []any) error {
+	if w.closed {
+		return ErrClosed
+	}
+	if len(row) != len(w.columns) {
+		return fmt.Errorf("parquet: row has %d values, the schema %d columns", len(row), len(w.columns))
+	}
+	// The row is checked in full first, so that a rejected row leaves the
+	// buffered row group as it was.
+	for i, value := range row {
+		if err := check(w.columns[i], value); err != nil {
+			return err
+		}
+	}
+	for i, value := range row {
+		w.append(i, value)
+	}
+	w.rows++
+	if w.rows >= w.rowGroupSize {
+		return w.flush()
+	}
+	return nil
+}
+
+func check(column Column, value any) error {
+	if value == nil {
+		if !column.Optional {
+			return fmt.Errorf("parquet: column %s is required", column.Name)
+		}
+		return nil
+	}
+	var ok bool
+	switch column.Type {
+	case Int64:
+		_, ok = value.(int)
+	case Double:
+		_, ok = value.(float64)
+	case String:
+		_, ok = value.(string)
+	case Timestamp:
+		_, ok = value.(time.Time)
+	}
+	if !ok {
+		return fmt.Errorf("parquet: unexpected %T value in column %s", value, column.Name)
+	}
+	return nil
+}
+
+// append adds a value that passed check to the buffer of column i.
+func (w *Writer) append(i int, value any) {
+	column, buf := w.columns[i], &w.buffers[i]
+	if column.Optional {
+		if value == nil {
+			buf.levels = append(buf.levels, 0)
+			return
+		}
+		buf.levels = append(buf.levels, 1)
+	}
+
+	var scratch [8]byte
+	switch v := value.(type) {
+	case float64:
+		binary.LittleEndian.PutUint64(scratch[:], math.Float64bits(v))
+		buf.values.Write(scratch[:])
+	case string:
+		binary.LittleEndian.PutUint32(scratch[:4], uint32(len(v)))
+		buf.values.Write(scratch[:4])
+		buf.values.WriteString(v)
+	case time.Time:
+		binary.LittleEndian.PutUint64(scratch[:], uint64(v.UnixMilli()))
+		buf.values.Write(scratch[:])
+	case int:
+		binary.LittleEndian.PutUint64(scratch[:], uint64(v))
+		buf.values.Write(scratch[:])
+	}
+}
+
+// Close writes the buffered rows and the footer. It does not close the
+// underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return ErrClosed
+	}
+	w.closed = true
+	if w.rows > 0 {
+		if err := w.flush(); err != nil {
+			return err
+		}
+	}
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	footer := w.fileMetaData()
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(footer)))
+	return w.write(footer, length[:], []byte(magic))
+}
+
+func (w *Writer) write(chunks ...[]byte) error {
+	for _, chunk := range chunks {
+		n, err := w.w.Write(chunk)
+		w.offset += int64(n)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// start writes the leading magic number, once.
+func (w *Writer) start() error {
+	if w.offset > 0 {
+		return nil
+	}
+	return w.write([]byte(magic))
+}
+
+// flush writes the buffered rows as a row group, with one data page per
+// column.
+func (w *Writer) flush() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	group := rowGroup{numRows: int64(w.rows)}
+	for i, column := range w.columns {
+		buf := &w.buffers[i]
+		var page bytes.Buffer
+		if column.Optional {
+			levels := encodeLevels(buf.levels)
+			var length [4]byte
+			binary.LittleEndian.PutUint32(length[:], uint32(len(levels)))
+			page.Write(length[:])
+			page.Write(levels)
+		}
+		page.Write(buf.values.Bytes())
+
+		data := page.Bytes()
+		if w.codec == Gzip {
+			var err error
+			if data, err = gzipBytes(data); err != nil {
+				return err
+			}
+		}
+		header := pageHeader(w.rows, page.Len(), len(data))
+
+		chunk := columnChunk{
+			offset:           w.offset,
+			numValues:        int64(w.rows),
+			uncompressedSize: int64(len(header) + page.Len()),
+			compressedSize:   int64(len(header) + len(data)),
+		}
+		if err := w.write(header, data); err != nil {
+			return err
+		}
+		group.columns = append(group.columns, chunk)
+		group.byteSize += chunk.uncompressedSize
+
+		buf.values.Reset()
+		buf.levels = buf.levels[:0]
+	}
+	w.rowGroups = append(w.rowGroups, group)
+	w.numRows += int64(w.rows)
+	w.rows = 0
+	return nil
+}
+
+// encodeLevels encodes definition levels of bit width 1 with the RLE
+// hybrid encoding, as one run per sequence of equal levels.
+func encodeLevels(levels []byte) []byte {
+	var out []byte
+	for start := 0; start < len(levels); {
+		end := start + 1
+		for end < len(levels) && levels[end] == levels[start] {
+			end++
+		}
+		out = binary.AppendUvarint(out, uint64(end-start)<<1)
+		out = append(out, levels[start])
+		start = end
+	}
+	return out
+}
+
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func pageHeader(numValues, uncompressedSize, compressedSize int) []byte {
+	var c compactWriter
+	c.beginStruct()
+	c.i32Field(1, pageTypeData)
+	c.i32Field(2, int32(uncompressedSize))
+	c.i32Field(3, int32(compressedSize))
+	c.structField(5)
+	c.i32Field(1, int32(numValues))
+	c.i32Field(2, encodingPlain)
+	c.i32Field(3, encodingRLE)
+	c.i32Field(4, encodingRLE)
+	c.endStruct()
+	c.endStruct()
+	return c.buf
+}
+
+func (w *Writer) fileMetaData() []byte {
+	var c compactWriter
+	c.beginStruct()
+	c.i32Field(1, 1)
+
+	c.listField(2, compactStruct, len(w.columns)+1)
+	c.beginStruct()
+	c.stringField(4, "schema")
+	c.i32Field(5, int32(len(w.columns)))
+	c.endStruct()
+	for _, column := range w.columns {
+		c.beginStruct()
+		c.i32Field(1, physicalType(column.Type))
+		repetition := int32(repetitionRequired)
+		if column.Optional {
+			repetition = repetitionOptional
+		}
+		c.i32Field(3, repetition)
+		c.stringField(4, column.Name)
+		switch column.Type {
+		case String:
+			c.i32Field(6, convertedUTF8)
+		case Timestamp:
+			c.i32Field(6, convertedTimestampMillis)
+		}
+		c.endStruct()
+	}
+
+	c.i64Field(3, w.numRows)
+
+	c.listField(4, compactStruct, len(w.rowGroups))
+	for _, group := range w.rowGroups {
+		c.beginStruct()
+		c.listField(1, compactStruct, len(group.columns))
+		for i, chunk := range group.columns {
+			column := w.columns[i]
+			c.beginStruct()
+			c.i64Field(2, chunk.offset)
+			c.structField(3)
+			c.i32Field(1, physicalType(column.Type))
+			c.listField(2, compactI32, 2)
+			c.i32(encodingPlain)
+			c.i32(encodingRLE)
+			c.listField(3, compactBinary, 1)
+			c.string(column.Name)
+			codec := int32(codecUncompressed)
+			if w.codec == Gzip {
+				codec = codecGzip
+			}
+			c.i32Field(4, codec)
+			c.i64Field(5, chunk.numValues)
+			c.i64Field(6, chunk.uncompressedSize)
+			c.i64Field(7, chunk.compressedSize)
+			c.i64Field(9, chunk.offset)
+			c.endStruct()
+			c.endStruct()
+		}
+		c.i64Field(2, group.byteSize)
+		c.i64Field(3, group.numRows)
+		c.endStruct()
+	}
+
+	c.stringField(6, createdBy)
+	c.endStruct()
+	return c.buf
+}
+
+func physicalType(t Type) int32 {
+	switch t {
+	case Double:
+		return physicalDouble
+	case String:
+		return physicalByteArray
+	default:
+		return physicalInt64
+	}
+}
@@ -0,0 +1,278 @@
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// compactReader decodes the Thrift compact structs written by the writer
+// into maps of field ID to value, so the tests can check the file without a
+// Parquet library.
+type compactReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *compactReader) byte() byte {
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *compactReader) uvarint() uint64 {
+	n, size := binary.Uvarint(r.data[r.pos:])
+	r.pos += size
+	return n
+}
+
+func (r *compactReader) varint() int64 {
+	n := r.uvarint()
+	return int64(n>>1) ^ -int64(n&1)
+}
+
+func (r *compactReader) value(typ byte) any {
+	switch typ {
+	case compactI32, compactI64:
+		return r.varint()
+	case compactBinary:
+		size := int(r.uvarint())
+		s := string(r.data[r.pos : r.pos+size])
+		r.pos += size
+		return s
+	case compactList:
+		header := r.byte()
+		size, elem := int(header>>4), header&0x0f
+		if size == 15 {
+			size = int(r.uvarint())
+		}
+		list := make([]any, size)
+		for i := range list {
+			list[i] = r.value(elem)
+		}
+		return list
+	case compactStruct:
+		return r.readStruct()
+	}
+	panic("unexpected compact type")
+}
+
+func (r *compactReader) readStruct() map[int16]any {
+	fields := map[int16]any{}
+	var last int16
+	for {
+		header := r.byte()
+		if header == 0 {
+			return fields
+		}
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			id = int16(r.varint())
+		}
+		fields[id] = r.value(header & 0x0f)
+		last = id
+	}
+}
+
+// readFile checks the framing of a file and returns its footer and the
+// values of every column, read back from the pages.
+func readFile(t *testing.T, data []byte, columns []Column) (map[int16]any, [][]any) {
+	t.Helper()
+	require.GreaterOrEqual(t, len(data), 12)
+	require.Equal(t, magic, string(data[:4]))
+	require.Equal(t, magic, string(data[len(data)-4:]))
+
+	footerLength := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footerStart := len(data) - 8 - footerLength
+	footer := &compactReader{data: data[footerStart : len(data)-8]}
+	meta := footer.readStruct()
+	require.Equal(t, footerLength, footer.pos, "the footer length covers the whole footer")
+
+	values := make([][]any, len(columns))
+	for _, group := range meta[4].([]any) {
+		chunks := group.(map[int16]any)[1].([]any)
+		require.Len(t, chunks, len(columns))
+		for i, chunk := range chunks {
+			chunkMeta := chunk.(map[int16]any)[3].(map[int16]any)
+			offset := int(chunkMeta[9].(int64))
+			assert.Equal(t, chunk.(map[int16]any)[2], chunkMeta[9])
+
+			pageReader := &compactReader{data: data[offset:]}
+			header := pageReader.readStruct()
+			page := data[offset+pageReader.pos : offset+pageReader.pos+int(header[3].(int64))]
+			assert.Equal(t, int64(pageReader.pos+len(page)), chunkMeta[7], "compressed chunk size")
+			if chunkMeta[4].(int64) == codecGzip {
+				zr, err := gzip.NewReader(bytes.NewReader(page))
+				require.NoError(t, err)
+				page, err = io.ReadAll(zr)
+				require.NoError(t, err)
+			}
+			require.Equal(t, int(header[2].(int64)), len(page), "uncompressed page size")
+
+			numValues := int(header[5].(map[int16]any)[1].(int64))
+			values[i] = append(values[i], decodePage(t, page, columns[i], numValues)...)
+		}
+	}
+	return meta, values
+}
+
+func decodePage(t *testing.T, page []byte, column Column, numValues int) []any {
+	t.Helper()
+	levels := make([]byte, 0, numValues)
+	if column.Optional {
+		length := int(binary.LittleEndian.Uint32(page))
+		runs := &compactReader{data: page[4 : 4+length]}
+		for runs.pos < length {
+			header := runs.uvarint()
+			require.Zero(t, header&1, "only RLE runs are written")
+			level := runs.byte()
+			for range header >> 1 {
+				levels = append(levels, level)
+			}
+		}
+		page = page[4+length:]
+	} else {
+		for range numValues {
+			levels = append(levels, 1)
+		}
+	}
+	require.Len(t, levels, numValues)
+
+	values := make([]any, 0, numValues)
+	for _, level := range levels {
+		if level == 0 {
+			values = append(values, nil)
+			continue
+		}
+		switch column.Type {
+		case Int64, Timestamp:
+			values = append(values, int64(binary.LittleEndian.Uint64(page)))
+			page = page[8:]
+		case Double:
+			values = append(values, math.Float64frombits(binary.LittleEndian.Uint64(page)))
+			page = page[8:]
+		case String:
+			size := int(binary.LittleEndian.Uint32(page))
+			values = append(values, string(page[4:4+size]))
+			page = page[4+size:]
+		}
+	}
+	assert.Empty(t, page, "every value is read")
+	return values
+}
+
+var testColumns = []Column{
+	{Name: "id", Type: Int64},
+	{Name: "name", Type: String},
+	{Name: "price", Type: Double},
+	{Name: "note", Type: String, Optional: true},
+	{Name: "createdAt", Type: Timestamp},
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	created := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	for _, codec := range []Codec{Uncompressed, Gzip} {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, testColumns, codec)
+		w.rowGroupSize = 2
+
+		require.NoError(t, w.Write([]any{1, "Boot", 59.9, nil, created}))
+		require.NoError(t, w.Write([]any{2, "Sandal", 19.5, "summer", created.Add(time.Hour)}))
+		require.NoError(t, w.Write([]any{3, "Clog", 0.0, nil, created}))
+		require.NoError(t, w.Close())
+
+		meta, values := readFile(t, buf.Bytes(), testColumns)
+		assert.Equal(t, int64(1), meta[1], "version")
+		assert.Equal(t, int64(3), meta[3], "num_rows")
+		assert.Len(t, meta[4], 2, "row groups")
+		assert.Equal(t, createdBy, meta[6])
+
+		schema := meta[2].([]any)
+		require.Len(t, schema, len(testColumns)+1)
+		assert.Equal(t, int64(len(testColumns)), schema[0].(map[int16]any)[5])
+		note := schema[4].(map[int16]any)
+		assert.Equal(t, "note", note[4])
+		assert.Equal(t, int64(repetitionOptional), note[3])
+		assert.Equal(t, int64(convertedTimestampMillis), schema[5].(map[int16]any)[6])
+
+		assert.Equal(t, []any{int64(1), int64(2), int64(3)}, values[0])
+		assert.Equal(t, []any{"Boot", "Sandal", "Clog"}, values[1])
+		assert.Equal(t, []any{59.9, 19.5, 0.0}, values[2])
+		assert.Equal(t, []any{nil, "summer", nil}, values[3])
+		assert.Equal(t, []any{created.UnixMilli(), created.Add(time.Hour).UnixMilli(), created.UnixMilli()}, values[4])
+	}
+}
+
+func TestWriterWithoutRows(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, testColumns, Gzip)
+	require.NoError(t, w.Close())
+
+	meta, values := readFile(t, buf.Bytes(), testColumns)
+	assert.Equal(t, int64(0), meta[3])
+	assert.Empty(t, meta[4])
+	assert.Empty(t, values[0])
+}
+
+func TestWriterBuffersARowGroup(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, testColumns, Uncompressed)
+	w.rowGroupSize = 3
+
+	for i := range 2 {
+		require.NoError(t, w.Write([]any{i, "Boot", 1.0, nil, time.Now()}))
+	}
+	assert.Zero(t, buf.Len(), "nothing is written before a row group is full")
+
+	require.NoError(t, w.Write([]any{2, "Boot", 1.0, nil, time.Now()}))
+	assert.NotZero(t, buf.Len())
+	assert.Zero(t, w.buffers[0].values.Len(), "the row group is released once written")
+}
+
+func TestWriterRejectsInvalidRows(t *testing.T) {
+	w := NewWriter(io.Discard, testColumns, Uncompressed)
+
+	assert.ErrorContains(t, w.Write([]any{1, "Boot"}), "row has 2 values")
+	assert.ErrorContains(t, w.Write([]any{1, nil, 1.0, nil, time.Now()}), "column name is required")
+	assert.ErrorContains(t, w.Write([]any{1, "Boot", 1, nil, time.Now()}), "unexpected int value in column price")
+	assert.ErrorContains(t, w.Write([]any{int64(1), "Boot", 1.0, nil, time.Now()}), "unexpected int64 value in column id")
+	assert.ErrorContains(t, w.Write([]any{1, "Boot", 1.0, nil, "2026-01-31"}), "unexpected string value in column createdAt")
+
+	assert.Zero(t, w.rows)
+	assert.Zero(t, w.buffers[0].values.Len(), "a rejected row leaves nothing behind")
+
+	require.NoError(t, w.Close())
+	assert.ErrorIs(t, w.Write([]any{1, "Boot", 1.0, nil, time.Now()}), ErrClosed)
+}
+
+func TestEncodeLevels(t *testing.T) {
+	assert.Equal(t, []byte{0x04, 1, 0x06, 0, 0x02, 1}, encodeLevels([]byte{1, 1, 0, 0, 0, 1}))
+	assert.Empty(t, encodeLevels(nil))
+}
+
+func TestCompactWriterLongFieldDeltaAndList(t *testing.T) {
+	var c compactWriter
+	c.beginStruct()
+	c.i32Field(1, -1)
+	c.i64Field(20, 300)
+	c.listField(21, compactI32, 20)
+	for i := range 20 {
+		c.i32(int32(i))
+	}
+	c.endStruct()
+
+	r := &compactReader{data: c.buf}
+	fields := r.readStruct()
+	assert.Equal(t, int64(-1), fields[1])
+	assert.Equal(t, int64(300), fields[20])
+	assert.Len(t, fields[21], 20)
+	assert.Equal(t, len(c.buf), r.pos)
+}
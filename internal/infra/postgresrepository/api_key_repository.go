@@ -0,0 +1,159 @@
+package postgresrepository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// apiKeyRow is the api_keys row. Scopes are stored space separated, as in
+// OAuth scope strings.
+type apiKeyRow struct {
+	ID         string
+	TenantID   string
+	Name       string
+	Prefix     string
+	SecretHash string
+	Scopes     string
+	CreatedBy  *string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	LastUsedAt *time.Time
+	RotatedAt  *time.Time
+	RevokedAt  *time.Time
+}
+
+func (apiKeyRow) TableName() string {
+	return "api_keys"
+}
+
+func toAPIKeyRow(key *domain.APIKey) *apiKeyRow {
+	row := &apiKeyRow{
+		ID:         key.ID,
+		TenantID:   key.TenantID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		SecretHash: key.SecretHash,
+		Scopes:     strings.Join(key.Scopes, " "),
+		CreatedAt:  key.CreatedAt,
+		UpdatedAt:  key.UpdatedAt,
+		LastUsedAt: key.LastUsedAt,
+		RotatedAt:  key.RotatedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+	// created_by is a nullable UUID column; an empty string is not a valid UUID.
+	if key.CreatedBy != "" {
+		row.CreatedBy = &key.CreatedBy
+	}
+	return row
+}
+
+func (row *apiKeyRow) toDomain() *domain.APIKey {
+	key := &domain.APIKey{
+		ID:         row.ID,
+		TenantID:   row.TenantID,
+		Name:       row.Name,
+		Prefix:     row.Prefix,
+		SecretHash: row.SecretHash,
+		Scopes:     strings.Fields(row.Scopes),
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+		LastUsedAt: row.LastUsedAt,
+		RotatedAt:  row.RotatedAt,
+		RevokedAt:  row.RevokedAt,
+	}
+	if row.CreatedBy != nil {
+		key.CreatedBy = *row.CreatedBy
+	}
+	return key
+}
+
+type APIKeyRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepositoryPostgres(db *gorm.DB) *APIKeyRepositoryPostgres {
+	return &APIKeyRepositoryPostgres{
+		db: db,
+	}
+}
+
+func (r *APIKeyRepositoryPostgres) Create(ctx context.Context, key *domain.APIKey) error {
+	now := time.Now()
+	key.ID = uuid.NewString()
+	key.CreatedAt = now
+	key.UpdatedAt = now
+	row := toAPIKeyRow(key)
+	if err := conn(ctx, r.db).Create(row).Error; err != nil {
+		return err
+	}
+	key.TenantID = row.TenantID
+	return nil
+}
+
+func (r *APIKeyRepositoryPostgres) GetByID(ctx context.Context, id string) (*domain.APIKey, error) {
+	return r.first(conn(ctx, r.db), "id = ?", id)
+}
+
+// GetByPrefix looks the key up in every tenant, since it runs before the
+// tenant of the request is known.
+func (r *APIKeyRepositoryPostgres) GetByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	return r.first(acrossTenants(conn(ctx, r.db)), "prefix = ?", prefix)
+}
+
+func (r *APIKeyRepositoryPostgres) first(tx *gorm.DB, query string, arg any) (*domain.APIKey, error) {
+	var row apiKeyRow
+	result := tx.Where(query, arg).First(&row)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, result.Error
+	}
+	return row.toDomain(), nil
+}
+
+func (r *APIKeyRepositoryPostgres) List(ctx context.Context) ([]*domain.APIKey, error) {
+	var rows []apiKeyRow
+	if err := conn(ctx, r.db).Order("created_at DESC, id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	keys := make([]*domain.APIKey, 0, len(rows))
+	for i := range rows {
+		keys = append(keys, rows[i].toDomain())
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepositoryPostgres) Revoke(ctx context.Context, id string, at time.Time) error {
+	return r.update(ctx, id, map[string]any{"revoked_at": at, "updated_at": at})
+}
+
+func (r *APIKeyRepositoryPostgres) UpdateSecret(ctx context.Context, id, prefix, secretHash string, at time.Time) error {
+	return r.update(ctx, id, map[string]any{"prefix": prefix, "secret_hash": secretHash, "rotated_at": at, "updated_at": at})
+}
+
+func (r *APIKeyRepositoryPostgres) update(ctx context.Context, id string, values map[string]any) error {
+	result := conn(ctx, r.db).Model(&apiKeyRow{}).Where("id = ?", id).Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed skips the write when the key was used recently, so that
+// busy keys do not update their row on every request. Like GetByPrefix, it
+// runs while authenticating, before the tenant is known.
+func (r *APIKeyRepositoryPostgres) TouchLastUsed(ctx context.Context, id string, at time.Time, interval time.Duration) error {
+	return acrossTenants(conn(ctx, r.db)).Model(&apiKeyRow{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, at.Add(-interval)).
+		UpdateColumn("last_used_at", at).Error
+}
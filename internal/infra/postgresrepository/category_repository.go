@@ -2,13 +2,32 @@
 package postgresrepository
 
 import (
+	"context"
 	"errors"
 	"time"
 
 	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
+// uniqueViolation is the SQLSTATE of a unique constraint violation.
+const uniqueViolation = "23505"
+
+var categoryListSpec = listSpec[*domain.Category]{
+	sortColumns: map[string]sortColumn{
+		"name":      textSortColumn("name"),
+		"createdAt": timeSortColumn("created_at"),
+	},
+	searchColumn: "name",
+	cursorValue: func(c *domain.Category, sortBy string) (string, int) {
+		if sortBy == "createdAt" {
+			return c.CreatedAt.Format(time.RFC3339Nano), c.ID
+		}
+		return c.Name, c.ID
+	},
+}
+
 type CategoryRepositoryPostgres struct {
 	db *gorm.DB
 }
@@ -19,14 +38,26 @@ func NewCategoryRepositoryPostgres(db *gorm.DB) *CategoryRepositoryPostgres {
 	}
 }
 
-func (r *CategoryRepositoryPostgres) Create(category *domain.Category) error {
+func (r *CategoryRepositoryPostgres) Create(ctx context.Context, category *domain.Category) error {
 	category.CreatedAt = time.Now()
-	return r.db.Create(category).Error
+	return conn(ctx, r.db).Create(category).Error
+}
+
+func (r *CategoryRepositoryPostgres) GetByID(ctx context.Context, id int) (*domain.Category, error) {
+	var category domain.Category
+	result := conn(ctx, r.db).First(&category, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrCategoryNotFound
+		}
+		return nil, result.Error
+	}
+	return &category, nil
 }
 
-func (r *CategoryRepositoryPostgres) GetByID(id int) (*domain.Category, error) {
+func (r *CategoryRepositoryPostgres) GetDeletedByID(ctx context.Context, id int) (*domain.Category, error) {
 	var category domain.Category
-	result := r.db.First(&category, id)
+	result := conn(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").First(&category, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrCategoryNotFound
@@ -36,21 +67,82 @@ func (r *CategoryRepositoryPostgres) GetByID(id int) (*domain.Category, error) {
 	return &category, nil
 }
 
-func (r *CategoryRepositoryPostgres) ListAll() ([]*domain.Category, error) {
+func (r *CategoryRepositoryPostgres) ListAll(ctx context.Context, includeDeleted bool) ([]*domain.Category, error) {
 	var categories []*domain.Category
-	result := r.db.Find(&categories)
+	tx := conn(ctx, r.db)
+	if includeDeleted {
+		tx = tx.Unscoped()
+	}
+	result := tx.Find(&categories)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return categories, nil
 }
 
-func (r *CategoryRepositoryPostgres) Update(category *domain.Category) error {
+func (r *CategoryRepositoryPostgres) List(ctx context.Context, query domain.ListQuery) (*domain.Page[*domain.Category], error) {
+	return paginate(conn(ctx, r.db).Model(&domain.Category{}), query, categoryListSpec)
+}
+
+func (r *CategoryRepositoryPostgres) ListDescendants(ctx context.Context, id int, includeDeleted bool) ([]*domain.Category, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var categories []*domain.Category
+	result := conn(ctx, r.db).Raw(`
+		WITH RECURSIVE descendants AS (
+			SELECT * FROM categories WHERE tenant_id = ? AND parent_id = ? AND (? OR deleted_at IS NULL)
+			UNION ALL
+			SELECT c.* FROM categories c
+			JOIN descendants d ON c.tenant_id = d.tenant_id AND c.parent_id = d.id
+			WHERE ? OR c.deleted_at IS NULL
+		)
+		SELECT * FROM descendants ORDER BY name`, tenant, id, includeDeleted, includeDeleted).Scan(&categories)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return categories, nil
+}
+
+func (r *CategoryRepositoryPostgres) Update(ctx context.Context, category *domain.Category) error {
 	now := time.Now()
 	category.UpdatedAt = &now
-	return r.db.Updates(category).Error
+	// Select the columns explicitly so that clearing ParentID moves the
+	// category back to the root instead of being skipped as a zero value.
+	return conn(ctx, r.db).Model(category).Select("Name", "ParentID", "UpdatedAt").Updates(category).Error
+}
+
+// ReassignChildren also moves the soft-deleted children, which still
+// reference their parent.
+func (r *CategoryRepositoryPostgres) ReassignChildren(ctx context.Context, fromParentID, toParentID int) error {
+	return conn(ctx, r.db).Unscoped().Model(&domain.Category{}).
+		Where("parent_id = ?", fromParentID).
+		Updates(map[string]any{"parent_id": toParentID, "updated_at": time.Now()}).Error
 }
 
-func (r *CategoryRepositoryPostgres) Delete(id int) error {
-	return r.db.Delete(&domain.Category{}, id).Error
+// Delete removes the row permanently, bypassing GORM's soft delete.
+func (r *CategoryRepositoryPostgres) Delete(ctx context.Context, id int) error {
+	return conn(ctx, r.db).Unscoped().Delete(&domain.Category{}, id).Error
+}
+
+func (r *CategoryRepositoryPostgres) SoftDelete(ctx context.Context, id int) error {
+	return conn(ctx, r.db).Delete(&domain.Category{}, id).Error
+}
+
+func (r *CategoryRepositoryPostgres) Restore(ctx context.Context, id int) error {
+	result := conn(ctx, r.db).Unscoped().Model(&domain.Category{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	var pgErr *pgconn.PgError
+	if errors.As(result.Error, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == "uq_categories_name_active" {
+		return domain.ErrCategoryNameTaken
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrCategoryNotFound
+	}
+	return nil
 }
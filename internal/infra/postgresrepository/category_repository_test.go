@@ -0,0 +1,33 @@
+package postgresrepository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/tenant"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReassignChildrenMovesSoftDeletedChildren(t *testing.T) {
+	db, recorder := recordingDB(t)
+	ctx := tenant.WithID(context.Background(), "seller-a")
+
+	require.NoError(t, NewCategoryRepositoryPostgres(db).ReassignChildren(ctx, 1, 2))
+
+	statements := recorder.take()
+	require.Len(t, statements, 1)
+	assert.NotContains(t, statements[0].query, "deleted_at IS NULL")
+}
+
+func TestRestoreCategoryWithATakenName(t *testing.T) {
+	db, recorder := recordingDB(t)
+	ctx := tenant.WithID(context.Background(), "seller-a")
+	recorder.execErr = &pgconn.PgError{Code: "23505", ConstraintName: "uq_categories_name_active"}
+
+	err := NewCategoryRepositoryPostgres(db).Restore(ctx, 1)
+
+	assert.ErrorIs(t, err, domain.ErrCategoryNameTaken)
+}
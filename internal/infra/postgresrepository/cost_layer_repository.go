@@ -0,0 +1,58 @@
+package postgresrepository
+
+import (
+	"context"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CostLayerRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewCostLayerRepositoryPostgres(db *gorm.DB) *CostLayerRepositoryPostgres {
+	return &CostLayerRepositoryPostgres{
+		db: db,
+	}
+}
+
+func (r *CostLayerRepositoryPostgres) Create(ctx context.Context, layer *domain.CostLayer) error {
+	tx := conn(ctx, r.db)
+	if layer.MovementID == 0 {
+		tx = tx.Omit("MovementID")
+	}
+	return tx.Create(layer).Error
+}
+
+func (r *CostLayerRepositoryPostgres) ListOpenByProductID(ctx context.Context, productID int) ([]*domain.CostLayer, error) {
+	var layers []*domain.CostLayer
+	result := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("product_id = ? AND remaining_qty > 0", productID).
+		Order("received_at, id").
+		Find(&layers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return layers, nil
+}
+
+func (r *CostLayerRepositoryPostgres) ListOpening(ctx context.Context) ([]*domain.CostLayer, error) {
+	var layers []*domain.CostLayer
+	result := conn(ctx, r.db).
+		Where("movement_id IS NULL").
+		Order("product_id, received_at, id").
+		Find(&layers)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return layers, nil
+}
+
+func (r *CostLayerRepositoryPostgres) UpdateRemainingQty(ctx context.Context, id int, remainingQty int) error {
+	return conn(ctx, r.db).Model(&domain.CostLayer{}).
+		Where("id = ?", id).
+		Update("remaining_qty", remainingQty).Error
+}
@@ -0,0 +1,200 @@
+package postgresrepository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// importJobRow is the import_jobs row. Row errors are stored as a JSON
+// array.
+type importJobRow struct {
+	ID                string
+	TenantID          string
+	Format            string
+	DryRun            bool
+	Status            string
+	Payload           []byte
+	TotalRows         int
+	ProcessedRows     int
+	CreatedProducts   int
+	CreatedCategories int
+	Errors            string
+	ErrorCount        int
+	Failure           string
+	UserID            *string
+	CreatedAt         time.Time
+	StartedAt         *time.Time
+	FinishedAt        *time.Time
+}
+
+func (importJobRow) TableName() string {
+	return "import_jobs"
+}
+
+type importRowErrorJSON struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func marshalImportErrors(rowErrors []domain.ImportRowError) (string, error) {
+	items := make([]importRowErrorJSON, 0, len(rowErrors))
+	for _, e := range rowErrors {
+		items = append(items, importRowErrorJSON(e))
+	}
+	data, err := json.Marshal(items)
+	return string(data), err
+}
+
+func (row *importJobRow) toDomain() (*domain.ImportJob, error) {
+	job := &domain.ImportJob{
+		ID:                row.ID,
+		TenantID:          row.TenantID,
+		Format:            row.Format,
+		DryRun:            row.DryRun,
+		Status:            row.Status,
+		Payload:           row.Payload,
+		TotalRows:         row.TotalRows,
+		ProcessedRows:     row.ProcessedRows,
+		CreatedProducts:   row.CreatedProducts,
+		CreatedCategories: row.CreatedCategories,
+		ErrorCount:        row.ErrorCount,
+		Failure:           row.Failure,
+		CreatedAt:         row.CreatedAt,
+		StartedAt:         row.StartedAt,
+		FinishedAt:        row.FinishedAt,
+	}
+	if row.UserID != nil {
+		job.UserID = *row.UserID
+	}
+	if row.Errors != "" {
+		var items []importRowErrorJSON
+		if err := json.Unmarshal([]byte(row.Errors), &items); err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			job.Errors = append(job.Errors, domain.ImportRowError(item))
+		}
+	}
+	return job, nil
+}
+
+type ImportJobRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewImportJobRepositoryPostgres(db *gorm.DB) *ImportJobRepositoryPostgres {
+	return &ImportJobRepositoryPostgres{
+		db: db,
+	}
+}
+
+func (r *ImportJobRepositoryPostgres) Create(ctx context.Context, job *domain.ImportJob) error {
+	job.ID = uuid.NewString()
+	job.CreatedAt = time.Now()
+	row := &importJobRow{
+		ID:        job.ID,
+		Format:    job.Format,
+		DryRun:    job.DryRun,
+		Status:    job.Status,
+		Payload:   job.Payload,
+		Errors:    "[]",
+		CreatedAt: job.CreatedAt,
+	}
+	// user_id is a nullable UUID column; an empty string is not a valid UUID.
+	if job.UserID != "" {
+		row.UserID = &job.UserID
+	}
+	if err := conn(ctx, r.db).Create(row).Error; err != nil {
+		return err
+	}
+	job.TenantID = row.TenantID
+	return nil
+}
+
+func (r *ImportJobRepositoryPostgres) GetByID(ctx context.Context, id string) (*domain.ImportJob, error) {
+	var row importJobRow
+	result := conn(ctx, r.db).Omit("Payload").Where("id = ?", id).First(&row)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrImportJobNotFound
+		}
+		return nil, result.Error
+	}
+	return row.toDomain()
+}
+
+// ClaimNext runs outside of any tenant: the worker serves them all, and
+// runs the claimed job in its tenant.
+func (r *ImportJobRepositoryPostgres) ClaimNext(ctx context.Context) (*domain.ImportJob, error) {
+	var rows []importJobRow
+	err := acrossTenants(conn(ctx, r.db)).Raw(`
+		UPDATE import_jobs SET status = ?, started_at = ?
+		WHERE id = (
+			SELECT id FROM import_jobs WHERE status = ?
+			ORDER BY created_at, id LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		domain.ImportStatusRunning, time.Now(), domain.ImportStatusPending,
+	).Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	return rows[0].toDomain()
+}
+
+func (r *ImportJobRepositoryPostgres) FailStale(ctx context.Context, startedBefore time.Time, failure string) (int, error) {
+	result := acrossTenants(conn(ctx, r.db)).Model(&importJobRow{}).
+		Where("status = ? AND started_at < ?", domain.ImportStatusRunning, startedBefore).
+		Updates(map[string]any{
+			"status":      domain.ImportStatusFailed,
+			"failure":     failure,
+			"payload":     nil,
+			"finished_at": time.Now(),
+		})
+	return int(result.RowsAffected), result.Error
+}
+
+func (r *ImportJobRepositoryPostgres) UpdateProgress(ctx context.Context, id string, processedRows int) error {
+	return conn(ctx, r.db).Model(&importJobRow{}).
+		Where("id = ?", id).
+		Update("processed_rows", processedRows).Error
+}
+
+func (r *ImportJobRepositoryPostgres) Finish(ctx context.Context, job *domain.ImportJob) error {
+	rowErrors, err := marshalImportErrors(job.Errors)
+	if err != nil {
+		return err
+	}
+	finishedAt := time.Now()
+	result := conn(ctx, r.db).Model(&importJobRow{}).
+		Where("id = ?", job.ID).
+		Updates(map[string]any{
+			"status":             job.Status,
+			"payload":            nil,
+			"total_rows":         job.TotalRows,
+			"processed_rows":     job.ProcessedRows,
+			"created_products":   job.CreatedProducts,
+			"created_categories": job.CreatedCategories,
+			"errors":             rowErrors,
+			"error_count":        job.ErrorCount,
+			"failure":            job.Failure,
+			"finished_at":        finishedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrImportJobNotFound
+	}
+	job.Payload = nil
+	job.FinishedAt = &finishedAt
+	return nil
+}
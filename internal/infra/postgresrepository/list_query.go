@@ -0,0 +1,106 @@
+package postgresrepository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"gorm.io/gorm"
+)
+
+// sortColumn maps an API sort field to a column and converts cursor values
+// back and forth between the column type and their string form.
+type sortColumn struct {
+	column string
+	parse  func(string) (any, error)
+}
+
+func textSortColumn(column string) sortColumn {
+	return sortColumn{column: column, parse: func(v string) (any, error) { return v, nil }}
+}
+
+func timeSortColumn(column string) sortColumn {
+	return sortColumn{column: column, parse: func(v string) (any, error) { return time.Parse(time.RFC3339Nano, v) }}
+}
+
+// listSpec describes how a repository exposes a table to list queries.
+type listSpec[T any] struct {
+	sortColumns map[string]sortColumn
+	// searchColumn is matched by ListQuery.Search and ListQuery.Prefix; leave
+	// empty for resources without a text name.
+	searchColumn string
+	// cursorValue returns the string form of item's sort field.
+	cursorValue func(item T, sortBy string) (value string, id int)
+}
+
+// paginate runs a normalized ListQuery against tx, which must already carry
+// the model and any resource specific filters.
+func paginate[T any](tx *gorm.DB, q domain.ListQuery, spec listSpec[T]) (*domain.Page[T], error) {
+	sort, ok := spec.sortColumns[q.SortBy]
+	if !ok {
+		return nil, domain.ErrInvalidSortField
+	}
+
+	if q.IncludeDeleted {
+		tx = tx.Unscoped()
+	}
+	if spec.searchColumn != "" {
+		if q.Search != "" {
+			tx = tx.Where(spec.searchColumn+" ILIKE ?", "%"+escapeLike(q.Search)+"%")
+		}
+		if q.Prefix != "" {
+			tx = tx.Where(spec.searchColumn+" ILIKE ?", escapeLike(q.Prefix)+"%")
+		}
+	}
+	tx = tx.Session(&gorm.Session{})
+
+	var total int64
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	comparison, direction := ">", "ASC"
+	if q.Order == domain.SortDesc {
+		comparison, direction = "<", "DESC"
+	}
+
+	page := tx
+	if q.Cursor != "" {
+		cursor, err := domain.DecodeCursor(q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		value, err := sort.parse(cursor.Value)
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+		page = page.Where(fmt.Sprintf("(%s, id) %s (?, ?)", sort.column, comparison), value, cursor.ID)
+	} else if q.Offset > 0 {
+		page = page.Offset(q.Offset)
+	}
+
+	var items []T
+	// Fetch one extra row to know whether another page follows.
+	err := page.
+		Order(fmt.Sprintf("%s %s, id %s", sort.column, direction, direction)).
+		Limit(q.Limit + 1).
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.Page[T]{Total: total, Limit: q.Limit, Offset: q.Offset}
+	if len(items) > q.Limit {
+		items = items[:q.Limit]
+		value, id := spec.cursorValue(items[len(items)-1], q.SortBy)
+		result.NextCursor = domain.EncodeCursor(domain.Cursor{Value: value, ID: id})
+	}
+	result.Items = items
+
+	return result, nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,155 @@
+package postgresrepository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+var productListSpec = listSpec[*domain.Product]{
+	sortColumns: map[string]sortColumn{
+		"name":      textSortColumn("name"),
+		"createdAt": timeSortColumn("created_at"),
+	},
+	searchColumn: "name",
+	cursorValue: func(p *domain.Product, sortBy string) (string, int) {
+		if sortBy == "createdAt" {
+			return p.CreatedAt.Format(time.RFC3339Nano), p.ID
+		}
+		return p.Name, p.ID
+	},
+}
+
+type ProductRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewProductRepositoryPostgres(db *gorm.DB) *ProductRepositoryPostgres {
+	return &ProductRepositoryPostgres{
+		db: db,
+	}
+}
+
+func (r *ProductRepositoryPostgres) Create(ctx context.Context, product *domain.Product) error {
+	now := time.Now()
+	product.CreatedAt = now
+	product.UpdatedAt = now
+	tx := conn(ctx, r.db)
+	if product.SKU == "" {
+		// Products without a SKU store NULL, which the unique index ignores.
+		tx = tx.Omit("SKU")
+	}
+	return skuTaken(tx.Create(product).Error)
+}
+
+func (r *ProductRepositoryPostgres) GetByID(ctx context.Context, id int) (*domain.Product, error) {
+	var product domain.Product
+	result := conn(ctx, r.db).First(&product, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, result.Error
+	}
+	return &product, nil
+}
+
+func (r *ProductRepositoryPostgres) GetDeletedByID(ctx context.Context, id int) (*domain.Product, error) {
+	var product domain.Product
+	result := conn(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").First(&product, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, result.Error
+	}
+	return &product, nil
+}
+
+func (r *ProductRepositoryPostgres) ListAll(ctx context.Context, includeDeleted bool) ([]*domain.Product, error) {
+	var products []*domain.Product
+	tx := conn(ctx, r.db)
+	if includeDeleted {
+		tx = tx.Unscoped()
+	}
+	result := tx.Order("id").Find(&products)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return products, nil
+}
+
+func (r *ProductRepositoryPostgres) Stream(ctx context.Context, includeDeleted bool, fn func(*domain.Product) error) error {
+	tx := conn(ctx, r.db).Model(&domain.Product{})
+	if includeDeleted {
+		tx = tx.Unscoped()
+	}
+	return stream(tx.Order("id"), fn)
+}
+
+func (r *ProductRepositoryPostgres) ListByCategoryIDs(ctx context.Context, categoryIDs []int, includeDeleted bool) ([]*domain.Product, error) {
+	var products []*domain.Product
+	tx := conn(ctx, r.db)
+	if includeDeleted {
+		tx = tx.Unscoped()
+	}
+	result := tx.Where("category_id IN ?", categoryIDs).Order("id").Find(&products)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return products, nil
+}
+
+// List pages through products, restricted to categoryIDs when not empty.
+func (r *ProductRepositoryPostgres) List(ctx context.Context, categoryIDs []int, query domain.ListQuery) (*domain.Page[*domain.Product], error) {
+	tx := conn(ctx, r.db).Model(&domain.Product{})
+	if len(categoryIDs) > 0 {
+		tx = tx.Where("category_id IN ?", categoryIDs)
+	}
+	return paginate(tx, query, productListSpec)
+}
+
+func (r *ProductRepositoryPostgres) Update(ctx context.Context, product *domain.Product) error {
+	product.UpdatedAt = time.Now()
+	return skuTaken(conn(ctx, r.db).Updates(product).Error)
+}
+
+// ReassignCategory also moves soft-deleted products, since they still hold a
+// foreign key to the category.
+func (r *ProductRepositoryPostgres) ReassignCategory(ctx context.Context, fromCategoryID, toCategoryID int) error {
+	return conn(ctx, r.db).Unscoped().Model(&domain.Product{}).
+		Where("category_id = ?", fromCategoryID).
+		Updates(map[string]any{"category_id": toCategoryID, "updated_at": time.Now()}).Error
+}
+
+// Delete soft-deletes the product so that its movement history stays intact.
+func (r *ProductRepositoryPostgres) Delete(ctx context.Context, id int) error {
+	return conn(ctx, r.db).Delete(&domain.Product{}, id).Error
+}
+
+func (r *ProductRepositoryPostgres) Restore(ctx context.Context, id int) error {
+	result := conn(ctx, r.db).Unscoped().Model(&domain.Product{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return skuTaken(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrProductNotFound
+	}
+	return nil
+}
+
+// skuTaken maps a violation of the unique SKU index of active products to
+// ErrProductSKUTaken.
+func skuTaken(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == "uq_products_sku_active" {
+		return domain.ErrProductSKUTaken
+	}
+	return err
+}
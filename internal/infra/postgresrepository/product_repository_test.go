@@ -0,0 +1,43 @@
+package postgresrepository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/tenant"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductWritesMapATakenSKU(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(ctx context.Context, repo *ProductRepositoryPostgres) error
+	}{
+		{
+			name: "restore",
+			write: func(ctx context.Context, repo *ProductRepositoryPostgres) error {
+				return repo.Restore(ctx, 1)
+			},
+		},
+		{
+			name: "update",
+			write: func(ctx context.Context, repo *ProductRepositoryPostgres) error {
+				return repo.Update(ctx, &domain.Product{ID: 1, Name: "Oak chair", SKU: "CHAIR-OAK-01", CategoryID: 2})
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db, recorder := recordingDB(t)
+			ctx := tenant.WithID(context.Background(), "seller-a")
+			recorder.execErr = &pgconn.PgError{Code: "23505", ConstraintName: "uq_products_sku_active"}
+
+			err := tc.write(ctx, NewProductRepositoryPostgres(db))
+
+			assert.ErrorIs(t, err, domain.ErrProductSKUTaken)
+		})
+	}
+}
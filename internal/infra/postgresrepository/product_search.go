@@ -0,0 +1,157 @@
+package postgresrepository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+type productSearchRow struct {
+	domain.Product
+	Rank float64
+}
+
+type categoryFacetRow struct {
+	CategoryID   int
+	CategoryName string
+	Count        int64
+}
+
+type priceFacetRow struct {
+	Bucket int
+	Count  int64
+}
+
+// productSearchFilter builds the FROM and WHERE clauses shared by the search
+// queries. Facet queries skip their own filter through the without* flags.
+type productSearchFilter struct {
+	tenantID        string
+	query           domain.ProductSearchQuery
+	tsQuery         string
+	withoutCategory bool
+	withoutPrice    bool
+}
+
+func (f productSearchFilter) sql() (string, []any) {
+	var b strings.Builder
+	args := []any{f.tsQuery, f.tenantID}
+	b.WriteString(" FROM products p CROSS JOIN to_tsquery('simple', ?) AS q")
+	b.WriteString(" WHERE p.tenant_id = ? AND p.deleted_at IS NULL AND p.search_vector @@ q")
+	if len(f.query.CategoryIDs) > 0 && !f.withoutCategory {
+		b.WriteString(" AND p.category_id IN ?")
+		args = append(args, f.query.CategoryIDs)
+	}
+	if !f.withoutPrice {
+		if f.query.MinPrice != nil {
+			b.WriteString(" AND p.price >= ?")
+			args = append(args, *f.query.MinPrice)
+		}
+		if f.query.MaxPrice != nil {
+			b.WriteString(" AND p.price <= ?")
+			args = append(args, *f.query.MaxPrice)
+		}
+	}
+	if f.query.InStockOnly {
+		b.WriteString(" AND EXISTS (SELECT 1 FROM stock_levels sl WHERE sl.product_id = p.id AND sl.quantity > 0)")
+	}
+	return b.String(), args
+}
+
+// Search ranks name matches above description matches through the weights
+// set on products.search_vector.
+func (r *ProductRepositoryPostgres) Search(ctx context.Context, query domain.ProductSearchQuery) (*domain.ProductSearchResult, error) {
+	result := &domain.ProductSearchResult{
+		Hits:           []domain.ProductSearchHit{},
+		Limit:          query.Limit,
+		Offset:         query.Offset,
+		CategoryFacets: []domain.CategoryFacet{},
+		PriceFacets:    domain.PriceRangeFacets(),
+	}
+
+	tsQuery := prefixTSQuery(query.Text)
+	if tsQuery == "" {
+		return result, nil
+	}
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter := productSearchFilter{tenantID: tenant, query: query, tsQuery: tsQuery}
+	db := conn(ctx, r.db)
+
+	from, args := filter.sql()
+	if err := db.Raw("SELECT count(*)"+from, args...).Scan(&result.Total).Error; err != nil {
+		return nil, err
+	}
+
+	var rows []productSearchRow
+	err = db.Raw(
+		"SELECT p.*, ts_rank(p.search_vector, q) AS rank"+from+" ORDER BY rank DESC, p.id LIMIT ? OFFSET ?",
+		append(args, query.Limit, query.Offset)...,
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		result.Hits = append(result.Hits, domain.ProductSearchHit{Product: &rows[i].Product, Rank: rows[i].Rank})
+	}
+
+	filter.withoutCategory = true
+	from, args = filter.sql()
+	var categoryRows []categoryFacetRow
+	err = db.Raw(
+		"SELECT coalesce(p.category_id, 0) AS category_id, coalesce(c.name, '') AS category_name, count(*) AS count"+
+			strings.Replace(from, " WHERE", " LEFT JOIN categories c ON c.tenant_id = p.tenant_id AND c.id = p.category_id WHERE", 1)+
+			" GROUP BY p.category_id, c.name ORDER BY count DESC, c.name",
+		args...,
+	).Scan(&categoryRows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range categoryRows {
+		result.CategoryFacets = append(result.CategoryFacets, domain.CategoryFacet(row))
+	}
+
+	filter.withoutCategory, filter.withoutPrice = false, true
+	from, args = filter.sql()
+	var priceRows []priceFacetRow
+	err = db.Raw(
+		"SELECT width_bucket(p.price, ?::numeric[]) AS bucket, count(*) AS count"+from+" GROUP BY bucket",
+		append([]any{numericArray(domain.PriceFacetBounds)}, args...)...,
+	).Scan(&priceRows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range priceRows {
+		if row.Bucket >= 0 && row.Bucket < len(result.PriceFacets) {
+			result.PriceFacets[row.Bucket].Count = row.Count
+		}
+	}
+
+	return result, nil
+}
+
+// prefixTSQuery turns free text into a tsquery that requires every word and
+// lets the last characters of each one be missing, e.g. "red sho" becomes
+// "red:* & sho:*". It returns "" when text holds no searchable word.
+func prefixTSQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = w + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// numericArray formats bounds as a Postgres array literal.
+func numericArray(bounds []float64) string {
+	parts := make([]string, len(bounds))
+	for i, b := range bounds {
+		parts[i] = strconv.FormatFloat(b, 'f', -1, 64)
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
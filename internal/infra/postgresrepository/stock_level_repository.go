@@ -0,0 +1,81 @@
+package postgresrepository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockLevelRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewStockLevelRepositoryPostgres(db *gorm.DB) *StockLevelRepositoryPostgres {
+	return &StockLevelRepositoryPostgres{
+		db: db,
+	}
+}
+
+func (r *StockLevelRepositoryPostgres) Create(ctx context.Context, stockLevel *domain.StockLevel) error {
+	stockLevel.UpdatedAt = time.Now()
+	return conn(ctx, r.db).Create(stockLevel).Error
+}
+
+func (r *StockLevelRepositoryPostgres) GetByProductID(ctx context.Context, productID int) (*domain.StockLevel, error) {
+	var stockLevel domain.StockLevel
+	result := conn(ctx, r.db).Where("product_id = ?", productID).First(&stockLevel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrStockLevelNotFound
+		}
+		return nil, result.Error
+	}
+	return &stockLevel, nil
+}
+
+// LockByProductID creates the missing stock level of a product, with a
+// quantity of 0, and locks the row until the transaction ends, so that
+// concurrent movements of the product apply one after the other.
+func (r *StockLevelRepositoryPostgres) LockByProductID(ctx context.Context, productID int) (*domain.StockLevel, error) {
+	db := conn(ctx, r.db)
+	missing := &domain.StockLevel{ProductID: productID, UpdatedAt: time.Now()}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(missing).Error; err != nil {
+		return nil, err
+	}
+
+	var stockLevel domain.StockLevel
+	result := db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("product_id = ?", productID).
+		First(&stockLevel)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &stockLevel, nil
+}
+
+func (r *StockLevelRepositoryPostgres) ListAll(ctx context.Context) ([]*domain.StockLevel, error) {
+	var stockLevels []*domain.StockLevel
+	result := conn(ctx, r.db).Order("product_id").Find(&stockLevels)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return stockLevels, nil
+}
+
+func (r *StockLevelRepositoryPostgres) Stream(ctx context.Context, fn func(*domain.StockLevel) error) error {
+	return stream(conn(ctx, r.db).Model(&domain.StockLevel{}).Order("product_id"), fn)
+}
+
+func (r *StockLevelRepositoryPostgres) UpdateQuantity(ctx context.Context, stockLevel *domain.StockLevel) error {
+	stockLevel.UpdatedAt = time.Now()
+	return conn(ctx, r.db).Model(&domain.StockLevel{}).
+		Where("product_id = ?", stockLevel.ProductID).
+		Updates(map[string]any{
+			"quantity":   stockLevel.Quantity,
+			"updated_at": stockLevel.UpdatedAt,
+		}).Error
+}
@@ -0,0 +1,60 @@
+package postgresrepository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"gorm.io/gorm"
+)
+
+var stockMovementListSpec = listSpec[*domain.StockMovement]{
+	sortColumns: map[string]sortColumn{
+		"createdAt": timeSortColumn("created_at"),
+	},
+	cursorValue: func(m *domain.StockMovement, _ string) (string, int) {
+		return m.CreatedAt.Format(time.RFC3339Nano), m.ID
+	},
+}
+
+type StockMovementRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewStockMovementRepositoryPostgres(db *gorm.DB) *StockMovementRepositoryPostgres {
+	return &StockMovementRepositoryPostgres{
+		db: db,
+	}
+}
+
+func (r *StockMovementRepositoryPostgres) Create(ctx context.Context, movement *domain.StockMovement) error {
+	movement.CreatedAt = time.Now()
+	tx := conn(ctx, r.db)
+	if movement.UserID == "" {
+		// user_id is a nullable UUID column; an empty string is not a valid UUID.
+		tx = tx.Omit("UserID")
+	}
+	return tx.Create(movement).Error
+}
+
+func (r *StockMovementRepositoryPostgres) ListByProductID(ctx context.Context, productID int, query domain.ListQuery) (*domain.Page[*domain.StockMovement], error) {
+	tx := conn(ctx, r.db).Model(&domain.StockMovement{}).Where("product_id = ?", productID)
+	return paginate(tx, query, stockMovementListSpec)
+}
+
+func (r *StockMovementRepositoryPostgres) ListUntil(ctx context.Context, asOf time.Time) ([]*domain.StockMovement, error) {
+	var movements []*domain.StockMovement
+	result := conn(ctx, r.db).Where("created_at <= ?", asOf).Order("created_at, id").Find(&movements)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return movements, nil
+}
+
+func (r *StockMovementRepositoryPostgres) StreamBetween(ctx context.Context, from, to time.Time, productID int, fn func(*domain.StockMovement) error) error {
+	tx := conn(ctx, r.db).Model(&domain.StockMovement{}).Where("created_at >= ? AND created_at <= ?", from, to)
+	if productID != 0 {
+		tx = tx.Where("product_id = ?", productID)
+	}
+	return stream(tx.Order("created_at, id"), fn)
+}
@@ -0,0 +1,99 @@
+package postgresrepository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockReservationRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewStockReservationRepositoryPostgres(db *gorm.DB) *StockReservationRepositoryPostgres {
+	return &StockReservationRepositoryPostgres{
+		db: db,
+	}
+}
+
+func (r *StockReservationRepositoryPostgres) Create(ctx context.Context, reservation *domain.StockReservation) error {
+	reservation.ReservedAt = time.Now()
+	if reservation.Status == "" {
+		reservation.Status = domain.ReservationStatusActive
+	}
+	tx := conn(ctx, r.db)
+	if reservation.UserID == "" {
+		// user_id is a nullable UUID column; an empty string is not a valid UUID.
+		tx = tx.Omit("UserID")
+	}
+	return tx.Create(reservation).Error
+}
+
+func (r *StockReservationRepositoryPostgres) GetByID(ctx context.Context, id int) (*domain.StockReservation, error) {
+	var reservation domain.StockReservation
+	result := conn(ctx, r.db).Where("id = ?", id).First(&reservation)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrReservationNotFound
+		}
+		return nil, result.Error
+	}
+	return &reservation, nil
+}
+
+func (r *StockReservationRepositoryPostgres) ListActiveByProduct(ctx context.Context, productID int) ([]*domain.StockReservation, error) {
+	return r.listActive(conn(ctx, r.db).Where("product_id = ?", productID))
+}
+
+func (r *StockReservationRepositoryPostgres) ListActive(ctx context.Context) ([]*domain.StockReservation, error) {
+	return r.listActive(conn(ctx, r.db))
+}
+
+func (r *StockReservationRepositoryPostgres) listActive(tx *gorm.DB) ([]*domain.StockReservation, error) {
+	var reservations []*domain.StockReservation
+	result := tx.Where("status = ?", domain.ReservationStatusActive).
+		Order("reserved_at, id").
+		Find(&reservations)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return reservations, nil
+}
+
+// ExpireDue runs outside of any tenant: the expiry worker serves them all.
+func (r *StockReservationRepositoryPostgres) ExpireDue(ctx context.Context, now time.Time) ([]*domain.StockReservation, error) {
+	var reservations []*domain.StockReservation
+	err := acrossTenants(conn(ctx, r.db)).Raw(`
+		UPDATE stock_reservations SET status = ?
+		WHERE status = ? AND expires_at <= ?
+		RETURNING *`,
+		domain.ReservationStatusExpired, domain.ReservationStatusActive, now,
+	).Scan(&reservations).Error
+	if err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// Release checks and changes the status in one statement, so that only one
+// of concurrent releases succeeds.
+func (r *StockReservationRepositoryPostgres) Release(ctx context.Context, id int) (*domain.StockReservation, error) {
+	var reservation domain.StockReservation
+	result := conn(ctx, r.db).Model(&reservation).Clauses(clause.Returning{}).
+		Where("id = ? AND status = ?", id, domain.ReservationStatusActive).
+		Update("status", domain.ReservationStatusReleased)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, domain.ErrReservationNotActive
+	}
+	return &reservation, nil
+}
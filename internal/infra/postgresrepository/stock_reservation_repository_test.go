@@ -0,0 +1,29 @@
+package postgresrepository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseOnlyUpdatesAnActiveReservation(t *testing.T) {
+	db, recorder := recordingDB(t)
+	ctx := tenant.WithID(context.Background(), "seller-a")
+
+	// The recorder returns no rows, as if the reservation did not exist.
+	reservation, err := NewStockReservationRepositoryPostgres(db).Release(ctx, 5)
+
+	assert.ErrorIs(t, err, domain.ErrReservationNotFound)
+	assert.Nil(t, reservation)
+	statements := recorder.take()
+	require.Len(t, statements, 2, "a release that changed nothing looks the reservation up")
+	assert.Contains(t, statements[0].query, `UPDATE "stock_reservations" SET "status"=`)
+	assert.Contains(t, statements[0].query, "status = $")
+	assert.Contains(t, statements[0].query, "RETURNING *")
+	assert.Contains(t, statements[0].args, domain.ReservationStatusActive)
+	assert.Contains(t, statements[1].query, `SELECT * FROM "stock_reservations"`)
+}
@@ -0,0 +1,26 @@
+package postgresrepository
+
+import "gorm.io/gorm"
+
+// stream runs the query of tx, which must carry its model, and calls fn with
+// every row. Rows are read from the connection as fn consumes them, so a
+// result of millions of rows is never held in memory; the connection stays
+// busy until the stream ends.
+func stream[T any](tx *gorm.DB, fn func(*T) error) error {
+	rows, err := tx.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := new(T)
+		if err := tx.ScanRows(rows, item); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
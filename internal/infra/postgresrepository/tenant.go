@@ -0,0 +1,111 @@
+package postgresrepository
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/tenant"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const acrossTenantsKey = "tenant:across_tenants"
+
+// TenantPlugin confines GORM operations on models with a TenantID field to
+// the tenant of the statement context: created rows are stamped with it and
+// queries, updates and deletes only match its rows. Operations on such
+// models fail with tenant.ErrMissing when the context has no tenant.
+//
+// Raw SQL is not rewritten; repositories filter it with tenantID
+// themselves.
+type TenantPlugin struct{}
+
+func NewTenantPlugin() *TenantPlugin {
+	return &TenantPlugin{}
+}
+
+func (p *TenantPlugin) Name() string {
+	return "tenant"
+}
+
+func (p *TenantPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("tenant:stamp", p.stamp),
+		cb.Query().Before("gorm:query").Register("tenant:scope_query", p.scope),
+		cb.Update().Before("gorm:update").Register("tenant:scope_update", p.scope),
+		cb.Delete().Before("gorm:delete").Register("tenant:scope_delete", p.scope),
+		cb.Row().Before("gorm:row").Register("tenant:scope_row", p.scope),
+	)
+}
+
+// tenantField returns the TenantID field of the statement model, or nil when
+// the model is not tenant owned or the statement opted out with
+// acrossTenants.
+func tenantField(db *gorm.DB) *schema.Field {
+	if db.Statement.Schema == nil {
+		return nil
+	}
+	if across, _ := db.Get(acrossTenantsKey); across == true {
+		return nil
+	}
+	return db.Statement.Schema.LookUpField("TenantID")
+}
+
+func (p *TenantPlugin) stamp(db *gorm.DB) {
+	field := tenantField(db)
+	if field == nil {
+		return
+	}
+	id, err := tenantID(db.Statement.Context)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+
+	// Rows are always created in the current tenant, whatever TenantID they
+	// were given.
+	ctx, rv := db.Statement.Context, db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			_ = db.AddError(field.Set(ctx, reflect.Indirect(rv.Index(i)), id))
+		}
+	case reflect.Struct:
+		_ = db.AddError(field.Set(ctx, rv, id))
+	}
+}
+
+func (p *TenantPlugin) scope(db *gorm.DB) {
+	field := tenantField(db)
+	if field == nil {
+		return
+	}
+	id, err := tenantID(db.Statement.Context)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: id},
+	}})
+}
+
+// acrossTenants lets tx reach the rows of every tenant. It is only meant for
+// lookups that run before the tenant of a request is known, such as finding
+// the API key that authenticates it, and for background workers serving
+// every tenant.
+func acrossTenants(tx *gorm.DB) *gorm.DB {
+	return tx.Set(acrossTenantsKey, true)
+}
+
+// tenantID returns the tenant of ctx, for queries written in raw SQL.
+func tenantID(ctx context.Context) (string, error) {
+	id, ok := tenant.FromContext(ctx)
+	if !ok {
+		return "", tenant.ErrMissing
+	}
+	return id, nil
+}
@@ -0,0 +1,292 @@
+package postgresrepository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// recordedStatement is a statement sent to the database.
+type recordedStatement struct {
+	query string
+	args  []any
+}
+
+// recordingConnector is a database/sql driver that records the statements
+// it receives and answers queries with no rows and writes with one affected
+// row, or with execErr when set.
+type recordingConnector struct {
+	mu         sync.Mutex
+	statements []recordedStatement
+	commits    int
+	rollbacks  int
+	execErr    error
+}
+
+func (r *recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return recordingConn{r}, nil
+}
+
+func (r *recordingConnector) Driver() driver.Driver {
+	return nil
+}
+
+func (r *recordingConnector) record(query string, args []driver.NamedValue) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	r.statements = append(r.statements, recordedStatement{query: query, args: values})
+}
+
+func (r *recordingConnector) end(count *int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*count++
+	return nil
+}
+
+// take returns the statements recorded since the last call.
+func (r *recordingConnector) take() []recordedStatement {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	statements := r.statements
+	r.statements = nil
+	return statements
+}
+
+type recordingConn struct {
+	r *recordingConnector
+}
+
+func (c recordingConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c recordingConn) Close() error                        { return nil }
+func (c recordingConn) Begin() (driver.Tx, error)           { return c, nil }
+func (c recordingConn) Commit() error                       { return c.r.end(&c.r.commits) }
+func (c recordingConn) Rollback() error                     { return c.r.end(&c.r.rollbacks) }
+
+func (c recordingConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c recordingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.r.record(query, args)
+	return emptyRows{}, nil
+}
+
+func (c recordingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.r.record(query, args)
+	if c.r.execErr != nil {
+		return nil, c.r.execErr
+	}
+	return driver.RowsAffected(1), nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string         { return nil }
+func (emptyRows) Close() error              { return nil }
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
+
+func recordingDB(t *testing.T) (*gorm.DB, *recordingConnector) {
+	t.Helper()
+	recorder := &recordingConnector{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(recorder)}), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(NewTenantPlugin()))
+	return db, recorder
+}
+
+func TestRepositoriesStayInTheirTenant(t *testing.T) {
+	db, recorder := recordingDB(t)
+	categories := NewCategoryRepositoryPostgres(db)
+	products := NewProductRepositoryPostgres(db)
+	stockLevels := NewStockLevelRepositoryPostgres(db)
+	movements := NewStockMovementRepositoryPostgres(db)
+	costLayers := NewCostLayerRepositoryPostgres(db)
+	reservations := NewStockReservationRepositoryPostgres(db)
+	apiKeys := NewAPIKeyRepositoryPostgres(db)
+	importJobs := NewImportJobRepositoryPostgres(db)
+	query := domain.ListQuery{Limit: 20, SortBy: "createdAt", Order: domain.SortAsc}
+
+	operations := []struct {
+		name string
+		run  func(ctx context.Context) error
+	}{
+		{"categories.Create", func(ctx context.Context) error { return categories.Create(ctx, &domain.Category{Name: "Shoes"}) }},
+		{"categories.GetByID", func(ctx context.Context) error { _, err := categories.GetByID(ctx, 1); return err }},
+		{"categories.GetDeletedByID", func(ctx context.Context) error { _, err := categories.GetDeletedByID(ctx, 1); return err }},
+		{"categories.ListAll", func(ctx context.Context) error { _, err := categories.ListAll(ctx, true); return err }},
+		{"categories.List", func(ctx context.Context) error { _, err := categories.List(ctx, query); return err }},
+		{"categories.ListDescendants", func(ctx context.Context) error { _, err := categories.ListDescendants(ctx, 1, true); return err }},
+		{"categories.Update", func(ctx context.Context) error { return categories.Update(ctx, &domain.Category{ID: 1, Name: "Boots"}) }},
+		{"categories.ReassignChildren", func(ctx context.Context) error { return categories.ReassignChildren(ctx, 1, 2) }},
+		{"categories.Delete", func(ctx context.Context) error { return categories.Delete(ctx, 1) }},
+		{"categories.SoftDelete", func(ctx context.Context) error { return categories.SoftDelete(ctx, 1) }},
+		{"categories.Restore", func(ctx context.Context) error { return categories.Restore(ctx, 1) }},
+		{"products.Create", func(ctx context.Context) error {
+			return products.Create(ctx, &domain.Product{Name: "Boot", CategoryID: 1})
+		}},
+		{"products.GetByID", func(ctx context.Context) error { _, err := products.GetByID(ctx, 1); return err }},
+		{"products.GetDeletedByID", func(ctx context.Context) error { _, err := products.GetDeletedByID(ctx, 1); return err }},
+		{"products.ListAll", func(ctx context.Context) error { _, err := products.ListAll(ctx, false); return err }},
+		{"products.ListByCategoryIDs", func(ctx context.Context) error {
+			_, err := products.ListByCategoryIDs(ctx, []int{1, 2}, false)
+			return err
+		}},
+		{"products.List", func(ctx context.Context) error { _, err := products.List(ctx, []int{1}, query); return err }},
+		{"products.Search", func(ctx context.Context) error {
+			_, err := products.Search(ctx, domain.ProductSearchQuery{Text: "boot", Limit: 20})
+			return err
+		}},
+		{"products.Stream", func(ctx context.Context) error {
+			return products.Stream(ctx, true, func(*domain.Product) error { return nil })
+		}},
+		{"products.Update", func(ctx context.Context) error { return products.Update(ctx, &domain.Product{ID: 1, Name: "Boot"}) }},
+		{"products.ReassignCategory", func(ctx context.Context) error { return products.ReassignCategory(ctx, 1, 2) }},
+		{"products.Delete", func(ctx context.Context) error { return products.Delete(ctx, 1) }},
+		{"products.Restore", func(ctx context.Context) error { return products.Restore(ctx, 1) }},
+		{"stockLevels.Create", func(ctx context.Context) error { return stockLevels.Create(ctx, &domain.StockLevel{ProductID: 1}) }},
+		{"stockLevels.GetByProductID", func(ctx context.Context) error { _, err := stockLevels.GetByProductID(ctx, 1); return err }},
+		{"stockLevels.LockByProductID", func(ctx context.Context) error {
+			_, err := stockLevels.LockByProductID(ctx, 1)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}},
+		{"stockLevels.ListAll", func(ctx context.Context) error { _, err := stockLevels.ListAll(ctx); return err }},
+		{"stockLevels.Stream", func(ctx context.Context) error {
+			return stockLevels.Stream(ctx, func(*domain.StockLevel) error { return nil })
+		}},
+		{"stockLevels.UpdateQuantity", func(ctx context.Context) error {
+			return stockLevels.UpdateQuantity(ctx, &domain.StockLevel{ProductID: 1, Quantity: 3})
+		}},
+		{"movements.Create", func(ctx context.Context) error {
+			return movements.Create(ctx, &domain.StockMovement{ProductID: 1, MovementType: domain.MovementTypeIssue, Quantity: 1})
+		}},
+		{"movements.ListByProductID", func(ctx context.Context) error { _, err := movements.ListByProductID(ctx, 1, query); return err }},
+		{"movements.ListUntil", func(ctx context.Context) error { _, err := movements.ListUntil(ctx, time.Now()); return err }},
+		{"movements.StreamBetween", func(ctx context.Context) error {
+			return movements.StreamBetween(ctx, time.Now().Add(-time.Hour), time.Now(), 1, func(*domain.StockMovement) error { return nil })
+		}},
+		{"costLayers.Create", func(ctx context.Context) error {
+			return costLayers.Create(ctx, &domain.CostLayer{ProductID: 1, Quantity: 1})
+		}},
+		{"costLayers.ListOpenByProductID", func(ctx context.Context) error { _, err := costLayers.ListOpenByProductID(ctx, 1); return err }},
+		{"costLayers.ListOpening", func(ctx context.Context) error { _, err := costLayers.ListOpening(ctx); return err }},
+		{"costLayers.UpdateRemainingQty", func(ctx context.Context) error { return costLayers.UpdateRemainingQty(ctx, 1, 0) }},
+		{"reservations.Create", func(ctx context.Context) error {
+			return reservations.Create(ctx, &domain.StockReservation{ProductID: 1, ReservedQty: 2})
+		}},
+		{"reservations.GetByID", func(ctx context.Context) error { _, err := reservations.GetByID(ctx, 1); return err }},
+		{"reservations.ListActiveByProduct", func(ctx context.Context) error {
+			_, err := reservations.ListActiveByProduct(ctx, 1)
+			return err
+		}},
+		{"reservations.ListActive", func(ctx context.Context) error { _, err := reservations.ListActive(ctx); return err }},
+		{"reservations.Release", func(ctx context.Context) error {
+			_, err := reservations.Release(ctx, 1)
+			if errors.Is(err, domain.ErrReservationNotFound) {
+				return nil
+			}
+			return err
+		}},
+		{"apiKeys.Create", func(ctx context.Context) error { return apiKeys.Create(ctx, &domain.APIKey{Name: "orders"}) }},
+		{"apiKeys.GetByID", func(ctx context.Context) error { _, err := apiKeys.GetByID(ctx, "key-1"); return err }},
+		{"apiKeys.List", func(ctx context.Context) error { _, err := apiKeys.List(ctx); return err }},
+		{"apiKeys.Revoke", func(ctx context.Context) error { return apiKeys.Revoke(ctx, "key-1", time.Now()) }},
+		{"apiKeys.UpdateSecret", func(ctx context.Context) error { return apiKeys.UpdateSecret(ctx, "key-1", "abc", "hash", time.Now()) }},
+		{"importJobs.Create", func(ctx context.Context) error {
+			return importJobs.Create(ctx, &domain.ImportJob{Format: domain.ImportFormatCSV, Payload: []byte("name")})
+		}},
+		{"importJobs.GetByID", func(ctx context.Context) error { _, err := importJobs.GetByID(ctx, "job-1"); return err }},
+		{"importJobs.UpdateProgress", func(ctx context.Context) error { return importJobs.UpdateProgress(ctx, "job-1", 100) }},
+		{"importJobs.Finish", func(ctx context.Context) error {
+			return importJobs.Finish(ctx, &domain.ImportJob{ID: "job-1", Status: domain.ImportStatusSucceeded})
+		}},
+	}
+
+	ctx := tenant.WithID(context.Background(), "seller-a")
+	for _, op := range operations {
+		t.Run(op.name, func(t *testing.T) {
+			err := op.run(ctx)
+			assert.NotErrorIs(t, err, tenant.ErrMissing)
+
+			statements := recorder.take()
+			require.NotEmpty(t, statements)
+			for _, s := range statements {
+				assert.Contains(t, s.query, "tenant_id", s.query)
+				assert.Contains(t, s.args, "seller-a", s.query)
+			}
+		})
+	}
+
+	for _, op := range operations {
+		t.Run(op.name+" without tenant", func(t *testing.T) {
+			err := op.run(context.Background())
+			assert.ErrorIs(t, err, tenant.ErrMissing)
+			assert.Empty(t, recorder.take(), "nothing may reach the database without a tenant")
+		})
+	}
+}
+
+func TestCreateStampsTheContextTenant(t *testing.T) {
+	db, _ := recordingDB(t)
+	ctx := tenant.WithID(context.Background(), "seller-a")
+
+	category := &domain.Category{Name: "Shoes", TenantID: "seller-b"}
+	require.NoError(t, NewCategoryRepositoryPostgres(db).Create(ctx, category))
+	assert.Equal(t, "seller-a", category.TenantID)
+
+	key := &domain.APIKey{Name: "orders"}
+	require.NoError(t, NewAPIKeyRepositoryPostgres(db).Create(ctx, key))
+	assert.Equal(t, "seller-a", key.TenantID)
+}
+
+func TestAPIKeyAuthenticationLooksUpEveryTenant(t *testing.T) {
+	db, recorder := recordingDB(t)
+	apiKeys := NewAPIKeyRepositoryPostgres(db)
+	ctx := context.Background()
+
+	_, err := apiKeys.GetByPrefix(ctx, "0a1b2c3d4e5f")
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+	require.NoError(t, apiKeys.TouchLastUsed(ctx, "key-1", time.Now(), time.Minute))
+
+	statements := recorder.take()
+	require.Len(t, statements, 2)
+	for _, s := range statements {
+		assert.NotContains(t, s.query, "tenant_id", s.query)
+	}
+}
+
+func TestImportWorkerQueriesEveryTenant(t *testing.T) {
+	db, recorder := recordingDB(t)
+	importJobs := NewImportJobRepositoryPostgres(db)
+	ctx := context.Background()
+
+	job, err := importJobs.ClaimNext(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, job)
+	_, err = importJobs.FailStale(ctx, time.Now().Add(-time.Hour), "timed out")
+	require.NoError(t, err)
+
+	statements := recorder.take()
+	require.Len(t, statements, 2)
+	assert.Contains(t, statements[0].query, "FOR UPDATE SKIP LOCKED")
+	for _, s := range statements {
+		assert.NotContains(t, s.query, "tenant_id", s.query)
+	}
+}
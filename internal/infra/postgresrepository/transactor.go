@@ -0,0 +1,37 @@
+package postgresrepository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type TransactorPostgres struct {
+	db *gorm.DB
+}
+
+func NewTransactorPostgres(db *gorm.DB) *TransactorPostgres {
+	return &TransactorPostgres{
+		db: db,
+	}
+}
+
+func (t *TransactorPostgres) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn returns the transaction ctx runs in, or db outside of one, bound to
+// ctx.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,66 @@
+package postgresrepository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithinTransaction(t *testing.T) {
+	ctx := tenant.WithID(context.Background(), "seller-a")
+
+	t.Run("commits", func(t *testing.T) {
+		db, recorder := recordingDB(t)
+		transactor := NewTransactorPostgres(db)
+		costLayers := NewCostLayerRepositoryPostgres(db)
+
+		err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				return costLayers.UpdateRemainingQty(ctx, 1, 0)
+			})
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, recorder.take(), 1)
+		assert.Equal(t, 1, recorder.commits, "nested units of work join the outer one")
+		assert.Zero(t, recorder.rollbacks)
+	})
+
+	t.Run("rolls back on error", func(t *testing.T) {
+		db, recorder := recordingDB(t)
+		transactor := NewTransactorPostgres(db)
+		costLayers := NewCostLayerRepositoryPostgres(db)
+		failure := errors.New("insufficient stock")
+
+		err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := costLayers.UpdateRemainingQty(ctx, 1, 0); err != nil {
+				return err
+			}
+			return failure
+		})
+
+		assert.ErrorIs(t, err, failure)
+		assert.Zero(t, recorder.commits)
+		assert.Equal(t, 1, recorder.rollbacks)
+	})
+}
+
+func TestMovementReadsLockTheirRows(t *testing.T) {
+	db, recorder := recordingDB(t)
+	ctx := tenant.WithID(context.Background(), "seller-a")
+
+	_, _ = NewStockLevelRepositoryPostgres(db).LockByProductID(ctx, 1)
+	_, err := NewCostLayerRepositoryPostgres(db).ListOpenByProductID(ctx, 1)
+	require.NoError(t, err)
+
+	statements := recorder.take()
+	require.Len(t, statements, 3)
+	assert.Contains(t, statements[0].query, `INSERT INTO "stock_levels"`)
+	assert.Contains(t, statements[0].query, "ON CONFLICT DO NOTHING")
+	assert.Contains(t, statements[1].query, "FOR UPDATE")
+	assert.Contains(t, statements[2].query, "FOR UPDATE")
+}
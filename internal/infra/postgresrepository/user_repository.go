@@ -0,0 +1,62 @@
+package postgresrepository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UserRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewUserRepositoryPostgres(db *gorm.DB) *UserRepositoryPostgres {
+	return &UserRepositoryPostgres{
+		db: db,
+	}
+}
+
+func (r *UserRepositoryPostgres) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	var user domain.User
+	result := conn(ctx, r.db).Where("id = ?", id).First(&user)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
+func (r *UserRepositoryPostgres) GetBySubject(ctx context.Context, subject string) (*domain.User, error) {
+	var user domain.User
+	result := conn(ctx, r.db).Where("subject = ?", subject).First(&user)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, result.Error
+	}
+	return &user, nil
+}
+
+// CreateIfMissing relies on the unique index on subject, so concurrent first
+// requests of the same user provision a single row.
+func (r *UserRepositoryPostgres) CreateIfMissing(ctx context.Context, user *domain.User) (*domain.User, error) {
+	now := time.Now()
+	user.ID = uuid.NewString()
+	user.CreatedAt = now
+	user.UpdatedAt = now
+	err := conn(ctx, r.db).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "subject"}}, DoNothing: true}).
+		Create(user).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.GetBySubject(ctx, user.Subject)
+}
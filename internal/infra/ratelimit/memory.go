@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the memory store drops buckets that have
+// refilled, which are indistinguishable from missing ones.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	fullAt time.Time
+}
+
+// MemoryStore keeps buckets in process. Each instance of the service limits
+// on its own, so the effective limit grows with the number of replicas.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Capacity()), last: now}
+		s.buckets[key] = b
+	}
+	tokens := refill(b.tokens, b.last, now, limit)
+	res := result(tokens, float64(limit.Capacity()), float64(limit.interval()))
+	if res.Allowed {
+		tokens--
+	}
+	b.tokens, b.last, b.fullAt = tokens, now, now.Add(res.ResetAfter)
+	return res, nil
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if !now.Before(b.fullAt) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,97 @@
+// Package ratelimit implements token bucket rate limiting over pluggable
+// stores: an in-memory one for a single instance and a Redis one shared by
+// every instance.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+)
+
+// Limit is a token bucket: it holds up to Burst requests and refills at
+// Requests per Window. A zero Burst means Requests.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+	Burst    int
+}
+
+// Capacity is the number of requests a full bucket allows at once.
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Requests
+}
+
+// interval is the time it takes to refill one token.
+func (l Limit) interval() time.Duration {
+	return l.Window / time.Duration(l.Requests)
+}
+
+// Result is the outcome of taking a token.
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is the wait until the next token, zero when allowed.
+	RetryAfter time.Duration
+	// ResetAfter is the wait until the bucket is full again.
+	ResetAfter time.Duration
+}
+
+// Store keeps the buckets. Take must be atomic for a given key.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+// Rules picks the limit of a route. Routes are gin patterns prefixed with
+// the method, such as "POST /stock/movements"; routes without their own
+// limit share the Default bucket.
+type Rules struct {
+	Default Limit
+	Routes  map[string]Limit
+}
+
+// For returns the bucket name and limit of a request.
+func (r Rules) For(method, route string) (string, Limit) {
+	name := method + " " + route
+	if limit, ok := r.Routes[name]; ok {
+		return name, limit
+	}
+	return "default", r.Default
+}
+
+// ParseRoute checks a route of Rules and normalizes its method.
+func ParseRoute(route string) (string, bool) {
+	method, path, ok := strings.Cut(strings.TrimSpace(route), " ")
+	path = strings.TrimSpace(path)
+	if !ok || method == "" || !strings.HasPrefix(path, "/") {
+		return "", false
+	}
+	return strings.ToUpper(method) + " " + path, true
+}
+
+// refill returns the tokens of a bucket that held tokens at last.
+func refill(tokens float64, last, now time.Time, limit Limit) float64 {
+	if elapsed := now.Sub(last); elapsed > 0 {
+		tokens += float64(elapsed) / float64(limit.interval())
+	}
+	return math.Min(float64(limit.Capacity()), tokens)
+}
+
+// result describes taking a token from a bucket holding tokens before the
+// request.
+func result(tokens, capacity, interval float64) Result {
+	var res Result
+	if tokens >= 1 {
+		res.Allowed = true
+		tokens--
+	} else {
+		res.RetryAfter = time.Duration((1 - tokens) * interval)
+	}
+	res.Remaining = int(tokens)
+	res.ResetAfter = time.Duration((capacity - tokens) * interval)
+	return res
+}
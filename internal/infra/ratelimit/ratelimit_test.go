@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stores(t *testing.T) map[string]Store {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return map[string]Store{
+		"memory": NewMemoryStore(),
+		"redis":  NewRedisStore(client),
+	}
+}
+
+func TestStoreTake(t *testing.T) {
+	limit := Limit{Requests: 2, Window: time.Second, Burst: 3}
+	start := time.Date(2025, 3, 14, 9, 0, 0, 0, time.UTC)
+
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			take := func(key string, at time.Duration) Result {
+				t.Helper()
+				res, err := store.Take(ctx, key, limit, start.Add(at))
+				require.NoError(t, err)
+				return res
+			}
+
+			for i := range 3 {
+				res := take("client-a", 0)
+				assert.True(t, res.Allowed, "burst request %d", i)
+				assert.Equal(t, 2-i, res.Remaining)
+			}
+
+			res := take("client-a", 0)
+			assert.False(t, res.Allowed, "the burst is used up")
+			assert.Equal(t, 0, res.Remaining)
+			assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+			assert.Equal(t, 1500*time.Millisecond, res.ResetAfter)
+
+			assert.True(t, take("client-b", 0).Allowed, "clients have their own buckets")
+
+			res = take("client-a", 500*time.Millisecond)
+			assert.True(t, res.Allowed, "a token refills every half second")
+			assert.False(t, take("client-a", 600*time.Millisecond).Allowed)
+
+			res = take("client-a", 10*time.Second)
+			assert.True(t, res.Allowed)
+			assert.Equal(t, 2, res.Remaining, "refills stop at the burst")
+		})
+	}
+}
+
+func TestMemoryStoreSweepsFullBuckets(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 1, Window: time.Second}
+	start := time.Now()
+
+	_, err := store.Take(context.Background(), "client-a", limit, start)
+	require.NoError(t, err)
+	_, err = store.Take(context.Background(), "client-b", limit, start.Add(sweepInterval))
+	require.NoError(t, err)
+
+	assert.NotContains(t, store.buckets, "client-a")
+	assert.Contains(t, store.buckets, "client-b")
+}
+
+func TestRules(t *testing.T) {
+	reserve := Limit{Requests: 5, Window: time.Second}
+	rules := Rules{Default: Limit{Requests: 50, Window: time.Second}, Routes: map[string]Limit{"POST /stock/movements": reserve}}
+
+	name, limit := rules.For("POST", "/stock/movements")
+	assert.Equal(t, "POST /stock/movements", name)
+	assert.Equal(t, reserve, limit)
+
+	name, limit = rules.For("GET", "/stock/movements/:productId")
+	assert.Equal(t, "default", name)
+	assert.Equal(t, rules.Default, limit)
+
+	route, ok := ParseRoute(" post  /stock/movements")
+	assert.True(t, ok)
+	assert.Equal(t, "POST /stock/movements", route)
+	_, ok = ParseRoute("/stock/movements")
+	assert.False(t, ok)
+}
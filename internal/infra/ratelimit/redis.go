@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript refills and reads the bucket in KEYS[1] atomically and takes a
+// token when there is one. Times are in microseconds. It returns the token
+// count before the request as a string, since Redis truncates Lua numbers.
+var takeScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = capacity
+  ts = now
+end
+if now > ts then
+  tokens = math.min(capacity, tokens + (now - ts) / interval)
+  ts = now
+end
+
+local before = tokens
+if tokens >= 1 then
+  tokens = tokens - 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(ts))
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) * interval / 1000) + 1000)
+return tostring(before)
+`)
+
+// RedisStore keeps buckets in Redis, or any server speaking its protocol
+// and running Lua scripts, so that every instance shares the same limits.
+// Instances pass their own clock, so keep them in sync.
+type RedisStore struct {
+	client redis.Scripter
+}
+
+func NewRedisStore(client redis.Scripter) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	interval := limit.interval().Microseconds()
+	if interval < 1 {
+		interval = 1
+	}
+	raw, err := takeScript.Run(ctx, s.client, []string{key}, limit.Capacity(), interval, now.UnixMicro()).Text()
+	if err != nil {
+		return Result{}, fmt.Errorf("taking rate limit token: %w", err)
+	}
+	tokens, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("parsing rate limit tokens %q: %w", raw, err)
+	}
+	return result(tokens, float64(limit.Capacity()), float64(time.Duration(interval)*time.Microsecond)), nil
+}
@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// KafkaHeader is a Kafka record header. It has the same shape as the header
+// types of the common Go Kafka clients, so producers and consumers can
+// convert their headers with a plain loop.
+type KafkaHeader struct {
+	Key   string
+	Value []byte
+}
+
+// KafkaHeaderCarrier adapts a list of Kafka headers to the OpenTelemetry
+// propagators. Set replaces an existing header with the same key.
+type KafkaHeaderCarrier struct {
+	Headers *[]KafkaHeader
+}
+
+var _ propagation.TextMapCarrier = KafkaHeaderCarrier{}
+
+func (c KafkaHeaderCarrier) Get(key string) string {
+	for _, h := range *c.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func (c KafkaHeaderCarrier) Set(key, value string) {
+	for i, h := range *c.Headers {
+		if h.Key == key {
+			(*c.Headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c.Headers = append(*c.Headers, KafkaHeader{Key: key, Value: []byte(value)})
+}
+
+func (c KafkaHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.Headers))
+	for _, h := range *c.Headers {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
+
+// InjectKafkaHeaders writes the trace context of ctx (traceparent,
+// tracestate and baggage) into the headers of an outgoing message.
+func InjectKafkaHeaders(ctx context.Context, headers *[]KafkaHeader) {
+	otel.GetTextMapPropagator().Inject(ctx, KafkaHeaderCarrier{Headers: headers})
+}
+
+// ExtractKafkaHeaders returns ctx carrying the trace context found in the
+// headers of an incoming message, so consumer spans continue the producer's
+// trace.
+func ExtractKafkaHeaders(ctx context.Context, headers []KafkaHeader) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, KafkaHeaderCarrier{Headers: &headers})
+}
@@ -0,0 +1,37 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestKafkaHeadersPropagateTraceContext(t *testing.T) {
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	provider := sdktrace.NewTracerProvider()
+	ctx, span := provider.Tracer("test").Start(context.Background(), "publish")
+	defer span.End()
+
+	headers := []KafkaHeader{
+		{Key: "event-type", Value: []byte("stock.reserved")},
+		{Key: "traceparent", Value: []byte("stale")},
+	}
+	InjectKafkaHeaders(ctx, &headers)
+
+	require.Len(t, headers, 2, "an existing traceparent header is replaced")
+	assert.Equal(t, "stock.reserved", string(headers[0].Value))
+
+	consumed := trace.SpanContextFromContext(ExtractKafkaHeaders(context.Background(), headers))
+	assert.True(t, consumed.IsRemote())
+	assert.Equal(t, span.SpanContext().TraceID(), consumed.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), consumed.SpanID())
+}
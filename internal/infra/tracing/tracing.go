@@ -0,0 +1,94 @@
+// Package tracing sets up OpenTelemetry tracing: the tracer provider and its
+// exporter, W3C trace context propagation, GORM query spans and trace context
+// propagation through Kafka message headers.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+const (
+	ExporterAuto   = "auto"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// Options configures the tracer provider.
+type Options struct {
+	ServiceName string
+	// Exporter is otlp, stdout or auto. Auto exports over OTLP when an
+	// endpoint is set and falls back to stdout otherwise, which suits local
+	// runs without a collector.
+	Exporter string
+	// Endpoint is the host:port of the OTLP/HTTP collector.
+	Endpoint string
+	Insecure bool
+	// SampleRatio is the fraction of new traces that are recorded. Requests
+	// arriving with a sampled traceparent are always recorded.
+	SampleRatio float64
+	// Stdout receives the spans of the stdout exporter.
+	Stdout io.Writer
+}
+
+// Setup installs a global tracer provider and the W3C trace context and
+// baggage propagators. The returned function flushes pending spans and must
+// be called on shutdown.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	exporter, err := newExporter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(opts.ServiceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, opts Options) (sdktrace.SpanExporter, error) {
+	kind := opts.Exporter
+	if kind == ExporterAuto {
+		kind = ExporterStdout
+		if opts.Endpoint != "" {
+			kind = ExporterOTLP
+		}
+	}
+
+	switch kind {
+	case ExporterOTLP:
+		clientOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(opts.Endpoint)}
+		if opts.Insecure {
+			clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, clientOpts...)
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(opts.Stdout))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", opts.Exporter)
+	}
+}
@@ -0,0 +1,25 @@
+// Package logging carries a request-scoped zap logger in context.Context so
+// every layer logs with the same correlation fields.
+package logging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type contextKey struct{}
+
+// WithLogger returns a copy of ctx carrying logger.
+func WithLogger(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger stored in ctx, or the global zap logger
+// when there is none, e.g. in background jobs and tests.
+func FromContext(ctx context.Context) *zap.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return zap.L()
+}
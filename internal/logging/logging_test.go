@@ -0,0 +1,22 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestFromContext(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	logger := zap.New(core).With(zap.String("requestId", "abc"))
+
+	ctx := WithLogger(context.Background(), logger)
+	FromContext(ctx).Info("hello")
+
+	assert.Equal(t, 1, logs.Len())
+	assert.Equal(t, "abc", logs.All()[0].ContextMap()["requestId"])
+	assert.Same(t, zap.L(), FromContext(context.Background()), "falls back to the global logger")
+}
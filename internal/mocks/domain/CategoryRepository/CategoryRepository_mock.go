@@ -5,6 +5,8 @@
 package categoryRepositoryMock
 
 import (
+	"context"
+
 	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -37,16 +39,16 @@ func (_m *MockCategoryRepository) EXPECT() *MockCategoryRepository_Expecter {
 }
 
 // Create provides a mock function for the type MockCategoryRepository
-func (_mock *MockCategoryRepository) Create(category *domain.Category) error {
-	ret := _mock.Called(category)
+func (_mock *MockCategoryRepository) Create(ctx context.Context, category *domain.Category) error {
+	ret := _mock.Called(ctx, category)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*domain.Category) error); ok {
-		r0 = returnFunc(category)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Category) error); ok {
+		r0 = returnFunc(ctx, category)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -59,19 +61,25 @@ type MockCategoryRepository_Create_Call struct {
 }
 
 // Create is a helper method to define mock.On call
+//   - ctx context.Context
 //   - category *domain.Category
-func (_e *MockCategoryRepository_Expecter) Create(category interface{}) *MockCategoryRepository_Create_Call {
-	return &MockCategoryRepository_Create_Call{Call: _e.mock.On("Create", category)}
+func (_e *MockCategoryRepository_Expecter) Create(ctx interface{}, category interface{}) *MockCategoryRepository_Create_Call {
+	return &MockCategoryRepository_Create_Call{Call: _e.mock.On("Create", ctx, category)}
 }
 
-func (_c *MockCategoryRepository_Create_Call) Run(run func(category *domain.Category)) *MockCategoryRepository_Create_Call {
+func (_c *MockCategoryRepository_Create_Call) Run(run func(ctx context.Context, category *domain.Category)) *MockCategoryRepository_Create_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *domain.Category
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*domain.Category)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Category
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Category)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -82,22 +90,22 @@ func (_c *MockCategoryRepository_Create_Call) Return(err error) *MockCategoryRep
 	return _c
 }
 
-func (_c *MockCategoryRepository_Create_Call) RunAndReturn(run func(category *domain.Category) error) *MockCategoryRepository_Create_Call {
+func (_c *MockCategoryRepository_Create_Call) RunAndReturn(run func(ctx context.Context, category *domain.Category) error) *MockCategoryRepository_Create_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Delete provides a mock function for the type MockCategoryRepository
-func (_mock *MockCategoryRepository) Delete(id int) error {
-	ret := _mock.Called(id)
+func (_mock *MockCategoryRepository) Delete(ctx context.Context, id int) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(int) error); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -110,19 +118,25 @@ type MockCategoryRepository_Delete_Call struct {
 }
 
 // Delete is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id int
-func (_e *MockCategoryRepository_Expecter) Delete(id interface{}) *MockCategoryRepository_Delete_Call {
-	return &MockCategoryRepository_Delete_Call{Call: _e.mock.On("Delete", id)}
+func (_e *MockCategoryRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockCategoryRepository_Delete_Call {
+	return &MockCategoryRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
 }
 
-func (_c *MockCategoryRepository_Delete_Call) Run(run func(id int)) *MockCategoryRepository_Delete_Call {
+func (_c *MockCategoryRepository_Delete_Call) Run(run func(ctx context.Context, id int)) *MockCategoryRepository_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(int)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -133,14 +147,14 @@ func (_c *MockCategoryRepository_Delete_Call) Return(err error) *MockCategoryRep
 	return _c
 }
 
-func (_c *MockCategoryRepository_Delete_Call) RunAndReturn(run func(id int) error) *MockCategoryRepository_Delete_Call {
+func (_c *MockCategoryRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id int) error) *MockCategoryRepository_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetByID provides a mock function for the type MockCategoryRepository
-func (_mock *MockCategoryRepository) GetByID(id int) (*domain.Category, error) {
-	ret := _mock.Called(id)
+func (_mock *MockCategoryRepository) GetByID(ctx context.Context, id int) (*domain.Category, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetByID")
@@ -148,18 +162,18 @@ func (_mock *MockCategoryRepository) GetByID(id int) (*domain.Category, error) {
 
 	var r0 *domain.Category
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int) (*domain.Category, error)); ok {
-		return returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*domain.Category, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int) *domain.Category); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *domain.Category); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.Category)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
-		r1 = returnFunc(id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -172,19 +186,25 @@ type MockCategoryRepository_GetByID_Call struct {
 }
 
 // GetByID is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id int
-func (_e *MockCategoryRepository_Expecter) GetByID(id interface{}) *MockCategoryRepository_GetByID_Call {
-	return &MockCategoryRepository_GetByID_Call{Call: _e.mock.On("GetByID", id)}
+func (_e *MockCategoryRepository_Expecter) GetByID(ctx interface{}, id interface{}) *MockCategoryRepository_GetByID_Call {
+	return &MockCategoryRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
 }
 
-func (_c *MockCategoryRepository_GetByID_Call) Run(run func(id int)) *MockCategoryRepository_GetByID_Call {
+func (_c *MockCategoryRepository_GetByID_Call) Run(run func(ctx context.Context, id int)) *MockCategoryRepository_GetByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(int)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -195,14 +215,150 @@ func (_c *MockCategoryRepository_GetByID_Call) Return(category *domain.Category,
 	return _c
 }
 
-func (_c *MockCategoryRepository_GetByID_Call) RunAndReturn(run func(id int) (*domain.Category, error)) *MockCategoryRepository_GetByID_Call {
+func (_c *MockCategoryRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, id int) (*domain.Category, error)) *MockCategoryRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedByID provides a mock function for the type MockCategoryRepository
+func (_mock *MockCategoryRepository) GetDeletedByID(ctx context.Context, id int) (*domain.Category, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedByID")
+	}
+
+	var r0 *domain.Category
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*domain.Category, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *domain.Category); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Category)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCategoryRepository_GetDeletedByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedByID'
+type MockCategoryRepository_GetDeletedByID_Call struct {
+	*mock.Call
+}
+
+// GetDeletedByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockCategoryRepository_Expecter) GetDeletedByID(ctx interface{}, id interface{}) *MockCategoryRepository_GetDeletedByID_Call {
+	return &MockCategoryRepository_GetDeletedByID_Call{Call: _e.mock.On("GetDeletedByID", ctx, id)}
+}
+
+func (_c *MockCategoryRepository_GetDeletedByID_Call) Run(run func(ctx context.Context, id int)) *MockCategoryRepository_GetDeletedByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepository_GetDeletedByID_Call) Return(category *domain.Category, err error) *MockCategoryRepository_GetDeletedByID_Call {
+	_c.Call.Return(category, err)
+	return _c
+}
+
+func (_c *MockCategoryRepository_GetDeletedByID_Call) RunAndReturn(run func(ctx context.Context, id int) (*domain.Category, error)) *MockCategoryRepository_GetDeletedByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockCategoryRepository
+func (_mock *MockCategoryRepository) List(ctx context.Context, query domain.ListQuery) (*domain.Page[*domain.Category], error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *domain.Page[*domain.Category]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListQuery) (*domain.Page[*domain.Category], error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ListQuery) *domain.Page[*domain.Category]); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Page[*domain.Category])
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ListQuery) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCategoryRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockCategoryRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query domain.ListQuery
+func (_e *MockCategoryRepository_Expecter) List(ctx interface{}, query interface{}) *MockCategoryRepository_List_Call {
+	return &MockCategoryRepository_List_Call{Call: _e.mock.On("List", ctx, query)}
+}
+
+func (_c *MockCategoryRepository_List_Call) Run(run func(ctx context.Context, query domain.ListQuery)) *MockCategoryRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.ListQuery
+		if args[1] != nil {
+			arg1 = args[1].(domain.ListQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepository_List_Call) Return(page *domain.Page[*domain.Category], err error) *MockCategoryRepository_List_Call {
+	_c.Call.Return(page, err)
+	return _c
+}
+
+func (_c *MockCategoryRepository_List_Call) RunAndReturn(run func(ctx context.Context, query domain.ListQuery) (*domain.Page[*domain.Category], error)) *MockCategoryRepository_List_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // ListAll provides a mock function for the type MockCategoryRepository
-func (_mock *MockCategoryRepository) ListAll() ([]*domain.Category, error) {
-	ret := _mock.Called()
+func (_mock *MockCategoryRepository) ListAll(ctx context.Context, includeDeleted bool) ([]*domain.Category, error) {
+	ret := _mock.Called(ctx, includeDeleted)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListAll")
@@ -210,18 +366,18 @@ func (_mock *MockCategoryRepository) ListAll() ([]*domain.Category, error) {
 
 	var r0 []*domain.Category
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() ([]*domain.Category, error)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bool) ([]*domain.Category, error)); ok {
+		return returnFunc(ctx, includeDeleted)
 	}
-	if returnFunc, ok := ret.Get(0).(func() []*domain.Category); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bool) []*domain.Category); ok {
+		r0 = returnFunc(ctx, includeDeleted)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.Category)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = returnFunc(ctx, includeDeleted)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -234,13 +390,26 @@ type MockCategoryRepository_ListAll_Call struct {
 }
 
 // ListAll is a helper method to define mock.On call
-func (_e *MockCategoryRepository_Expecter) ListAll() *MockCategoryRepository_ListAll_Call {
-	return &MockCategoryRepository_ListAll_Call{Call: _e.mock.On("ListAll")}
+//   - ctx context.Context
+//   - includeDeleted bool
+func (_e *MockCategoryRepository_Expecter) ListAll(ctx interface{}, includeDeleted interface{}) *MockCategoryRepository_ListAll_Call {
+	return &MockCategoryRepository_ListAll_Call{Call: _e.mock.On("ListAll", ctx, includeDeleted)}
 }
 
-func (_c *MockCategoryRepository_ListAll_Call) Run(run func()) *MockCategoryRepository_ListAll_Call {
+func (_c *MockCategoryRepository_ListAll_Call) Run(run func(ctx context.Context, includeDeleted bool)) *MockCategoryRepository_ListAll_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
 	})
 	return _c
 }
@@ -250,22 +419,273 @@ func (_c *MockCategoryRepository_ListAll_Call) Return(categorys []*domain.Catego
 	return _c
 }
 
-func (_c *MockCategoryRepository_ListAll_Call) RunAndReturn(run func() ([]*domain.Category, error)) *MockCategoryRepository_ListAll_Call {
+func (_c *MockCategoryRepository_ListAll_Call) RunAndReturn(run func(ctx context.Context, includeDeleted bool) ([]*domain.Category, error)) *MockCategoryRepository_ListAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDescendants provides a mock function for the type MockCategoryRepository
+func (_mock *MockCategoryRepository) ListDescendants(ctx context.Context, id int, includeDeleted bool) ([]*domain.Category, error) {
+	ret := _mock.Called(ctx, id, includeDeleted)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDescendants")
+	}
+
+	var r0 []*domain.Category
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, bool) ([]*domain.Category, error)); ok {
+		return returnFunc(ctx, id, includeDeleted)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, bool) []*domain.Category); ok {
+		r0 = returnFunc(ctx, id, includeDeleted)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Category)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, bool) error); ok {
+		r1 = returnFunc(ctx, id, includeDeleted)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCategoryRepository_ListDescendants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDescendants'
+type MockCategoryRepository_ListDescendants_Call struct {
+	*mock.Call
+}
+
+// ListDescendants is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - includeDeleted bool
+func (_e *MockCategoryRepository_Expecter) ListDescendants(ctx interface{}, id interface{}, includeDeleted interface{}) *MockCategoryRepository_ListDescendants_Call {
+	return &MockCategoryRepository_ListDescendants_Call{Call: _e.mock.On("ListDescendants", ctx, id, includeDeleted)}
+}
+
+func (_c *MockCategoryRepository_ListDescendants_Call) Run(run func(ctx context.Context, id int, includeDeleted bool)) *MockCategoryRepository_ListDescendants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepository_ListDescendants_Call) Return(categorys []*domain.Category, err error) *MockCategoryRepository_ListDescendants_Call {
+	_c.Call.Return(categorys, err)
+	return _c
+}
+
+func (_c *MockCategoryRepository_ListDescendants_Call) RunAndReturn(run func(ctx context.Context, id int, includeDeleted bool) ([]*domain.Category, error)) *MockCategoryRepository_ListDescendants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReassignChildren provides a mock function for the type MockCategoryRepository
+func (_mock *MockCategoryRepository) ReassignChildren(ctx context.Context, fromParentID int, toParentID int) error {
+	ret := _mock.Called(ctx, fromParentID, toParentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignChildren")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = returnFunc(ctx, fromParentID, toParentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCategoryRepository_ReassignChildren_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReassignChildren'
+type MockCategoryRepository_ReassignChildren_Call struct {
+	*mock.Call
+}
+
+// ReassignChildren is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromParentID int
+//   - toParentID int
+func (_e *MockCategoryRepository_Expecter) ReassignChildren(ctx interface{}, fromParentID interface{}, toParentID interface{}) *MockCategoryRepository_ReassignChildren_Call {
+	return &MockCategoryRepository_ReassignChildren_Call{Call: _e.mock.On("ReassignChildren", ctx, fromParentID, toParentID)}
+}
+
+func (_c *MockCategoryRepository_ReassignChildren_Call) Run(run func(ctx context.Context, fromParentID int, toParentID int)) *MockCategoryRepository_ReassignChildren_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepository_ReassignChildren_Call) Return(err error) *MockCategoryRepository_ReassignChildren_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCategoryRepository_ReassignChildren_Call) RunAndReturn(run func(ctx context.Context, fromParentID int, toParentID int) error) *MockCategoryRepository_ReassignChildren_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockCategoryRepository
+func (_mock *MockCategoryRepository) Restore(ctx context.Context, id int) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCategoryRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockCategoryRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockCategoryRepository_Expecter) Restore(ctx interface{}, id interface{}) *MockCategoryRepository_Restore_Call {
+	return &MockCategoryRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *MockCategoryRepository_Restore_Call) Run(run func(ctx context.Context, id int)) *MockCategoryRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepository_Restore_Call) Return(err error) *MockCategoryRepository_Restore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCategoryRepository_Restore_Call) RunAndReturn(run func(ctx context.Context, id int) error) *MockCategoryRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SoftDelete provides a mock function for the type MockCategoryRepository
+func (_mock *MockCategoryRepository) SoftDelete(ctx context.Context, id int) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SoftDelete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCategoryRepository_SoftDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SoftDelete'
+type MockCategoryRepository_SoftDelete_Call struct {
+	*mock.Call
+}
+
+// SoftDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockCategoryRepository_Expecter) SoftDelete(ctx interface{}, id interface{}) *MockCategoryRepository_SoftDelete_Call {
+	return &MockCategoryRepository_SoftDelete_Call{Call: _e.mock.On("SoftDelete", ctx, id)}
+}
+
+func (_c *MockCategoryRepository_SoftDelete_Call) Run(run func(ctx context.Context, id int)) *MockCategoryRepository_SoftDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCategoryRepository_SoftDelete_Call) Return(err error) *MockCategoryRepository_SoftDelete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCategoryRepository_SoftDelete_Call) RunAndReturn(run func(ctx context.Context, id int) error) *MockCategoryRepository_SoftDelete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Update provides a mock function for the type MockCategoryRepository
-func (_mock *MockCategoryRepository) Update(category *domain.Category) error {
-	ret := _mock.Called(category)
+func (_mock *MockCategoryRepository) Update(ctx context.Context, category *domain.Category) error {
+	ret := _mock.Called(ctx, category)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*domain.Category) error); ok {
-		r0 = returnFunc(category)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Category) error); ok {
+		r0 = returnFunc(ctx, category)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -278,19 +698,25 @@ type MockCategoryRepository_Update_Call struct {
 }
 
 // Update is a helper method to define mock.On call
+//   - ctx context.Context
 //   - category *domain.Category
-func (_e *MockCategoryRepository_Expecter) Update(category interface{}) *MockCategoryRepository_Update_Call {
-	return &MockCategoryRepository_Update_Call{Call: _e.mock.On("Update", category)}
+func (_e *MockCategoryRepository_Expecter) Update(ctx interface{}, category interface{}) *MockCategoryRepository_Update_Call {
+	return &MockCategoryRepository_Update_Call{Call: _e.mock.On("Update", ctx, category)}
 }
 
-func (_c *MockCategoryRepository_Update_Call) Run(run func(category *domain.Category)) *MockCategoryRepository_Update_Call {
+func (_c *MockCategoryRepository_Update_Call) Run(run func(ctx context.Context, category *domain.Category)) *MockCategoryRepository_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *domain.Category
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*domain.Category)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.Category
+		if args[1] != nil {
+			arg1 = args[1].(*domain.Category)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -301,7 +727,7 @@ func (_c *MockCategoryRepository_Update_Call) Return(err error) *MockCategoryRep
 	return _c
 }
 
-func (_c *MockCategoryRepository_Update_Call) RunAndReturn(run func(category *domain.Category) error) *MockCategoryRepository_Update_Call {
+func (_c *MockCategoryRepository_Update_Call) RunAndReturn(run func(ctx context.Context, category *domain.Category) error) *MockCategoryRepository_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
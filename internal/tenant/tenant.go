@@ -0,0 +1,37 @@
+// Package tenant carries the seller a request acts for in context.Context.
+// Every category, product and stock row belongs to one tenant, and the
+// repositories only see the rows of the tenant in their context.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+var (
+	ErrMissing = errors.New("no tenant in context")
+	ErrInvalid = errors.New("invalid tenant ID")
+)
+
+// validID matches tenant IDs: lowercase letters, digits, '-' and '_', up to
+// the 64 characters of the tenant_id columns.
+var validID = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Valid reports whether id is a well-formed tenant ID.
+func Valid(id string) bool {
+	return validID.MatchString(id)
+}
+
+type contextKey struct{}
+
+// WithID returns a copy of ctx acting for tenant id.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the tenant of ctx, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(contextKey{}).(string)
+	return id, ok && id != ""
+}
@@ -0,0 +1,30 @@
+package tenant
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValid(t *testing.T) {
+	for _, id := range []string{"default", "seller-42", "acme_store", "7"} {
+		assert.True(t, Valid(id), id)
+	}
+	for _, id := range []string{"", "Acme", "-seller", "seller 42", "seller/42", strings.Repeat("a", 65)} {
+		assert.False(t, Valid(id), id)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	_, ok = FromContext(WithID(context.Background(), ""))
+	assert.False(t, ok)
+
+	id, ok := FromContext(WithID(context.Background(), "seller-42"))
+	assert.True(t, ok)
+	assert.Equal(t, "seller-42", id)
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"go.uber.org/zap"
+)
+
+// apiKeyUsageInterval is how stale the recorded last use of a key may get,
+// which bounds the writes made by busy keys.
+const apiKeyUsageInterval = time.Minute
+
+type APIKeyUsecase struct {
+	repo domain.APIKeyRepository
+}
+
+func NewAPIKeyUsecase(repo domain.APIKeyRepository) *APIKeyUsecase {
+	return &APIKeyUsecase{repo: repo}
+}
+
+// CreateAPIKey issues a new key and returns it with its plaintext, which is
+// not stored.
+func (u *APIKeyUsecase) CreateAPIKey(ctx context.Context, dto dtos.CreateAPIKeyDTO) (_ *domain.APIKey, _ string, err error) {
+	ctx, span := startSpan(ctx, "APIKeyUsecase.CreateAPIKey")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermAPIKeysManage); err != nil {
+		return nil, "", err
+	}
+	if dto.Name == "" {
+		return nil, "", domain.ErrInvalidAPIKeyName
+	}
+	if len(dto.Scopes) == 0 {
+		return nil, "", domain.ErrInvalidAPIKeyScope
+	}
+	for _, scope := range dto.Scopes {
+		if !auth.ValidScope(scope) {
+			return nil, "", domain.ErrInvalidAPIKeyScope
+		}
+	}
+
+	plaintext, prefix, secretHash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+	scopes := slices.Clone(dto.Scopes)
+	slices.Sort(scopes)
+	key := &domain.APIKey{
+		Name:       dto.Name,
+		Prefix:     prefix,
+		SecretHash: secretHash,
+		Scopes:     slices.Compact(scopes),
+		CreatedBy:  auth.UserID(ctx),
+	}
+	if err := u.repo.Create(ctx, key); err != nil {
+		return nil, "", err
+	}
+
+	logging.FromContext(ctx).Info(
+		"API key created",
+		zap.String("apiKeyID", key.ID),
+		zap.String("name", key.Name),
+		zap.Strings("scopes", key.Scopes),
+	)
+	return key, plaintext, nil
+}
+
+func (u *APIKeyUsecase) ListAPIKeys(ctx context.Context) (_ []*domain.APIKey, err error) {
+	ctx, span := startSpan(ctx, "APIKeyUsecase.ListAPIKeys")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermAPIKeysManage); err != nil {
+		return nil, err
+	}
+	return u.repo.List(ctx)
+}
+
+// RevokeAPIKey disables a key for good. Revoking a revoked key is a no-op.
+func (u *APIKeyUsecase) RevokeAPIKey(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "APIKeyUsecase.RevokeAPIKey")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermAPIKeysManage); err != nil {
+		return err
+	}
+	key, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if key.Revoked() {
+		return nil
+	}
+	if err := u.repo.Revoke(ctx, id, time.Now()); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info("API key revoked", zap.String("apiKeyID", id))
+	return nil
+}
+
+// RotateAPIKey replaces the secret of a key, keeping its ID and scopes. The
+// previous secret stops working immediately.
+func (u *APIKeyUsecase) RotateAPIKey(ctx context.Context, id string) (_ *domain.APIKey, _ string, err error) {
+	ctx, span := startSpan(ctx, "APIKeyUsecase.RotateAPIKey")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermAPIKeysManage); err != nil {
+		return nil, "", err
+	}
+	key, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if key.Revoked() {
+		return nil, "", domain.ErrAPIKeyRevoked
+	}
+
+	plaintext, prefix, secretHash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	if err := u.repo.UpdateSecret(ctx, id, prefix, secretHash, now); err != nil {
+		return nil, "", err
+	}
+	key.Prefix, key.SecretHash, key.RotatedAt, key.UpdatedAt = prefix, secretHash, &now, now
+
+	logging.FromContext(ctx).Info("API key rotated", zap.String("apiKeyID", id))
+	return key, plaintext, nil
+}
+
+// AuthenticateAPIKey returns the active key matching plaintext and records
+// its use. Unknown, revoked and malformed keys all fail with
+// auth.ErrInvalidAPIKey.
+func (u *APIKeyUsecase) AuthenticateAPIKey(ctx context.Context, plaintext string) (_ *domain.APIKey, err error) {
+	ctx, span := startSpan(ctx, "APIKeyUsecase.AuthenticateAPIKey")
+	defer func() { endSpan(span, err) }()
+
+	prefix, secret, err := auth.ParseAPIKey(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	key, err := u.repo.GetByPrefix(ctx, prefix)
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return nil, auth.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !auth.SecretMatches(secret, key.SecretHash) || key.Revoked() {
+		return nil, auth.ErrInvalidAPIKey
+	}
+
+	// A failed usage update must not fail the request it belongs to.
+	if err := u.repo.TouchLastUsed(ctx, key.ID, time.Now(), apiKeyUsageInterval); err != nil {
+		logging.FromContext(ctx).Warn("Recording API key usage failed", zap.String("apiKeyID", key.ID), zap.Error(err))
+	}
+	return key, nil
+}
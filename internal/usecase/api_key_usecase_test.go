@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	apiKeyRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/APIKeyRepository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		role        string
+		dto         dtos.CreateAPIKeyDTO
+		mockSetup   func(repo *apiKeyRepositoryMock.MockAPIKeyRepository)
+		expectedErr error
+	}{
+		{
+			name: "admin creates a key",
+			role: auth.RoleAdmin,
+			dto:  dtos.CreateAPIKeyDTO{Name: "orders", Scopes: []string{"stock:reserve", "catalog:read", "stock:reserve"}},
+			mockSetup: func(repo *apiKeyRepositoryMock.MockAPIKeyRepository) {
+				repo.On("Create", mock.Anything, mock.MatchedBy(func(k *domain.APIKey) bool {
+					return k.Name == "orders" && k.CreatedBy == testUserID &&
+						assert.ObjectsAreEqual([]string{"catalog:read", "stock:reserve"}, k.Scopes)
+				})).Return(nil)
+			},
+		},
+		{
+			name:        "unknown scope",
+			role:        auth.RoleAdmin,
+			dto:         dtos.CreateAPIKeyDTO{Name: "orders", Scopes: []string{"stock:adjust"}},
+			expectedErr: domain.ErrInvalidAPIKeyScope,
+		},
+		{
+			name:        "managers cannot manage keys",
+			role:        auth.RoleManager,
+			dto:         dtos.CreateAPIKeyDTO{Name: "orders", Scopes: []string{"catalog:read"}},
+			expectedErr: auth.ErrForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := apiKeyRepositoryMock.NewMockAPIKeyRepository(t)
+			if tc.mockSetup != nil {
+				tc.mockSetup(repo)
+			}
+
+			key, plaintext, err := NewAPIKeyUsecase(repo).CreateAPIKey(principalContext(tc.role), tc.dto)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, key)
+				return
+			}
+			require.NoError(t, err)
+			prefix, secret, err := auth.ParseAPIKey(plaintext)
+			require.NoError(t, err)
+			assert.Equal(t, key.Prefix, prefix)
+			assert.True(t, auth.SecretMatches(secret, key.SecretHash))
+		})
+	}
+}
+
+func TestRotateAPIKey(t *testing.T) {
+	repo := apiKeyRepositoryMock.NewMockAPIKeyRepository(t)
+	old := &domain.APIKey{ID: "k-1", Prefix: "0a1b2c3d4e5f", SecretHash: auth.HashAPIKeySecret("old"), Scopes: []string{"catalog:read"}}
+	repo.On("GetByID", mock.Anything, "k-1").Return(old, nil)
+	repo.On("UpdateSecret", mock.Anything, "k-1", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	key, plaintext, err := NewAPIKeyUsecase(repo).RotateAPIKey(principalContext(auth.RoleAdmin), "k-1")
+
+	require.NoError(t, err)
+	prefix, secret, err := auth.ParseAPIKey(plaintext)
+	require.NoError(t, err)
+	assert.Equal(t, prefix, key.Prefix)
+	assert.NotEqual(t, "0a1b2c3d4e5f", key.Prefix)
+	assert.True(t, auth.SecretMatches(secret, key.SecretHash))
+	assert.NotNil(t, key.RotatedAt)
+
+	revoked := apiKeyRepositoryMock.NewMockAPIKeyRepository(t)
+	revokedAt := time.Now()
+	revoked.On("GetByID", mock.Anything, "k-2").Return(&domain.APIKey{ID: "k-2", RevokedAt: &revokedAt}, nil)
+	_, _, err = NewAPIKeyUsecase(revoked).RotateAPIKey(principalContext(auth.RoleAdmin), "k-2")
+	assert.ErrorIs(t, err, domain.ErrAPIKeyRevoked)
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	repo := apiKeyRepositoryMock.NewMockAPIKeyRepository(t)
+	repo.On("GetByID", mock.Anything, "k-1").Return(&domain.APIKey{ID: "k-1"}, nil)
+	repo.On("Revoke", mock.Anything, "k-1", mock.Anything).Return(nil)
+
+	assert.NoError(t, NewAPIKeyUsecase(repo).RevokeAPIKey(principalContext(auth.RoleAdmin), "k-1"))
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	plaintext, prefix, secretHash, err := auth.GenerateAPIKey()
+	require.NoError(t, err)
+	revokedAt := time.Now()
+
+	tests := []struct {
+		name        string
+		plaintext   string
+		mockSetup   func(repo *apiKeyRepositoryMock.MockAPIKeyRepository)
+		expectedErr error
+	}{
+		{
+			name:      "valid key records its use",
+			plaintext: plaintext,
+			mockSetup: func(repo *apiKeyRepositoryMock.MockAPIKeyRepository) {
+				repo.On("GetByPrefix", mock.Anything, prefix).Return(&domain.APIKey{ID: "k-1", SecretHash: secretHash}, nil)
+				repo.On("TouchLastUsed", mock.Anything, "k-1", mock.Anything, time.Minute).Return(nil)
+			},
+		},
+		{
+			name:      "failing to record the use does not fail the request",
+			plaintext: plaintext,
+			mockSetup: func(repo *apiKeyRepositoryMock.MockAPIKeyRepository) {
+				repo.On("GetByPrefix", mock.Anything, prefix).Return(&domain.APIKey{ID: "k-1", SecretHash: secretHash}, nil)
+				repo.On("TouchLastUsed", mock.Anything, "k-1", mock.Anything, time.Minute).Return(errors.New("connection refused"))
+			},
+		},
+		{
+			name:      "wrong secret",
+			plaintext: "inv_" + prefix + "_guessed",
+			mockSetup: func(repo *apiKeyRepositoryMock.MockAPIKeyRepository) {
+				repo.On("GetByPrefix", mock.Anything, prefix).Return(&domain.APIKey{ID: "k-1", SecretHash: secretHash}, nil)
+			},
+			expectedErr: auth.ErrInvalidAPIKey,
+		},
+		{
+			name:      "revoked key",
+			plaintext: plaintext,
+			mockSetup: func(repo *apiKeyRepositoryMock.MockAPIKeyRepository) {
+				repo.On("GetByPrefix", mock.Anything, prefix).Return(&domain.APIKey{ID: "k-1", SecretHash: secretHash, RevokedAt: &revokedAt}, nil)
+			},
+			expectedErr: auth.ErrInvalidAPIKey,
+		},
+		{
+			name:      "unknown prefix",
+			plaintext: plaintext,
+			mockSetup: func(repo *apiKeyRepositoryMock.MockAPIKeyRepository) {
+				repo.On("GetByPrefix", mock.Anything, prefix).Return(nil, domain.ErrAPIKeyNotFound)
+			},
+			expectedErr: auth.ErrInvalidAPIKey,
+		},
+		{
+			name:        "malformed key",
+			plaintext:   "not-a-key",
+			mockSetup:   func(repo *apiKeyRepositoryMock.MockAPIKeyRepository) {},
+			expectedErr: auth.ErrInvalidAPIKey,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := apiKeyRepositoryMock.NewMockAPIKeyRepository(t)
+			tc.mockSetup(repo)
+
+			key, err := NewAPIKeyUsecase(repo).AuthenticateAPIKey(context.Background(), tc.plaintext)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, key)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "k-1", key.ID)
+		})
+	}
+}
@@ -3,56 +3,276 @@ package usecase
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
 	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"go.uber.org/zap"
 )
 
 type CategoryUsecase struct {
-	repo domain.CategoryRepository
+	repo        domain.CategoryRepository
+	productRepo domain.ProductRepository
+	transactor  domain.Transactor
 }
 
-func NewCategoryUsecase(repo domain.CategoryRepository) *CategoryUsecase {
-	return &CategoryUsecase{repo: repo}
+func NewCategoryUsecase(repo domain.CategoryRepository, productRepo domain.ProductRepository, transactor domain.Transactor) *CategoryUsecase {
+	return &CategoryUsecase{repo: repo, productRepo: productRepo, transactor: transactor}
 }
 
-func (u *CategoryUsecase) CreateCategory(ctx context.Context, dto dtos.CreateCategoryDTO) (*domain.Category, error) {
-	if dto.Name == "" {
-		return nil, domain.ErrInvalidCategoryName
+func (u *CategoryUsecase) CreateCategory(ctx context.Context, dto dtos.CreateCategoryDTO) (_ *domain.Category, err error) {
+	ctx, span := startSpan(ctx, "CategoryUsecase.CreateCategory")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermCategoriesWrite); err != nil {
+		return nil, err
+	}
+
+	if dto.ParentID != nil {
+		if err := u.ensureParentExists(ctx, *dto.ParentID); err != nil {
+			return nil, err
+		}
 	}
 
 	category := &domain.Category{
-		Name: dto.Name,
+		Name:     dto.Name,
+		ParentID: dto.ParentID,
 	}
 
-	if err := u.repo.Create(category); err != nil {
+	if err := u.repo.Create(ctx, category); err != nil {
 		return nil, err
 	}
 
 	return category, nil
 }
 
-func (u *CategoryUsecase) ListCategories(ctx context.Context) ([]*domain.Category, error) {
-	return u.repo.ListAll()
+func (u *CategoryUsecase) ListCategories(ctx context.Context, dto dtos.ListCategoriesDTO) (_ *domain.Page[*domain.Category], err error) {
+	ctx, span := startSpan(ctx, "CategoryUsecase.ListCategories")
+	defer func() { endSpan(span, err) }()
+
+	query, err := toListQuery(dto.ListQueryDTO, dto.IncludeDeleted, categorySortFields)
+	if err != nil {
+		return nil, err
+	}
+	return u.repo.List(ctx, query)
+}
+
+func (u *CategoryUsecase) GetCategoryByID(ctx context.Context, id int) (_ *domain.Category, err error) {
+	ctx, span := startSpan(ctx, "CategoryUsecase.GetCategoryByID")
+	defer func() { endSpan(span, err) }()
+
+	return u.repo.GetByID(ctx, id)
 }
 
-func (u *CategoryUsecase) GetCategoryByID(ctx context.Context, id int) (*domain.Category, error) {
-	return u.repo.GetByID(id)
+// GetCategoryTree returns every category nested under its parent.
+func (u *CategoryUsecase) GetCategoryTree(ctx context.Context) (_ []*domain.CategoryNode, err error) {
+	ctx, span := startSpan(ctx, "CategoryUsecase.GetCategoryTree")
+	defer func() { endSpan(span, err) }()
+
+	categories, err := u.repo.ListAll(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildCategoryTree(categories), nil
 }
 
-func (u *CategoryUsecase) UpdateCategory(ctx context.Context, id int, dto dtos.UpdateCategoryDTO) error {
-	if dto.Name == "" {
-		return domain.ErrInvalidCategoryName
+// ListDescendants returns all subcategories of a category at any depth.
+func (u *CategoryUsecase) ListDescendants(ctx context.Context, id int) (_ []*domain.Category, err error) {
+	ctx, span := startSpan(ctx, "CategoryUsecase.ListDescendants")
+	defer func() { endSpan(span, err) }()
+
+	if _, err := u.repo.GetByID(ctx, id); err != nil {
+		return nil, err
 	}
+	return u.repo.ListDescendants(ctx, id, false)
+}
 
-	category := &domain.Category{
-		ID:   id,
-		Name: dto.Name,
+func (u *CategoryUsecase) UpdateCategory(ctx context.Context, id int, dto dtos.UpdateCategoryDTO) (err error) {
+	ctx, span := startSpan(ctx, "CategoryUsecase.UpdateCategory")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermCategoriesWrite); err != nil {
+		return err
+	}
+
+	category, err := u.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	category.Name = dto.Name
+
+	if dto.ParentID != nil {
+		if *dto.ParentID == 0 {
+			category.ParentID = nil
+		} else {
+			if err := u.ensureNoCycle(ctx, id, *dto.ParentID); err != nil {
+				return err
+			}
+			category.ParentID = dto.ParentID
+		}
+	}
+
+	return u.repo.Update(ctx, category)
+}
+
+// DeleteCategory deletes a category according to the requested policy. See
+// domain.CategoryDeletePolicy for what each policy does with the products
+// and subcategories that reference it.
+func (u *CategoryUsecase) DeleteCategory(ctx context.Context, id int, dto dtos.DeleteCategoryDTO) (err error) {
+	ctx, span := startSpan(ctx, "CategoryUsecase.DeleteCategory")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermCategoriesDelete); err != nil {
+		return err
+	}
+
+	policy, err := domain.ParseCategoryDeletePolicy(dto.Policy)
+	if err != nil {
+		return err
+	}
+
+	if _, err := u.repo.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	// Soft-deleted subcategories still reference the category, so they
+	// block or follow its permanent deletion like active ones.
+	subcategories, err := u.repo.ListDescendants(ctx, id, policy != domain.CategoryDeleteSoft)
+	if err != nil {
+		return err
+	}
+
+	switch policy {
+	case domain.CategoryDeleteReassign:
+		return u.reassignAndDelete(ctx, id, dto.ReassignTo, subcategories)
+	case domain.CategoryDeleteSoft:
+		if len(subcategories) > 0 {
+			return &domain.CategoryInUseError{CategoryID: id, Subcategories: subcategories}
+		}
+		return u.repo.SoftDelete(ctx, id)
+	default:
+		// Soft-deleted products still hold a foreign key to the category.
+		products, err := u.productRepo.ListByCategoryIDs(ctx, []int{id}, true)
+		if err != nil {
+			return err
+		}
+		if len(products) > 0 || len(subcategories) > 0 {
+			return &domain.CategoryInUseError{CategoryID: id, Products: products, Subcategories: subcategories}
+		}
+		return u.repo.Delete(ctx, id)
+	}
+}
+
+// RestoreCategory brings back a soft-deleted category. Its parent, if any,
+// must be active.
+func (u *CategoryUsecase) RestoreCategory(ctx context.Context, id int) (_ *domain.Category, err error) {
+	ctx, span := startSpan(ctx, "CategoryUsecase.RestoreCategory")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermCategoriesWrite); err != nil {
+		return nil, err
+	}
+
+	category, err := u.repo.GetDeletedByID(ctx, id)
+	if errors.Is(err, domain.ErrCategoryNotFound) {
+		if _, activeErr := u.repo.GetByID(ctx, id); activeErr == nil {
+			return nil, domain.ErrCategoryNotDeleted
+		}
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return u.repo.Update(category)
+	if category.ParentID != nil {
+		if _, err := u.repo.GetByID(ctx, *category.ParentID); err != nil {
+			if errors.Is(err, domain.ErrCategoryNotFound) {
+				return nil, domain.ErrParentCategoryDeleted
+			}
+			return nil, err
+		}
+	}
+
+	if err := u.repo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetByID(ctx, id)
 }
 
-func (u *CategoryUsecase) DeleteCategory(ctx context.Context, id int) error {
-	return u.repo.Delete(id)
+func (u *CategoryUsecase) reassignAndDelete(ctx context.Context, id, targetID int, subcategories []*domain.Category) error {
+	if targetID == 0 {
+		return domain.ErrReassignTargetRequired
+	}
+	if targetID == id {
+		return domain.ErrInvalidReassignTarget
+	}
+	for _, s := range subcategories {
+		if s.ID == targetID {
+			return domain.ErrInvalidReassignTarget
+		}
+	}
+
+	_, err := u.repo.GetByID(ctx, targetID)
+	if errors.Is(err, domain.ErrCategoryNotFound) {
+		return domain.ErrInvalidReassignTarget
+	}
+	if err != nil {
+		return err
+	}
+
+	// The category is only deleted along with the moves that free it.
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.productRepo.ReassignCategory(ctx, id, targetID); err != nil {
+			return err
+		}
+		if err := u.repo.ReassignChildren(ctx, id, targetID); err != nil {
+			return err
+		}
+		return u.repo.Delete(ctx, id)
+	})
+	if err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info(
+		"Category references reassigned",
+		zap.Int("categoryID", id),
+		zap.Int("reassignTo", targetID),
+		zap.Int("subcategories", len(subcategories)),
+	)
+	return nil
+}
+
+func (u *CategoryUsecase) ensureParentExists(ctx context.Context, parentID int) error {
+	_, err := u.repo.GetByID(ctx, parentID)
+	if errors.Is(err, domain.ErrCategoryNotFound) {
+		return domain.ErrParentCategoryNotFound
+	}
+	return err
+}
+
+// ensureNoCycle rejects moving a category under itself or under one of its
+// own descendants.
+func (u *CategoryUsecase) ensureNoCycle(ctx context.Context, id, parentID int) error {
+	if id == parentID {
+		return domain.ErrCategoryCycle
+	}
+
+	if err := u.ensureParentExists(ctx, parentID); err != nil {
+		return err
+	}
+
+	descendants, err := u.repo.ListDescendants(ctx, id, false)
+	if err != nil {
+		return err
+	}
+	for _, d := range descendants {
+		if d.ID == parentID {
+			return domain.ErrCategoryCycle
+		}
+	}
+	return nil
 }
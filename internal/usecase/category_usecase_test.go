@@ -6,8 +6,10 @@ import (
 	"testing"
 
 	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
 	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
 	categoryRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/CategoryRepository"
+	productRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/ProductRepository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -24,24 +26,18 @@ func TestCreateCategory(t *testing.T) {
 			name: "success",
 			dto:  dtos.CreateCategoryDTO{Name: "Electronics"},
 			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
-				repo.On("Create", mock.MatchedBy(func(cat *domain.Category) bool {
+				repo.On("Create", mock.Anything, mock.MatchedBy(func(cat *domain.Category) bool {
 					return cat.Name == "Electronics"
 				})).Return(nil)
 			},
 			expectedErr:  nil,
 			expectedName: "Electronics",
 		},
-		{
-			name:        "invalid name",
-			dto:         dtos.CreateCategoryDTO{Name: ""},
-			mockSetup:   func(repo *categoryRepositoryMock.MockCategoryRepository) {},
-			expectedErr: domain.ErrInvalidCategoryName,
-		},
 		{
 			name: "repo error",
 			dto:  dtos.CreateCategoryDTO{Name: "Electronics"},
 			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
-				repo.On("Create", mock.Anything).Return(errors.New("database error"))
+				repo.On("Create", mock.Anything, mock.Anything).Return(errors.New("database error"))
 			},
 			expectedErr: errors.New("database error"),
 		},
@@ -53,14 +49,11 @@ func TestCreateCategory(t *testing.T) {
 			if tc.mockSetup != nil {
 				tc.mockSetup(repo)
 			}
-			usecase := NewCategoryUsecase(repo)
+			usecase := NewCategoryUsecase(repo, productRepositoryMock.NewMockProductRepository(t), inlineTransactor(t))
 			ctx := context.Background()
 			category, err := usecase.CreateCategory(ctx, tc.dto)
 			if tc.expectedErr != nil {
 				assert.Error(t, err)
-				if errors.Is(tc.expectedErr, domain.ErrInvalidCategoryName) {
-					assert.ErrorIs(t, err, domain.ErrInvalidCategoryName)
-				}
 				assert.Nil(t, category)
 			} else {
 				assert.NoError(t, err)
@@ -72,9 +65,78 @@ func TestCreateCategory(t *testing.T) {
 	}
 }
 
+func TestCreateCategoryWithParent(t *testing.T) {
+	tests := []struct {
+		name        string
+		dto         dtos.CreateCategoryDTO
+		mockSetup   func(repo *categoryRepositoryMock.MockCategoryRepository)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			dto:  dtos.CreateCategoryDTO{Name: "Phones", ParentID: intPtr(1)},
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1, Name: "Electronics"}, nil)
+				repo.On("Create", mock.Anything, mock.MatchedBy(func(cat *domain.Category) bool {
+					return cat.ParentID != nil && *cat.ParentID == 1
+				})).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "parent not found",
+			dto:  dtos.CreateCategoryDTO{Name: "Phones", ParentID: intPtr(9)},
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
+				repo.On("GetByID", mock.Anything, 9).Return(nil, domain.ErrCategoryNotFound)
+			},
+			expectedErr: domain.ErrParentCategoryNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := categoryRepositoryMock.NewMockCategoryRepository(t)
+			tc.mockSetup(repo)
+			usecase := NewCategoryUsecase(repo, productRepositoryMock.NewMockProductRepository(t), inlineTransactor(t))
+			category, err := usecase.CreateCategory(context.Background(), tc.dto)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, category)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, category)
+			}
+		})
+	}
+}
+
+func TestGetCategoryTree(t *testing.T) {
+	repo := categoryRepositoryMock.NewMockCategoryRepository(t)
+	repo.On("ListAll", mock.Anything, false).Return([]*domain.Category{
+		{ID: 1, Name: "Electronics"},
+		{ID: 2, Name: "Phones", ParentID: intPtr(1)},
+		{ID: 3, Name: "Books"},
+		{ID: 4, Name: "Accessories", ParentID: intPtr(1)},
+		{ID: 5, Name: "Cases", ParentID: intPtr(4)},
+	}, nil)
+
+	usecase := NewCategoryUsecase(repo, productRepositoryMock.NewMockProductRepository(t), inlineTransactor(t))
+	tree, err := usecase.GetCategoryTree(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, tree, 2)
+	assert.Equal(t, "Books", tree[0].Category.Name)
+	assert.Equal(t, "Electronics", tree[1].Category.Name)
+	assert.Len(t, tree[1].Children, 2)
+	assert.Equal(t, "Accessories", tree[1].Children[0].Category.Name)
+	assert.Equal(t, "Cases", tree[1].Children[0].Children[0].Category.Name)
+	assert.Equal(t, "Phones", tree[1].Children[1].Category.Name)
+}
+
 func TestListCategories(t *testing.T) {
 	tests := []struct {
 		name         string
+		dto          dtos.ListCategoriesDTO
 		mockSetup    func(repo *categoryRepositoryMock.MockCategoryRepository)
 		expectedErr  error
 		expectedList []*domain.Category
@@ -82,9 +144,13 @@ func TestListCategories(t *testing.T) {
 		{
 			name: "success",
 			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
-				repo.On("ListAll").Return([]*domain.Category{
-					{ID: 1, Name: "Electronics"},
-					{ID: 2, Name: "Books"},
+				repo.On("List", mock.Anything, domain.ListQuery{Limit: 20, SortBy: "name", Order: domain.SortAsc}).Return(&domain.Page[*domain.Category]{
+					Items: []*domain.Category{
+						{ID: 1, Name: "Electronics"},
+						{ID: 2, Name: "Books"},
+					},
+					Total: 2,
+					Limit: 20,
 				}, nil)
 			},
 			expectedErr: nil,
@@ -93,6 +159,56 @@ func TestListCategories(t *testing.T) {
 				{ID: 2, Name: "Books"},
 			},
 		},
+		{
+			name: "sort, search and include deleted",
+			dto: dtos.ListCategoriesDTO{
+				ListQueryDTO:   dtos.ListQueryDTO{Limit: 5, Offset: 10, Sort: "createdAt", Order: "desc", Q: "tron", Prefix: "El"},
+				IncludeDeleted: true,
+			},
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
+				repo.On("List", mock.Anything, domain.ListQuery{
+					Limit: 5, Offset: 10, SortBy: "createdAt", Order: domain.SortDesc,
+					Search: "tron", Prefix: "El", IncludeDeleted: true,
+				}).Return(&domain.Page[*domain.Category]{Items: []*domain.Category{{ID: 1, Name: "Electronics"}}}, nil)
+			},
+			expectedList: []*domain.Category{{ID: 1, Name: "Electronics"}},
+		},
+		{
+			name: "cursor replaces offset",
+			dto: dtos.ListCategoriesDTO{
+				ListQueryDTO: dtos.ListQueryDTO{Offset: 10, Cursor: domain.EncodeCursor(domain.Cursor{Value: "Books", ID: 2})},
+			},
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
+				repo.On("List", mock.Anything, mock.MatchedBy(func(q domain.ListQuery) bool {
+					return q.Offset == 0 && q.Cursor != ""
+				})).Return(&domain.Page[*domain.Category]{}, nil)
+			},
+		},
+		{
+			name:        "invalid sort field",
+			dto:         dtos.ListCategoriesDTO{ListQueryDTO: dtos.ListQueryDTO{Sort: "updatedAt"}},
+			expectedErr: domain.ErrInvalidSortField,
+		},
+		{
+			name:        "invalid order",
+			dto:         dtos.ListCategoriesDTO{ListQueryDTO: dtos.ListQueryDTO{Order: "up"}},
+			expectedErr: domain.ErrInvalidSortOrder,
+		},
+		{
+			name:        "limit too large",
+			dto:         dtos.ListCategoriesDTO{ListQueryDTO: dtos.ListQueryDTO{Limit: 500}},
+			expectedErr: domain.ErrInvalidPageLimit,
+		},
+		{
+			name:        "negative offset",
+			dto:         dtos.ListCategoriesDTO{ListQueryDTO: dtos.ListQueryDTO{Offset: -1}},
+			expectedErr: domain.ErrInvalidPageOffset,
+		},
+		{
+			name:        "malformed cursor",
+			dto:         dtos.ListCategoriesDTO{ListQueryDTO: dtos.ListQueryDTO{Cursor: "not-a-cursor"}},
+			expectedErr: domain.ErrInvalidCursor,
+		},
 	}
 
 	for _, tc := range tests {
@@ -101,15 +217,15 @@ func TestListCategories(t *testing.T) {
 			if tc.mockSetup != nil {
 				tc.mockSetup(repo)
 			}
-			usecase := NewCategoryUsecase(repo)
+			usecase := NewCategoryUsecase(repo, productRepositoryMock.NewMockProductRepository(t), inlineTransactor(t))
 			ctx := context.Background()
-			categories, err := usecase.ListCategories(ctx)
+			page, err := usecase.ListCategories(ctx, tc.dto)
 			if tc.expectedErr != nil {
-				assert.Error(t, err)
-				assert.Nil(t, categories)
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, page)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tc.expectedList, categories)
+				assert.Equal(t, tc.expectedList, page.Items)
 			}
 			repo.AssertExpectations(t)
 		})
@@ -128,7 +244,7 @@ func TestGetCategoryByID(t *testing.T) {
 			name: "success",
 			id:   1,
 			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
-				repo.On("GetByID", 1).Return(&domain.Category{ID: 1, Name: "Electronics"}, nil)
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1, Name: "Electronics"}, nil)
 			},
 			expectedErr: nil,
 			expectedCat: &domain.Category{ID: 1, Name: "Electronics"},
@@ -137,7 +253,7 @@ func TestGetCategoryByID(t *testing.T) {
 			name: "not found",
 			id:   2,
 			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
-				repo.On("GetByID", 2).Return(nil, domain.ErrCategoryNotFound)
+				repo.On("GetByID", mock.Anything, 2).Return(nil, domain.ErrCategoryNotFound)
 			},
 			expectedErr: domain.ErrCategoryNotFound,
 			expectedCat: nil,
@@ -149,7 +265,7 @@ func TestGetCategoryByID(t *testing.T) {
 			if tc.mockSetup != nil {
 				tc.mockSetup(repo)
 			}
-			usecase := NewCategoryUsecase(repo)
+			usecase := NewCategoryUsecase(repo, productRepositoryMock.NewMockProductRepository(t), inlineTransactor(t))
 			ctx := context.Background()
 			category, err := usecase.GetCategoryByID(ctx, tc.id)
 			if tc.expectedErr != nil {
@@ -178,18 +294,77 @@ func TestUpdateCategory(t *testing.T) {
 			id:   1,
 			dto:  dtos.UpdateCategoryDTO{Name: "Updated Electronics"},
 			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
-				repo.On("Update", mock.MatchedBy(func(cat *domain.Category) bool {
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1, Name: "Electronics"}, nil)
+				repo.On("Update", mock.Anything, mock.MatchedBy(func(cat *domain.Category) bool {
 					return cat.ID == 1 && cat.Name == "Updated Electronics"
 				})).Return(nil)
 			},
 			expectedErr: nil,
 		},
 		{
-			name:        "invalid name",
-			id:          1,
-			dto:         dtos.UpdateCategoryDTO{Name: ""},
-			mockSetup:   func(repo *categoryRepositoryMock.MockCategoryRepository) {},
-			expectedErr: domain.ErrInvalidCategoryName,
+			name: "not found",
+			id:   2,
+			dto:  dtos.UpdateCategoryDTO{Name: "Books"},
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
+				repo.On("GetByID", mock.Anything, 2).Return(nil, domain.ErrCategoryNotFound)
+			},
+			expectedErr: domain.ErrCategoryNotFound,
+		},
+		{
+			name: "move under another parent",
+			id:   1,
+			dto:  dtos.UpdateCategoryDTO{Name: "Phones", ParentID: intPtr(3)},
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1, Name: "Phones"}, nil)
+				repo.On("GetByID", mock.Anything, 3).Return(&domain.Category{ID: 3, Name: "Electronics"}, nil)
+				repo.On("ListDescendants", mock.Anything, 1, false).Return([]*domain.Category{{ID: 4}}, nil)
+				repo.On("Update", mock.Anything, mock.MatchedBy(func(cat *domain.Category) bool {
+					return cat.ParentID != nil && *cat.ParentID == 3
+				})).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "move to root",
+			id:   1,
+			dto:  dtos.UpdateCategoryDTO{Name: "Phones", ParentID: intPtr(0)},
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1, Name: "Phones", ParentID: intPtr(3)}, nil)
+				repo.On("Update", mock.Anything, mock.MatchedBy(func(cat *domain.Category) bool {
+					return cat.ParentID == nil
+				})).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "parent is itself",
+			id:   1,
+			dto:  dtos.UpdateCategoryDTO{Name: "Phones", ParentID: intPtr(1)},
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1, Name: "Phones"}, nil)
+			},
+			expectedErr: domain.ErrCategoryCycle,
+		},
+		{
+			name: "parent is a descendant",
+			id:   1,
+			dto:  dtos.UpdateCategoryDTO{Name: "Phones", ParentID: intPtr(4)},
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1, Name: "Phones"}, nil)
+				repo.On("GetByID", mock.Anything, 4).Return(&domain.Category{ID: 4, Name: "Smartphones", ParentID: intPtr(1)}, nil)
+				repo.On("ListDescendants", mock.Anything, 1, false).Return([]*domain.Category{{ID: 4}}, nil)
+			},
+			expectedErr: domain.ErrCategoryCycle,
+		},
+		{
+			name: "parent not found",
+			id:   1,
+			dto:  dtos.UpdateCategoryDTO{Name: "Phones", ParentID: intPtr(9)},
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1, Name: "Phones"}, nil)
+				repo.On("GetByID", mock.Anything, 9).Return(nil, domain.ErrCategoryNotFound)
+			},
+			expectedErr: domain.ErrParentCategoryNotFound,
 		},
 	}
 	for _, tc := range tests {
@@ -198,11 +373,11 @@ func TestUpdateCategory(t *testing.T) {
 			if tc.mockSetup != nil {
 				tc.mockSetup(repo)
 			}
-			usecase := NewCategoryUsecase(repo)
+			usecase := NewCategoryUsecase(repo, productRepositoryMock.NewMockProductRepository(t), inlineTransactor(t))
 			ctx := context.Background()
 			err := usecase.UpdateCategory(ctx, tc.id, tc.dto)
 			if tc.expectedErr != nil {
-				assert.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedErr)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -215,37 +390,114 @@ func TestDeleteCategory(t *testing.T) {
 	tests := []struct {
 		name        string
 		id          int
-		mockSetup   func(repo *categoryRepositoryMock.MockCategoryRepository)
+		dto         dtos.DeleteCategoryDTO
+		mockSetup   func(repo *categoryRepositoryMock.MockCategoryRepository, productRepo *productRepositoryMock.MockProductRepository)
 		expectedErr error
 	}{
 		{
 			name: "success",
 			id:   1,
-			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
-				repo.On("Delete", 1).Return(nil)
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository, productRepo *productRepositoryMock.MockProductRepository) {
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1}, nil)
+				repo.On("ListDescendants", mock.Anything, 1, true).Return([]*domain.Category{}, nil)
+				productRepo.On("ListByCategoryIDs", mock.Anything, []int{1}, true).Return([]*domain.Product{}, nil)
+				repo.On("Delete", mock.Anything, 1).Return(nil)
 			},
 			expectedErr: nil,
 		},
 		{
 			name: "not found",
 			id:   2,
-			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
-				repo.On("Delete", 2).Return(domain.ErrCategoryNotFound)
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository, productRepo *productRepositoryMock.MockProductRepository) {
+				repo.On("GetByID", mock.Anything, 2).Return(nil, domain.ErrCategoryNotFound)
 			},
 			expectedErr: domain.ErrCategoryNotFound,
 		},
+		{
+			name: "restrict with products",
+			id:   1,
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository, productRepo *productRepositoryMock.MockProductRepository) {
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1}, nil)
+				repo.On("ListDescendants", mock.Anything, 1, true).Return([]*domain.Category{}, nil)
+				productRepo.On("ListByCategoryIDs", mock.Anything, []int{1}, true).Return([]*domain.Product{{ID: 3, Name: "Cable"}}, nil)
+			},
+			expectedErr: domain.ErrCategoryInUse,
+		},
+		{
+			name: "restrict with subcategories",
+			id:   1,
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository, productRepo *productRepositoryMock.MockProductRepository) {
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1}, nil)
+				repo.On("ListDescendants", mock.Anything, 1, true).Return([]*domain.Category{{ID: 4}}, nil)
+				productRepo.On("ListByCategoryIDs", mock.Anything, []int{1}, true).Return([]*domain.Product{}, nil)
+			},
+			expectedErr: domain.ErrCategoryInUse,
+		},
+		{
+			name: "reassign",
+			id:   1,
+			dto:  dtos.DeleteCategoryDTO{Policy: "reassign", ReassignTo: 5},
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository, productRepo *productRepositoryMock.MockProductRepository) {
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1}, nil)
+				repo.On("ListDescendants", mock.Anything, 1, true).Return([]*domain.Category{{ID: 4}}, nil)
+				repo.On("GetByID", mock.Anything, 5).Return(&domain.Category{ID: 5}, nil)
+				inTx := mock.MatchedBy(inTransaction)
+				productRepo.On("ReassignCategory", inTx, 1, 5).Return(nil)
+				repo.On("ReassignChildren", inTx, 1, 5).Return(nil)
+				repo.On("Delete", inTx, 1).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "reassign without target",
+			id:   1,
+			dto:  dtos.DeleteCategoryDTO{Policy: "reassign"},
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository, productRepo *productRepositoryMock.MockProductRepository) {
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1}, nil)
+				repo.On("ListDescendants", mock.Anything, 1, true).Return([]*domain.Category{}, nil)
+			},
+			expectedErr: domain.ErrReassignTargetRequired,
+		},
+		{
+			name: "reassign into own subtree",
+			id:   1,
+			dto:  dtos.DeleteCategoryDTO{Policy: "reassign", ReassignTo: 4},
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository, productRepo *productRepositoryMock.MockProductRepository) {
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1}, nil)
+				repo.On("ListDescendants", mock.Anything, 1, true).Return([]*domain.Category{{ID: 4}}, nil)
+			},
+			expectedErr: domain.ErrInvalidReassignTarget,
+		},
+		{
+			name: "soft delete keeps products",
+			id:   1,
+			dto:  dtos.DeleteCategoryDTO{Policy: "soft"},
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository, productRepo *productRepositoryMock.MockProductRepository) {
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1}, nil)
+				repo.On("ListDescendants", mock.Anything, 1, false).Return([]*domain.Category{}, nil)
+				repo.On("SoftDelete", mock.Anything, 1).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			name:        "invalid policy",
+			id:          1,
+			dto:         dtos.DeleteCategoryDTO{Policy: "cascade"},
+			expectedErr: domain.ErrInvalidDeletePolicy,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			repo := categoryRepositoryMock.NewMockCategoryRepository(t)
+			productRepo := productRepositoryMock.NewMockProductRepository(t)
 			if tc.mockSetup != nil {
-				tc.mockSetup(repo)
+				tc.mockSetup(repo, productRepo)
 			}
-			usecase := NewCategoryUsecase(repo)
+			usecase := NewCategoryUsecase(repo, productRepo, inlineTransactor(t))
 			ctx := context.Background()
-			err := usecase.DeleteCategory(ctx, tc.id)
+			err := usecase.DeleteCategory(ctx, tc.id, tc.dto)
 			if tc.expectedErr != nil {
-				assert.Error(t, err)
+				assert.ErrorIs(t, err, tc.expectedErr)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -253,3 +505,87 @@ func TestDeleteCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteCategoryRequiresPermission(t *testing.T) {
+	repo := categoryRepositoryMock.NewMockCategoryRepository(t)
+	usecase := NewCategoryUsecase(repo, productRepositoryMock.NewMockProductRepository(t), inlineTransactor(t))
+
+	err := usecase.DeleteCategory(principalContext(auth.RoleClerk), 1, dtos.DeleteCategoryDTO{})
+
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	repo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestRestoreCategory(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          int
+		mockSetup   func(repo *categoryRepositoryMock.MockCategoryRepository)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			id:   1,
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
+				repo.On("GetDeletedByID", mock.Anything, 1).Return(&domain.Category{ID: 1, Name: "Electronics"}, nil)
+				repo.On("Restore", mock.Anything, 1).Return(nil)
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1, Name: "Electronics"}, nil)
+			},
+		},
+		{
+			name: "not deleted",
+			id:   1,
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
+				repo.On("GetDeletedByID", mock.Anything, 1).Return(nil, domain.ErrCategoryNotFound)
+				repo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1}, nil)
+			},
+			expectedErr: domain.ErrCategoryNotDeleted,
+		},
+		{
+			name: "not found",
+			id:   2,
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
+				repo.On("GetDeletedByID", mock.Anything, 2).Return(nil, domain.ErrCategoryNotFound)
+				repo.On("GetByID", mock.Anything, 2).Return(nil, domain.ErrCategoryNotFound)
+			},
+			expectedErr: domain.ErrCategoryNotFound,
+		},
+		{
+			name: "parent deleted",
+			id:   3,
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
+				repo.On("GetDeletedByID", mock.Anything, 3).Return(&domain.Category{ID: 3, ParentID: intPtr(1)}, nil)
+				repo.On("GetByID", mock.Anything, 1).Return(nil, domain.ErrCategoryNotFound)
+			},
+			expectedErr: domain.ErrParentCategoryDeleted,
+		},
+		{
+			name: "name taken by an active category",
+			id:   1,
+			mockSetup: func(repo *categoryRepositoryMock.MockCategoryRepository) {
+				repo.On("GetDeletedByID", mock.Anything, 1).Return(&domain.Category{ID: 1, Name: "Electronics"}, nil)
+				repo.On("Restore", mock.Anything, 1).Return(domain.ErrCategoryNameTaken)
+			},
+			expectedErr: domain.ErrCategoryNameTaken,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := categoryRepositoryMock.NewMockCategoryRepository(t)
+			tc.mockSetup(repo)
+			usecase := NewCategoryUsecase(repo, productRepositoryMock.NewMockProductRepository(t), inlineTransactor(t))
+			category, err := usecase.RestoreCategory(context.Background(), tc.id)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, category)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, category)
+			}
+		})
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
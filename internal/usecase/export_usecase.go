@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+// ExportUsecase streams the catalogue, the stock levels and the movement
+// ledger of a tenant for bulk exports. Records are handed to the caller as
+// they are read, so exports of any size run in constant memory.
+type ExportUsecase struct {
+	productRepo    domain.ProductRepository
+	stockLevelRepo domain.StockLevelRepository
+	movementRepo   domain.StockMovementRepository
+}
+
+func NewExportUsecase(
+	productRepo domain.ProductRepository,
+	stockLevelRepo domain.StockLevelRepository,
+	movementRepo domain.StockMovementRepository,
+) *ExportUsecase {
+	return &ExportUsecase{
+		productRepo:    productRepo,
+		stockLevelRepo: stockLevelRepo,
+		movementRepo:   movementRepo,
+	}
+}
+
+// authorizeExport checks that the caller may read reports, which bulk
+// exports are, and the exported data.
+func authorizeExport(ctx context.Context, perm auth.Permission) error {
+	if err := auth.Authorize(ctx, auth.PermReportsRead); err != nil {
+		return err
+	}
+	return auth.Authorize(ctx, perm)
+}
+
+func (u *ExportUsecase) ExportProducts(ctx context.Context, includeDeleted bool, fn func(*domain.Product) error) (err error) {
+	ctx, span := startSpan(ctx, "ExportUsecase.ExportProducts")
+	defer func() { endSpan(span, err) }()
+
+	if err := authorizeExport(ctx, auth.PermProductsRead); err != nil {
+		return err
+	}
+	return u.productRepo.Stream(ctx, includeDeleted, fn)
+}
+
+func (u *ExportUsecase) ExportStockLevels(ctx context.Context, fn func(*domain.StockLevel) error) (err error) {
+	ctx, span := startSpan(ctx, "ExportUsecase.ExportStockLevels")
+	defer func() { endSpan(span, err) }()
+
+	if err := authorizeExport(ctx, auth.PermStockRead); err != nil {
+		return err
+	}
+	return u.stockLevelRepo.Stream(ctx, fn)
+}
+
+// ExportMovements streams the movements recorded from from to to, both
+// included, of one product or, with a productID of 0, of every product.
+func (u *ExportUsecase) ExportMovements(ctx context.Context, from, to time.Time, productID int, fn func(*domain.StockMovement) error) (err error) {
+	ctx, span := startSpan(ctx, "ExportUsecase.ExportMovements")
+	defer func() { endSpan(span, err) }()
+
+	if err := authorizeExport(ctx, auth.PermStockRead); err != nil {
+		return err
+	}
+	if from.After(to) {
+		return domain.ErrInvalidExportRange
+	}
+	return u.movementRepo.StreamBetween(ctx, from, to, productID, fn)
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	productRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/ProductRepository"
+	stockLevelRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockLevelRepository"
+	stockMovementRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockMovementRepository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type exportMocks struct {
+	productRepo    *productRepositoryMock.MockProductRepository
+	stockLevelRepo *stockLevelRepositoryMock.MockStockLevelRepository
+	movementRepo   *stockMovementRepositoryMock.MockStockMovementRepository
+}
+
+func newExportMocks(t *testing.T) *exportMocks {
+	return &exportMocks{
+		productRepo:    productRepositoryMock.NewMockProductRepository(t),
+		stockLevelRepo: stockLevelRepositoryMock.NewMockStockLevelRepository(t),
+		movementRepo:   stockMovementRepositoryMock.NewMockStockMovementRepository(t),
+	}
+}
+
+func (m *exportMocks) usecase() *ExportUsecase {
+	return NewExportUsecase(m.productRepo, m.stockLevelRepo, m.movementRepo)
+}
+
+func TestExportProducts(t *testing.T) {
+	m := newExportMocks(t)
+	m.productRepo.On("Stream", mock.Anything, true, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(*domain.Product) error)
+			_ = fn(&domain.Product{ID: 1, Name: "Boot"})
+			_ = fn(&domain.Product{ID: 2, Name: "Sandal"})
+		}).
+		Return(nil)
+
+	var names []string
+	err := m.usecase().ExportProducts(principalContext(auth.RoleViewer), true, func(p *domain.Product) error {
+		names = append(names, p.Name)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Boot", "Sandal"}, names)
+}
+
+func TestExportStockLevelsPassesStreamErrors(t *testing.T) {
+	m := newExportMocks(t)
+	streamErr := errors.New("connection reset")
+	m.stockLevelRepo.On("Stream", mock.Anything, mock.Anything).Return(streamErr)
+
+	err := m.usecase().ExportStockLevels(context.Background(), func(*domain.StockLevel) error { return nil })
+
+	assert.ErrorIs(t, err, streamErr)
+}
+
+func TestExportMovements(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	t.Run("streams the range", func(t *testing.T) {
+		m := newExportMocks(t)
+		m.movementRepo.On("StreamBetween", mock.Anything, from, to, 7, mock.Anything).Return(nil)
+
+		err := m.usecase().ExportMovements(context.Background(), from, to, 7, func(*domain.StockMovement) error { return nil })
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects a reversed range", func(t *testing.T) {
+		m := newExportMocks(t)
+
+		err := m.usecase().ExportMovements(context.Background(), to, from, 0, func(*domain.StockMovement) error { return nil })
+
+		assert.ErrorIs(t, err, domain.ErrInvalidExportRange)
+	})
+}
+
+func TestExportsRequireReportsRead(t *testing.T) {
+	m := newExportMocks(t)
+	ctx := principalContext(auth.RoleService)
+
+	err := m.usecase().ExportProducts(ctx, false, func(*domain.Product) error { return nil })
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	err = m.usecase().ExportStockLevels(ctx, func(*domain.StockLevel) error { return nil })
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+	err = m.usecase().ExportMovements(ctx, time.Now(), time.Now(), 0, func(*domain.StockMovement) error { return nil })
+	assert.ErrorIs(t, err, auth.ErrForbidden)
+}
@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+// Columns of an import file. CSV headers and NDJSON keys match them
+// case-insensitively.
+const (
+	importColumnName            = "name"
+	importColumnSKU             = "sku"
+	importColumnCategory        = "category"
+	importColumnPrice           = "price"
+	importColumnOpeningQuantity = "openingQuantity"
+	importColumnUnitCost        = "unitCost"
+)
+
+var (
+	importColumns         = []string{importColumnName, importColumnSKU, importColumnCategory, importColumnPrice, importColumnOpeningQuantity, importColumnUnitCost}
+	requiredImportColumns = []string{importColumnName, importColumnSKU, importColumnCategory, importColumnPrice}
+)
+
+// parsedImport is the content of an import file.
+type parsedImport struct {
+	// rows counts the rows of the file, including those with errors.
+	rows      int
+	records   []importRecord
+	rowErrors []domain.ImportRowError
+}
+
+// importRecord is a row as found in the file, before validation.
+type importRecord struct {
+	line            int
+	name            string
+	sku             string
+	category        string
+	price           *float64
+	openingQuantity int
+	unitCost        *float64
+}
+
+// errTooManyImportRows is returned by parseImport once a file exceeds the
+// row limit.
+var errTooManyImportRows = errors.New("too many rows")
+
+// parseImport reads the rows of an import file. Rows whose values cannot be
+// read are reported as row errors; errors that make the rest of the file
+// unreadable, such as a missing column, are returned.
+func parseImport(format string, payload []byte, maxRows int) (*parsedImport, error) {
+	switch format {
+	case domain.ImportFormatCSV:
+		return parseImportCSV(payload, maxRows)
+	case domain.ImportFormatNDJSON:
+		return parseImportNDJSON(payload, maxRows)
+	}
+	return nil, domain.ErrUnsupportedImportFormat
+}
+
+func parseImportCSV(payload []byte, maxRows int) (*parsedImport, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(payload, []byte("\ufeff"))))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("the file has no header")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		column, ok := importColumn(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q, expected %s", strings.TrimSpace(name), strings.Join(importColumns, ", "))
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("duplicate column %q", column)
+		}
+		seen[column] = true
+		columns[i] = column
+	}
+	for _, column := range requiredImportColumns {
+		if !seen[column] {
+			return nil, fmt.Errorf("missing column %q", column)
+		}
+	}
+
+	parsed := &parsedImport{}
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return parsed, nil
+		}
+		// A malformed record, such as one with a stray quote, is a row error
+		// reported on the line it starts on. The reader has no field
+		// positions for it.
+		var parseErr *csv.ParseError
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) && !errors.As(err, &parseErr) {
+			return nil, err
+		}
+		if parsed.rows == maxRows {
+			return nil, errTooManyImportRows
+		}
+		parsed.rows++
+		if parseErr != nil {
+			parsed.rowErrors = append(parsed.rowErrors, domain.ImportRowError{Line: parseErr.StartLine, Message: parseErr.Err.Error()})
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			parsed.rowErrors = append(parsed.rowErrors, domain.ImportRowError{
+				Line:    line,
+				Message: fmt.Sprintf("expected %d fields, got %d", len(columns), len(fields)),
+			})
+			continue
+		}
+
+		record := importRecord{line: line}
+		var fieldErrors []domain.ImportRowError
+		for i, value := range fields {
+			value = strings.TrimSpace(value)
+			if err := record.set(columns[i], value); err != nil {
+				fieldErrors = append(fieldErrors, domain.ImportRowError{Line: line, Field: columns[i], Message: err.Error()})
+			}
+		}
+		if len(fieldErrors) > 0 {
+			parsed.rowErrors = append(parsed.rowErrors, fieldErrors...)
+			continue
+		}
+		parsed.records = append(parsed.records, record)
+	}
+}
+
+// importColumn returns the column a CSV header or NDJSON key names.
+func importColumn(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	for _, column := range importColumns {
+		if strings.EqualFold(name, column) {
+			return column, true
+		}
+	}
+	return "", false
+}
+
+// set parses the CSV value of column. Empty numbers are left unset.
+func (r *importRecord) set(column, value string) error {
+	switch column {
+	case importColumnName:
+		r.name = value
+	case importColumnSKU:
+		r.sku = value
+	case importColumnCategory:
+		r.category = value
+	case importColumnPrice, importColumnUnitCost:
+		if value == "" {
+			return nil
+		}
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		if column == importColumnPrice {
+			r.price = &number
+		} else {
+			r.unitCost = &number
+		}
+	case importColumnOpeningQuantity:
+		if value == "" {
+			return nil
+		}
+		quantity, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		r.openingQuantity = quantity
+	}
+	return nil
+}
+
+func parseImportNDJSON(payload []byte, maxRows int) (*parsedImport, error) {
+	parsed := &parsedImport{}
+	for i, raw := range bytes.Split(payload, []byte("\n")) {
+		line := i + 1
+		raw = bytes.TrimSpace(raw)
+		if len(raw) == 0 {
+			continue
+		}
+		if parsed.rows == maxRows {
+			return nil, errTooManyImportRows
+		}
+		parsed.rows++
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			parsed.rowErrors = append(parsed.rowErrors, domain.ImportRowError{Line: line, Message: "invalid JSON object"})
+			continue
+		}
+		record := importRecord{line: line}
+		var fieldErrors []domain.ImportRowError
+		for key, value := range fields {
+			column, ok := importColumn(key)
+			if !ok {
+				fieldErrors = append(fieldErrors, domain.ImportRowError{Line: line, Field: key, Message: "unknown field"})
+				continue
+			}
+			if err := record.decode(column, value); err != nil {
+				fieldErrors = append(fieldErrors, domain.ImportRowError{Line: line, Field: column, Message: err.Error()})
+			}
+		}
+		if len(fieldErrors) > 0 {
+			sortRowErrors(fieldErrors)
+			parsed.rowErrors = append(parsed.rowErrors, fieldErrors...)
+			continue
+		}
+		parsed.records = append(parsed.records, record)
+	}
+	return parsed, nil
+}
+
+// decode parses the NDJSON value of column. Null leaves it unset.
+func (r *importRecord) decode(column string, value json.RawMessage) error {
+	if string(value) == "null" {
+		return nil
+	}
+	switch column {
+	case importColumnName, importColumnSKU, importColumnCategory:
+		var text string
+		if err := json.Unmarshal(value, &text); err != nil {
+			return errors.New("must be a string")
+		}
+		return r.set(column, strings.TrimSpace(text))
+	case importColumnPrice, importColumnUnitCost:
+		var number float64
+		if err := json.Unmarshal(value, &number); err != nil {
+			return errors.New("must be a number")
+		}
+		if column == importColumnPrice {
+			r.price = &number
+		} else {
+			r.unitCost = &number
+		}
+	case importColumnOpeningQuantity:
+		var quantity int
+		if err := json.Unmarshal(value, &quantity); err != nil {
+			return errors.New("must be an integer")
+		}
+		r.openingQuantity = quantity
+	}
+	return nil
+}
+
+// sortRowErrors orders the errors of a row by the position of their column,
+// since JSON objects are decoded in no particular order.
+func sortRowErrors(rowErrors []domain.ImportRowError) {
+	position := func(field string) int {
+		for i, column := range importColumns {
+			if column == field {
+				return i
+			}
+		}
+		return len(importColumns)
+	}
+	slices.SortStableFunc(rowErrors, func(a, b domain.ImportRowError) int {
+		if c := position(a.Field) - position(b.Field); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Field, b.Field)
+	})
+}
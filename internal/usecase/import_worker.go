@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/tenant"
+	"go.uber.org/zap"
+)
+
+// ImportWorker runs the queued imports of every tenant, one at a time.
+// Several instances of the service may run a worker each; a job is only
+// claimed by one of them.
+type ImportWorker struct {
+	jobRepo      domain.ImportJobRepository
+	imports      *ProductImportUsecase
+	logger       *zap.Logger
+	pollInterval time.Duration
+	jobTimeout   time.Duration
+}
+
+func NewImportWorker(jobRepo domain.ImportJobRepository, imports *ProductImportUsecase, logger *zap.Logger, pollInterval, jobTimeout time.Duration) *ImportWorker {
+	return &ImportWorker{
+		jobRepo:      jobRepo,
+		imports:      imports,
+		logger:       logger,
+		pollInterval: pollInterval,
+		jobTimeout:   jobTimeout,
+	}
+}
+
+// Run polls for pending jobs until ctx is cancelled. A job interrupted by
+// the cancellation fails; one whose service died is failed as stale once
+// the job timeout has passed.
+func (w *ImportWorker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+	for {
+		w.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fails the jobs that ran for longer than the job timeout, then runs
+// the pending jobs until none is left.
+func (w *ImportWorker) Poll(ctx context.Context) {
+	failed, err := w.jobRepo.FailStale(ctx, time.Now().Add(-w.jobTimeout), "the import did not finish in time")
+	if err != nil {
+		w.logger.Error("Failing stale imports failed", zap.Error(err))
+	} else if failed > 0 {
+		w.logger.Warn("Stale imports failed", zap.Int("count", failed))
+	}
+
+	for ctx.Err() == nil {
+		job, err := w.jobRepo.ClaimNext(ctx)
+		if err != nil {
+			w.logger.Error("Claiming an import failed", zap.Error(err))
+			return
+		}
+		if job == nil {
+			return
+		}
+		w.run(ctx, job)
+	}
+}
+
+// run imports job in its tenant, on behalf of the user who uploaded it.
+func (w *ImportWorker) run(ctx context.Context, job *domain.ImportJob) {
+	logger := w.logger.With(zap.String("importID", job.ID), zap.String("tenantID", job.TenantID))
+	ctx = logging.WithLogger(ctx, logger)
+	ctx = tenant.WithID(ctx, job.TenantID)
+	ctx = auth.WithPrincipal(ctx, auth.NewJobPrincipal("import:"+job.ID, job.UserID, job.TenantID, importPermissions...))
+	ctx, cancel := context.WithTimeout(ctx, w.jobTimeout)
+	defer cancel()
+
+	// A bug triggered by one file must fail its job, not the service the
+	// worker runs in.
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+		logger.Error("Import panicked", zap.Any("panic", recovered), zap.Stack("stack"))
+		job.Status = domain.ImportStatusFailed
+		job.Failure = "the import failed unexpectedly"
+		if err := w.jobRepo.Finish(context.WithoutCancel(ctx), job); err != nil {
+			logger.Error("Saving the outcome of an import failed", zap.Error(err))
+		}
+	}()
+
+	logger.Info("Import started", zap.String("format", job.Format), zap.Bool("dryRun", job.DryRun))
+	if err := w.imports.RunImport(ctx, job); err != nil {
+		logger.Error("Saving the outcome of an import failed", zap.Error(err))
+	}
+}
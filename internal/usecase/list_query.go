@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+var (
+	categorySortFields      = []string{"name", "createdAt"}
+	productSortFields       = []string{"name", "createdAt"}
+	stockMovementSortFields = []string{"createdAt"}
+)
+
+func toListQuery(dto dtos.ListQueryDTO, includeDeleted bool, sortFields []string) (domain.ListQuery, error) {
+	query := domain.ListQuery{
+		Limit:          dto.Limit,
+		Offset:         dto.Offset,
+		Cursor:         dto.Cursor,
+		SortBy:         dto.Sort,
+		Order:          domain.SortOrder(dto.Order),
+		Search:         dto.Q,
+		Prefix:         dto.Prefix,
+		IncludeDeleted: includeDeleted,
+	}
+	return query.Normalize(sortFields...)
+}
@@ -0,0 +1,356 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"go.uber.org/zap"
+)
+
+// importProgressInterval is how many rows are written between two progress
+// updates of a running import.
+const importProgressInterval = 100
+
+// importPermissions are needed to queue an import, since running it creates
+// categories, products and stock movements.
+var importPermissions = []auth.Permission{auth.PermProductsWrite, auth.PermCategoriesWrite, auth.PermStockWrite}
+
+type ProductImportUsecase struct {
+	jobRepo      domain.ImportJobRepository
+	productRepo  domain.ProductRepository
+	categoryRepo domain.CategoryRepository
+	movements    *StockMovementUsecase
+	transactor   domain.Transactor
+	maxRows      int
+}
+
+func NewProductImportUsecase(
+	jobRepo domain.ImportJobRepository,
+	productRepo domain.ProductRepository,
+	categoryRepo domain.CategoryRepository,
+	movements *StockMovementUsecase,
+	transactor domain.Transactor,
+	maxRows int,
+) *ProductImportUsecase {
+	return &ProductImportUsecase{
+		jobRepo:      jobRepo,
+		productRepo:  productRepo,
+		categoryRepo: categoryRepo,
+		movements:    movements,
+		transactor:   transactor,
+		maxRows:      maxRows,
+	}
+}
+
+// CreateImport queues the import of a file. The file is only read when the
+// job runs, so the returned job is pending.
+func (u *ProductImportUsecase) CreateImport(ctx context.Context, dto dtos.CreateImportDTO) (_ *domain.ImportJob, err error) {
+	ctx, span := startSpan(ctx, "ProductImportUsecase.CreateImport")
+	defer func() { endSpan(span, err) }()
+
+	for _, perm := range importPermissions {
+		if err := auth.Authorize(ctx, perm); err != nil {
+			return nil, err
+		}
+	}
+
+	if dto.Format != domain.ImportFormatCSV && dto.Format != domain.ImportFormatNDJSON {
+		return nil, domain.ErrUnsupportedImportFormat
+	}
+	if len(strings.TrimSpace(string(dto.Payload))) == 0 {
+		return nil, domain.ErrEmptyImport
+	}
+
+	job := &domain.ImportJob{
+		Format:  dto.Format,
+		DryRun:  dto.DryRun,
+		Status:  domain.ImportStatusPending,
+		Payload: dto.Payload,
+		UserID:  auth.UserID(ctx),
+	}
+	if err := u.jobRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (u *ProductImportUsecase) GetImport(ctx context.Context, id string) (_ *domain.ImportJob, err error) {
+	ctx, span := startSpan(ctx, "ProductImportUsecase.GetImport")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermProductsRead); err != nil {
+		return nil, err
+	}
+	return u.jobRepo.GetByID(ctx, id)
+}
+
+// RunImport runs a claimed job in the tenant of ctx and saves its outcome.
+// Every row is validated first: a file with an invalid row creates nothing,
+// and a dry run stops there. Rows are then written one by one, each in its
+// own transaction: a failure midway leaves the rows before it imported, as
+// ProcessedRows reports, and nothing of the failed row.
+// Failures of the job are recorded on it; the error is only set when the
+// outcome could not be saved.
+func (u *ProductImportUsecase) RunImport(ctx context.Context, job *domain.ImportJob) (err error) {
+	ctx, span := startSpan(ctx, "ProductImportUsecase.RunImport")
+	defer func() { endSpan(span, err) }()
+
+	runErr := u.runImport(ctx, job)
+	switch {
+	case runErr != nil:
+		job.Status = domain.ImportStatusFailed
+		if job.Failure == "" {
+			job.Failure = runErr.Error()
+		}
+	case job.ErrorCount > 0:
+		job.Status = domain.ImportStatusFailed
+	default:
+		job.Status = domain.ImportStatusSucceeded
+	}
+
+	// The outcome is saved even when the job ran out of time.
+	if err := u.jobRepo.Finish(context.WithoutCancel(ctx), job); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info(
+		"Import finished",
+		zap.String("importID", job.ID),
+		zap.String("status", job.Status),
+		zap.Bool("dryRun", job.DryRun),
+		zap.Int("totalRows", job.TotalRows),
+		zap.Int("processedRows", job.ProcessedRows),
+		zap.Int("createdProducts", job.CreatedProducts),
+		zap.Int("createdCategories", job.CreatedCategories),
+		zap.Int("errorCount", job.ErrorCount),
+		zap.String("failure", job.Failure),
+	)
+	return nil
+}
+
+func (u *ProductImportUsecase) runImport(ctx context.Context, job *domain.ImportJob) error {
+	parsed, err := parseImport(job.Format, job.Payload, u.maxRows)
+	if errors.Is(err, errTooManyImportRows) {
+		return fmt.Errorf("the file has more than %d rows", u.maxRows)
+	}
+	if err != nil {
+		return err
+	}
+	job.TotalRows = parsed.rows
+	if parsed.rows == 0 {
+		return errors.New("the file has no rows")
+	}
+
+	rows, rowErrors, err := u.validateImport(ctx, parsed.records)
+	if err != nil {
+		return err
+	}
+	rowErrors = append(rowErrors, parsed.rowErrors...)
+	if len(rowErrors) > 0 {
+		slices.SortStableFunc(rowErrors, func(a, b domain.ImportRowError) int { return a.Line - b.Line })
+		for _, rowErr := range rowErrors {
+			job.AddError(rowErr)
+		}
+		return nil
+	}
+
+	categories, err := u.categoriesByName(ctx)
+	if err != nil {
+		return err
+	}
+	if job.DryRun {
+		job.ProcessedRows = len(rows)
+		job.CreatedProducts = len(rows)
+		for _, row := range rows {
+			key := strings.ToLower(row.CategoryName)
+			if _, ok := categories[key]; !ok {
+				categories[key] = nil
+				job.CreatedCategories++
+			}
+		}
+		return nil
+	}
+
+	for _, row := range rows {
+		key := strings.ToLower(row.CategoryName)
+		category := categories[key]
+		var created *domain.Category
+		err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			created, err = u.importRow(ctx, job, category, row)
+			return err
+		})
+		if err != nil {
+			job.Failure = fmt.Sprintf("line %d: %v", row.Line, err)
+			return err
+		}
+		// The category and the counts are only kept once the row is
+		// committed.
+		if created != nil {
+			categories[key] = created
+			job.CreatedCategories++
+		}
+		job.CreatedProducts++
+		job.ProcessedRows++
+		if job.ProcessedRows%importProgressInterval == 0 {
+			if err := u.jobRepo.UpdateProgress(ctx, job.ID, job.ProcessedRows); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateImport checks the values of every record, and that SKUs are
+// neither repeated in the file nor used by an existing product. It returns
+// the valid rows and the errors of the others.
+func (u *ProductImportUsecase) validateImport(ctx context.Context, records []importRecord) ([]domain.ImportRow, []domain.ImportRowError, error) {
+	products, err := u.productRepo.ListAll(ctx, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	existingSKUs := make(map[string]int, len(products))
+	for _, p := range products {
+		if p.SKU != "" {
+			existingSKUs[p.SKU] = p.ID
+		}
+	}
+
+	fileSKUs := make(map[string]int, len(records))
+	rows := make([]domain.ImportRow, 0, len(records))
+	var invalid []domain.ImportRowError
+	for _, r := range records {
+		rowErrors := validateImportRecord(r)
+		if r.sku != "" {
+			if line, ok := fileSKUs[r.sku]; ok {
+				rowErrors = append(rowErrors, domain.ImportRowError{Line: r.line, Field: importColumnSKU, Message: fmt.Sprintf("repeats the SKU of line %d", line)})
+			} else if id, ok := existingSKUs[r.sku]; ok {
+				rowErrors = append(rowErrors, domain.ImportRowError{Line: r.line, Field: importColumnSKU, Message: fmt.Sprintf("is already used by product %d", id)})
+			} else {
+				fileSKUs[r.sku] = r.line
+			}
+		}
+		if len(rowErrors) > 0 {
+			invalid = append(invalid, rowErrors...)
+			continue
+		}
+
+		rows = append(rows, domain.ImportRow{
+			Line:            r.line,
+			Name:            r.name,
+			SKU:             r.sku,
+			CategoryName:    r.category,
+			Price:           *r.price,
+			OpeningQuantity: r.openingQuantity,
+			UnitCost:        r.unitCost,
+		})
+	}
+	return rows, invalid, nil
+}
+
+func validateImportRecord(r importRecord) []domain.ImportRowError {
+	var rowErrors []domain.ImportRowError
+	invalid := func(field, message string) {
+		rowErrors = append(rowErrors, domain.ImportRowError{Line: r.line, Field: field, Message: message})
+	}
+
+	switch {
+	case r.name == "":
+		invalid(importColumnName, "is required")
+	case utf8.RuneCountInString(r.name) > 255:
+		invalid(importColumnName, "cannot be longer than 255 characters")
+	}
+	switch {
+	case r.sku == "":
+		invalid(importColumnSKU, "is required")
+	case !domain.ValidSKU(r.sku):
+		invalid(importColumnSKU, "must be 3 to 64 upper-case letters or digits in dash separated groups, e.g. CHAIR-OAK-01")
+	}
+	switch {
+	case r.category == "":
+		invalid(importColumnCategory, "is required")
+	case utf8.RuneCountInString(r.category) > 255:
+		invalid(importColumnCategory, "cannot be longer than 255 characters")
+	}
+	switch {
+	case r.price == nil:
+		invalid(importColumnPrice, "is required")
+	case *r.price < 0:
+		invalid(importColumnPrice, "cannot be negative")
+	}
+	if r.openingQuantity < 0 {
+		invalid(importColumnOpeningQuantity, "cannot be negative")
+	}
+	if r.unitCost != nil && *r.unitCost < 0 {
+		invalid(importColumnUnitCost, "cannot be negative")
+	}
+	return rowErrors
+}
+
+// categoriesByName returns the active categories by lower-cased name. When
+// names only differ by case, the oldest category wins.
+func (u *ProductImportUsecase) categoriesByName(ctx context.Context) (map[string]*domain.Category, error) {
+	categories, err := u.categoryRepo.ListAll(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*domain.Category, len(categories))
+	for _, c := range categories {
+		key := strings.ToLower(c.Name)
+		if _, ok := byName[key]; !ok {
+			byName[key] = c
+		}
+	}
+	return byName, nil
+}
+
+// importRow creates the product of row, in category or in a new category
+// named after the row when category is nil, and a receipt of its opening
+// quantity. It returns the category it created, if any.
+func (u *ProductImportUsecase) importRow(ctx context.Context, job *domain.ImportJob, category *domain.Category, row domain.ImportRow) (*domain.Category, error) {
+	var created *domain.Category
+	if category == nil {
+		category = &domain.Category{Name: row.CategoryName}
+		if err := u.categoryRepo.Create(ctx, category); err != nil {
+			return nil, fmt.Errorf("creating category %q: %w", row.CategoryName, err)
+		}
+		created = category
+	}
+
+	product := &domain.Product{
+		Name:       row.Name,
+		SKU:        row.SKU,
+		Price:      row.Price,
+		CategoryID: category.ID,
+	}
+	if err := u.productRepo.Create(ctx, product); err != nil {
+		return nil, fmt.Errorf("creating product %q: %w", row.SKU, err)
+	}
+
+	if row.OpeningQuantity == 0 {
+		return created, nil
+	}
+	unitCost := row.UnitCost
+	if unitCost == nil {
+		unitCost = new(float64)
+	}
+	_, err := u.movements.RecordMovement(ctx, dtos.CreateStockMovementDTO{
+		ProductID:    product.ID,
+		MovementType: domain.MovementTypeReceipt,
+		Quantity:     row.OpeningQuantity,
+		UnitCost:     unitCost,
+		Reason:       "Opening stock, import " + job.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("recording the opening stock of %q: %w", row.SKU, err)
+	}
+	return created, nil
+}
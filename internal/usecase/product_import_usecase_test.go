@@ -0,0 +1,345 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	categoryRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/CategoryRepository"
+	costLayerRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/CostLayerRepository"
+	importJobRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/ImportJobRepository"
+	productRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/ProductRepository"
+	stockLevelRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockLevelRepository"
+	stockMovementRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockMovementRepository"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type importMocks struct {
+	jobRepo      *importJobRepositoryMock.MockImportJobRepository
+	productRepo  *productRepositoryMock.MockProductRepository
+	categoryRepo *categoryRepositoryMock.MockCategoryRepository
+	stockMovementMocks
+}
+
+func newImportMocks(t *testing.T) importMocks {
+	productRepo := productRepositoryMock.NewMockProductRepository(t)
+	return importMocks{
+		jobRepo:      importJobRepositoryMock.NewMockImportJobRepository(t),
+		productRepo:  productRepo,
+		categoryRepo: categoryRepositoryMock.NewMockCategoryRepository(t),
+		stockMovementMocks: stockMovementMocks{
+			movementRepo:   stockMovementRepositoryMock.NewMockStockMovementRepository(t),
+			stockLevelRepo: stockLevelRepositoryMock.NewMockStockLevelRepository(t),
+			costLayerRepo:  costLayerRepositoryMock.NewMockCostLayerRepository(t),
+			productRepo:    productRepo,
+			transactor:     inlineTransactor(t),
+		},
+	}
+}
+
+func (m importMocks) usecase(maxRows int) *ProductImportUsecase {
+	movements := NewStockMovementUsecase(m.movementRepo, m.stockLevelRepo, m.costLayerRepo, m.productRepo, m.transactor, nil)
+	return NewProductImportUsecase(m.jobRepo, m.productRepo, m.categoryRepo, movements, m.transactor, maxRows)
+}
+
+func TestParseImport(t *testing.T) {
+	t.Run("csv", func(t *testing.T) {
+		payload := "\ufeffName,SKU,Category,Price,openingquantity,unitCost\n" +
+			"Desk,DSK-1,Furniture,120.50,3,80\n" +
+			"\"Chair, folding\",CHR-1,Furniture,25,,\n" +
+			"Lamp,LMP-1,Lighting,cheap,1.5,\n" +
+			"Rug,RUG-1\n"
+
+		parsed, err := parseImport(domain.ImportFormatCSV, []byte(payload), 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, parsed.rows)
+		assert.Equal(t, []importRecord{
+			{line: 2, name: "Desk", sku: "DSK-1", category: "Furniture", price: floatPtr(120.5), openingQuantity: 3, unitCost: floatPtr(80)},
+			{line: 3, name: "Chair, folding", sku: "CHR-1", category: "Furniture", price: floatPtr(25)},
+		}, parsed.records)
+		assert.Equal(t, []domain.ImportRowError{
+			{Line: 4, Field: "price", Message: "must be a number"},
+			{Line: 4, Field: "openingQuantity", Message: "must be an integer"},
+			{Line: 5, Message: "expected 6 fields, got 2"},
+		}, parsed.rowErrors)
+	})
+
+	t.Run("csv with malformed quoting", func(t *testing.T) {
+		payload := "name,sku,category,price\n" +
+			"a\"b,X-1,Furniture,1\n" +
+			"Desk,DSK-1,Furniture,2\n" +
+			"\"Lamp,LMP-1,Lighting,3\n"
+
+		parsed, err := parseImport(domain.ImportFormatCSV, []byte(payload), 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, parsed.rows)
+		assert.Equal(t, []importRecord{
+			{line: 3, name: "Desk", sku: "DSK-1", category: "Furniture", price: floatPtr(2)},
+		}, parsed.records)
+		assert.Equal(t, []domain.ImportRowError{
+			{Line: 2, Message: csv.ErrBareQuote.Error()},
+			{Line: 4, Message: csv.ErrQuote.Error()},
+		}, parsed.rowErrors)
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		payload := `{"name":"Desk","sku":"DSK-1","category":"Furniture","price":120.5,"openingQuantity":3}` + "\n\n" +
+			`{"name":"Lamp","SKU":"LMP-1","category":"Lighting","price":"cheap","colour":"red"}` + "\n" +
+			`not json` + "\n"
+
+		parsed, err := parseImport(domain.ImportFormatNDJSON, []byte(payload), 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, parsed.rows)
+		assert.Equal(t, []importRecord{
+			{line: 1, name: "Desk", sku: "DSK-1", category: "Furniture", price: floatPtr(120.5), openingQuantity: 3},
+		}, parsed.records)
+		assert.Equal(t, []domain.ImportRowError{
+			{Line: 3, Field: "price", Message: "must be a number"},
+			{Line: 3, Field: "colour", Message: "unknown field"},
+			{Line: 4, Message: "invalid JSON object"},
+		}, parsed.rowErrors)
+	})
+
+	t.Run("unreadable files", func(t *testing.T) {
+		_, err := parseImport(domain.ImportFormatCSV, []byte("name,sku,price\n"), 10)
+		assert.EqualError(t, err, `missing column "category"`)
+
+		_, err = parseImport(domain.ImportFormatCSV, []byte("name,sku,category,price,colour\n"), 10)
+		assert.ErrorContains(t, err, `unknown column "colour"`)
+
+		_, err = parseImport(domain.ImportFormatNDJSON, []byte("{}\n{}\n{}\n"), 2)
+		assert.ErrorIs(t, err, errTooManyImportRows)
+	})
+}
+
+func TestCreateImport(t *testing.T) {
+	t.Run("queues the file", func(t *testing.T) {
+		m := newImportMocks(t)
+		m.jobRepo.On("Create", mock.Anything, mock.MatchedBy(func(j *domain.ImportJob) bool {
+			return j.Status == domain.ImportStatusPending && j.Format == domain.ImportFormatCSV && j.DryRun && j.UserID == testUserID
+		})).Return(nil)
+
+		job, err := m.usecase(10).CreateImport(principalContext(auth.RoleAdmin), dtos.CreateImportDTO{
+			Format: domain.ImportFormatCSV, DryRun: true, Payload: []byte("name,sku,category,price\n"),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ImportStatusPending, job.Status)
+	})
+
+	t.Run("rejects empty files", func(t *testing.T) {
+		_, err := newImportMocks(t).usecase(10).CreateImport(context.Background(), dtos.CreateImportDTO{
+			Format: domain.ImportFormatNDJSON, Payload: []byte("\n "),
+		})
+		assert.ErrorIs(t, err, domain.ErrEmptyImport)
+	})
+
+	t.Run("needs every permission the import uses", func(t *testing.T) {
+		_, err := newImportMocks(t).usecase(10).CreateImport(principalContext(auth.RoleClerk), dtos.CreateImportDTO{
+			Format: domain.ImportFormatCSV, Payload: []byte("name,sku,category,price\n"),
+		})
+		var forbidden *auth.ForbiddenError
+		require.ErrorAs(t, err, &forbidden)
+		assert.Equal(t, auth.PermProductsWrite, forbidden.Permission)
+	})
+}
+
+func TestRunImport(t *testing.T) {
+	const payload = "name,sku,category,price,openingQuantity,unitCost\n" +
+		"Desk,DSK-1,furniture,120,3,80\n" +
+		"Lamp,LMP-1,Lighting,15,0,\n" +
+		"Bulb,BLB-1,Lighting,2,10,\n"
+
+	existing := func(m importMocks) {
+		m.productRepo.On("ListAll", mock.Anything, false).Return([]*domain.Product{{ID: 1, SKU: "OLD-1"}, {ID: 2}}, nil)
+		m.categoryRepo.On("ListAll", mock.Anything, false).Return([]*domain.Category{{ID: 4, Name: "Furniture"}}, nil)
+	}
+
+	t.Run("creates products, categories and opening stock", func(t *testing.T) {
+		m := newImportMocks(t)
+		existing(m)
+		m.categoryRepo.On("Create", mock.MatchedBy(inTransaction), mock.MatchedBy(func(c *domain.Category) bool {
+			return c.Name == "Lighting" && c.ParentID == nil
+		})).Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Category).ID = 9
+		}).Return(nil).Once()
+		var created []*domain.Product
+		m.productRepo.On("Create", mock.MatchedBy(inTransaction), mock.Anything).Run(func(args mock.Arguments) {
+			p := args.Get(1).(*domain.Product)
+			p.ID = 10 + len(created)
+			created = append(created, p)
+		}).Return(nil)
+		m.productRepo.On("GetByID", mock.Anything, mock.Anything).Return(&domain.Product{}, nil)
+		m.stockLevelRepo.On("LockByProductID", mock.Anything, mock.Anything).Return(&domain.StockLevel{}, nil)
+		m.costLayerRepo.On("ListOpenByProductID", mock.Anything, mock.Anything).Return(nil, nil)
+		var movements []*domain.StockMovement
+		m.movementRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			movements = append(movements, args.Get(1).(*domain.StockMovement))
+		}).Return(nil)
+		m.costLayerRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		m.stockLevelRepo.On("UpdateQuantity", mock.Anything, mock.Anything).Return(nil)
+		m.jobRepo.On("Finish", mock.Anything, mock.Anything).Return(nil)
+
+		job := &domain.ImportJob{ID: "job-1", Format: domain.ImportFormatCSV, Payload: []byte(payload)}
+		require.NoError(t, m.usecase(10).RunImport(principalContext(auth.RoleAdmin), job))
+
+		assert.Equal(t, domain.ImportStatusSucceeded, job.Status)
+		assert.Equal(t, 3, job.TotalRows)
+		assert.Equal(t, 3, job.ProcessedRows)
+		assert.Equal(t, 3, job.CreatedProducts)
+		assert.Equal(t, 1, job.CreatedCategories)
+		require.Len(t, created, 3)
+		assert.Equal(t, domain.Product{ID: 10, Name: "Desk", SKU: "DSK-1", Price: 120, CategoryID: 4}, *created[0])
+		assert.Equal(t, 9, created[1].CategoryID)
+		assert.Equal(t, 9, created[2].CategoryID)
+
+		require.Len(t, movements, 2)
+		assert.Equal(t, 10, movements[0].ProductID)
+		assert.Equal(t, domain.MovementTypeReceipt, movements[0].MovementType)
+		assert.Equal(t, 3, movements[0].Quantity)
+		assert.Equal(t, floatPtr(80), movements[0].UnitCost)
+		assert.Equal(t, "Opening stock, import job-1", movements[0].Reason)
+		assert.Equal(t, testUserID, movements[0].UserID)
+		assert.Equal(t, 12, movements[1].ProductID)
+		assert.Equal(t, floatPtr(0), movements[1].UnitCost)
+	})
+
+	t.Run("dry run counts what would be created", func(t *testing.T) {
+		m := newImportMocks(t)
+		existing(m)
+		m.jobRepo.On("Finish", mock.Anything, mock.Anything).Return(nil)
+
+		job := &domain.ImportJob{ID: "job-1", Format: domain.ImportFormatCSV, DryRun: true, Payload: []byte(payload)}
+		require.NoError(t, m.usecase(10).RunImport(context.Background(), job))
+
+		assert.Equal(t, domain.ImportStatusSucceeded, job.Status)
+		assert.Equal(t, 3, job.CreatedProducts)
+		assert.Equal(t, 1, job.CreatedCategories)
+	})
+
+	t.Run("invalid rows import nothing", func(t *testing.T) {
+		m := newImportMocks(t)
+		m.productRepo.On("ListAll", mock.Anything, false).Return([]*domain.Product{{ID: 1, SKU: "OLD-1"}}, nil)
+		m.jobRepo.On("Finish", mock.Anything, mock.Anything).Return(nil)
+
+		job := &domain.ImportJob{ID: "job-1", Format: domain.ImportFormatCSV, Payload: []byte(
+			"name,sku,category,price,openingQuantity\n" +
+				"Desk,DSK-1,Furniture,120,1\n" +
+				",DSK-1,Furniture,-1,-2\n" +
+				"Lamp,OLD-1,Lighting,1,x\n" +
+				"Bulb,OLD-1,Lighting,,\n" +
+				"Rug,rug 1,Home,5,\n",
+		)}
+		require.NoError(t, m.usecase(10).RunImport(context.Background(), job))
+
+		assert.Equal(t, domain.ImportStatusFailed, job.Status)
+		assert.Empty(t, job.Failure)
+		assert.Equal(t, 5, job.TotalRows)
+		assert.Zero(t, job.ProcessedRows)
+		assert.Equal(t, []domain.ImportRowError{
+			{Line: 3, Field: "name", Message: "is required"},
+			{Line: 3, Field: "price", Message: "cannot be negative"},
+			{Line: 3, Field: "openingQuantity", Message: "cannot be negative"},
+			{Line: 3, Field: "sku", Message: "repeats the SKU of line 2"},
+			{Line: 4, Field: "openingQuantity", Message: "must be an integer"},
+			{Line: 5, Field: "price", Message: "is required"},
+			{Line: 5, Field: "sku", Message: "is already used by product 1"},
+			{Line: 6, Field: "sku", Message: "must be 3 to 64 upper-case letters or digits in dash separated groups, e.g. CHAIR-OAK-01"},
+		}, job.Errors)
+		assert.Equal(t, 8, job.ErrorCount)
+	})
+
+	t.Run("files over the row limit fail", func(t *testing.T) {
+		m := newImportMocks(t)
+		m.jobRepo.On("Finish", mock.Anything, mock.MatchedBy(func(j *domain.ImportJob) bool {
+			return j.Status == domain.ImportStatusFailed && j.Failure == "the file has more than 2 rows"
+		})).Return(nil)
+
+		job := &domain.ImportJob{ID: "job-1", Format: domain.ImportFormatCSV, Payload: []byte(payload)}
+		require.NoError(t, m.usecase(2).RunImport(context.Background(), job))
+	})
+
+	t.Run("a failed write stops the import", func(t *testing.T) {
+		m := newImportMocks(t)
+		existing(m)
+		m.productRepo.On("Create", mock.Anything, mock.Anything).Return(assert.AnError).Once()
+		m.jobRepo.On("Finish", mock.Anything, mock.Anything).Return(nil)
+
+		job := &domain.ImportJob{ID: "job-1", Format: domain.ImportFormatCSV, Payload: []byte(payload)}
+		require.NoError(t, m.usecase(10).RunImport(context.Background(), job))
+
+		assert.Equal(t, domain.ImportStatusFailed, job.Status)
+		assert.Equal(t, `line 2: creating product "DSK-1": `+assert.AnError.Error(), job.Failure)
+		assert.Zero(t, job.ProcessedRows)
+	})
+
+	t.Run("a failed receipt counts nothing of its row", func(t *testing.T) {
+		m := newImportMocks(t)
+		m.productRepo.On("ListAll", mock.Anything, false).Return(nil, nil)
+		m.categoryRepo.On("ListAll", mock.Anything, false).Return(nil, nil)
+		m.categoryRepo.On("Create", mock.MatchedBy(inTransaction), mock.Anything).Return(nil).Once()
+		m.productRepo.On("Create", mock.MatchedBy(inTransaction), mock.Anything).Return(nil).Once()
+		m.productRepo.On("GetByID", mock.Anything, mock.Anything).Return(&domain.Product{}, nil)
+		m.stockLevelRepo.On("LockByProductID", mock.MatchedBy(inTransaction), mock.Anything).Return(nil, assert.AnError)
+		m.jobRepo.On("Finish", mock.Anything, mock.Anything).Return(nil)
+
+		job := &domain.ImportJob{ID: "job-1", Format: domain.ImportFormatCSV, Payload: []byte(payload)}
+		require.NoError(t, m.usecase(10).RunImport(principalContext(auth.RoleAdmin), job))
+
+		assert.Equal(t, domain.ImportStatusFailed, job.Status)
+		assert.Zero(t, job.ProcessedRows)
+		assert.Zero(t, job.CreatedProducts, "the product is rolled back with the receipt")
+		assert.Zero(t, job.CreatedCategories, "and so is its category")
+	})
+}
+
+func TestImportWorkerPoll(t *testing.T) {
+	m := newImportMocks(t)
+	m.jobRepo.On("FailStale", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= time.Hour
+	}), mock.Anything).Return(1, nil)
+	job := &domain.ImportJob{ID: "job-1", TenantID: "seller-a", UserID: testUserID, Format: domain.ImportFormatNDJSON, DryRun: true,
+		Payload: []byte(`{"name":"Desk","sku":"DSK-1","category":"Furniture","price":120}`)}
+	m.jobRepo.On("ClaimNext", mock.Anything).Return(job, nil).Once()
+	m.jobRepo.On("ClaimNext", mock.Anything).Return(nil, nil).Once()
+	m.productRepo.On("ListAll", mock.MatchedBy(func(ctx context.Context) bool {
+		id, _ := tenant.FromContext(ctx)
+		return id == "seller-a" && auth.UserID(ctx) == testUserID
+	}), false).Return(nil, nil)
+	m.categoryRepo.On("ListAll", mock.Anything, false).Return(nil, nil)
+	m.jobRepo.On("Finish", mock.Anything, job).Return(nil)
+
+	NewImportWorker(m.jobRepo, m.usecase(10), zap.NewNop(), time.Second, time.Hour).Poll(context.Background())
+
+	assert.Equal(t, domain.ImportStatusSucceeded, job.Status)
+	assert.Equal(t, 1, job.CreatedCategories)
+}
+
+func TestImportWorkerFailsAPanickingJob(t *testing.T) {
+	m := newImportMocks(t)
+	m.jobRepo.On("FailStale", mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+	job := &domain.ImportJob{ID: "job-1", TenantID: "seller-a", Format: domain.ImportFormatNDJSON,
+		Payload: []byte(`{"name":"Desk","sku":"DSK-1","category":"Furniture","price":120}`)}
+	m.jobRepo.On("ClaimNext", mock.Anything).Return(job, nil).Once()
+	m.jobRepo.On("ClaimNext", mock.Anything).Return(nil, nil).Once()
+	m.productRepo.On("ListAll", mock.Anything, false).Run(func(mock.Arguments) { panic("boom") })
+	m.jobRepo.On("Finish", mock.Anything, job).Return(nil)
+
+	assert.NotPanics(t, func() {
+		NewImportWorker(m.jobRepo, m.usecase(10), zap.NewNop(), time.Second, time.Hour).Poll(context.Background())
+	})
+
+	assert.Equal(t, domain.ImportStatusFailed, job.Status)
+	assert.Equal(t, "the import failed unexpectedly", job.Failure)
+}
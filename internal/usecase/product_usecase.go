@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+type ProductUsecase struct {
+	productRepo  domain.ProductRepository
+	categoryRepo domain.CategoryRepository
+}
+
+func NewProductUsecase(productRepo domain.ProductRepository, categoryRepo domain.CategoryRepository) *ProductUsecase {
+	return &ProductUsecase{productRepo: productRepo, categoryRepo: categoryRepo}
+}
+
+// CreateProduct adds a product to an existing category.
+func (u *ProductUsecase) CreateProduct(ctx context.Context, dto dtos.CreateProductDTO) (_ *domain.Product, err error) {
+	ctx, span := startSpan(ctx, "ProductUsecase.CreateProduct")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermProductsWrite); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.categoryRepo.GetByID(ctx, dto.CategoryID); err != nil {
+		return nil, err
+	}
+
+	product := &domain.Product{
+		Name:        dto.Name,
+		SKU:         dto.SKU,
+		Description: dto.Description,
+		Price:       dto.Price,
+		CategoryID:  dto.CategoryID,
+	}
+	if err := u.productRepo.Create(ctx, product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// UpdateProduct replaces the details of a product, keeping its SKU when dto
+// has none.
+func (u *ProductUsecase) UpdateProduct(ctx context.Context, id int, dto dtos.UpdateProductDTO) (_ *domain.Product, err error) {
+	ctx, span := startSpan(ctx, "ProductUsecase.UpdateProduct")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermProductsWrite); err != nil {
+		return nil, err
+	}
+
+	product, err := u.productRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if dto.CategoryID != product.CategoryID {
+		if _, err := u.categoryRepo.GetByID(ctx, dto.CategoryID); err != nil {
+			return nil, err
+		}
+	}
+
+	product.Name = dto.Name
+	if dto.SKU != "" {
+		product.SKU = dto.SKU
+	}
+	product.Description = dto.Description
+	product.Price = dto.Price
+	product.CategoryID = dto.CategoryID
+	if err := u.productRepo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// ListProducts pages through all products, or only those in the given
+// category and any of its subcategories when a category is set.
+func (u *ProductUsecase) ListProducts(ctx context.Context, dto dtos.ListProductsDTO) (_ *domain.Page[*domain.Product], err error) {
+	ctx, span := startSpan(ctx, "ProductUsecase.ListProducts")
+	defer func() { endSpan(span, err) }()
+
+	query, err := toListQuery(dto.ListQueryDTO, dto.IncludeDeleted, productSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.CategoryID == 0 {
+		return u.productRepo.List(ctx, nil, query)
+	}
+
+	categoryIDs, err := u.categoryWithDescendants(ctx, dto.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.productRepo.List(ctx, categoryIDs, query)
+}
+
+// SearchProducts runs a full-text search over product names and
+// descriptions, best matches first. A category filter also matches products
+// in its subcategories.
+func (u *ProductUsecase) SearchProducts(ctx context.Context, dto dtos.SearchProductsDTO) (_ *domain.ProductSearchResult, err error) {
+	ctx, span := startSpan(ctx, "ProductUsecase.SearchProducts")
+	defer func() { endSpan(span, err) }()
+
+	query, err := domain.ProductSearchQuery{
+		Text:        dto.Q,
+		MinPrice:    dto.MinPrice,
+		MaxPrice:    dto.MaxPrice,
+		InStockOnly: dto.InStock,
+		Limit:       dto.Limit,
+		Offset:      dto.Offset,
+	}.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.CategoryID != 0 {
+		query.CategoryIDs, err = u.categoryWithDescendants(ctx, dto.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return u.productRepo.Search(ctx, query)
+}
+
+func (u *ProductUsecase) categoryWithDescendants(ctx context.Context, categoryID int) ([]int, error) {
+	if _, err := u.categoryRepo.GetByID(ctx, categoryID); err != nil {
+		return nil, err
+	}
+
+	descendants, err := u.categoryRepo.ListDescendants(ctx, categoryID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryIDs := []int{categoryID}
+	for _, d := range descendants {
+		categoryIDs = append(categoryIDs, d.ID)
+	}
+	return categoryIDs, nil
+}
+
+// DeleteProduct soft-deletes a product. Its stock movements keep referencing
+// it, so the audit trail is preserved.
+func (u *ProductUsecase) DeleteProduct(ctx context.Context, id int) (err error) {
+	ctx, span := startSpan(ctx, "ProductUsecase.DeleteProduct")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermProductsWrite); err != nil {
+		return err
+	}
+
+	if _, err := u.productRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+	return u.productRepo.Delete(ctx, id)
+}
+
+func (u *ProductUsecase) RestoreProduct(ctx context.Context, id int) (_ *domain.Product, err error) {
+	ctx, span := startSpan(ctx, "ProductUsecase.RestoreProduct")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermProductsWrite); err != nil {
+		return nil, err
+	}
+
+	if _, err := u.productRepo.GetDeletedByID(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrProductNotFound) {
+			if _, activeErr := u.productRepo.GetByID(ctx, id); activeErr == nil {
+				return nil, domain.ErrProductNotDeleted
+			}
+		}
+		return nil, err
+	}
+
+	if err := u.productRepo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return u.productRepo.GetByID(ctx, id)
+}
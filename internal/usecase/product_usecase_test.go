@@ -0,0 +1,342 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	categoryRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/CategoryRepository"
+	productRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/ProductRepository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListProducts(t *testing.T) {
+	defaultProductQuery := domain.ListQuery{Limit: domain.DefaultPageLimit, SortBy: "name", Order: domain.SortAsc}
+
+	tests := []struct {
+		name        string
+		dto         dtos.ListProductsDTO
+		mockSetup   func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository)
+		expectedErr error
+		expectedLen int
+	}{
+		{
+			name: "all products",
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository) {
+				productRepo.On("List", mock.Anything, []int(nil), defaultProductQuery).Return(&domain.Page[*domain.Product]{
+					Items: []*domain.Product{{ID: 1}, {ID: 2}},
+				}, nil)
+			},
+			expectedLen: 2,
+		},
+		{
+			name: "category includes subcategories",
+			dto:  dtos.ListProductsDTO{CategoryID: 1},
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository) {
+				categoryRepo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1}, nil)
+				categoryRepo.On("ListDescendants", mock.Anything, 1, false).Return([]*domain.Category{{ID: 2}, {ID: 5}}, nil)
+				productRepo.On("List", mock.Anything, []int{1, 2, 5}, defaultProductQuery).Return(&domain.Page[*domain.Product]{
+					Items: []*domain.Product{{ID: 7, CategoryID: 5}},
+				}, nil)
+			},
+			expectedLen: 1,
+		},
+		{
+			name: "category not found",
+			dto:  dtos.ListProductsDTO{CategoryID: 9},
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository) {
+				categoryRepo.On("GetByID", mock.Anything, 9).Return(nil, domain.ErrCategoryNotFound)
+			},
+			expectedErr: domain.ErrCategoryNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := productRepositoryMock.NewMockProductRepository(t)
+			categoryRepo := categoryRepositoryMock.NewMockCategoryRepository(t)
+			tc.mockSetup(productRepo, categoryRepo)
+			usecase := NewProductUsecase(productRepo, categoryRepo)
+			page, err := usecase.ListProducts(context.Background(), tc.dto)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, page)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, page.Items, tc.expectedLen)
+			}
+		})
+	}
+}
+
+func TestSearchProducts(t *testing.T) {
+	tests := []struct {
+		name        string
+		dto         dtos.SearchProductsDTO
+		mockSetup   func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository)
+		expectedErr error
+		expectedLen int
+	}{
+		{
+			name: "trims text and applies default limit",
+			dto:  dtos.SearchProductsDTO{Q: "  red sho ", InStock: true},
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository) {
+				productRepo.On("Search", mock.Anything, domain.ProductSearchQuery{
+					Text:        "red sho",
+					InStockOnly: true,
+					Limit:       domain.DefaultPageLimit,
+				}).Return(&domain.ProductSearchResult{
+					Hits: []domain.ProductSearchHit{{Product: &domain.Product{ID: 3}, Rank: 0.6}},
+				}, nil)
+			},
+			expectedLen: 1,
+		},
+		{
+			name: "category includes subcategories",
+			dto:  dtos.SearchProductsDTO{Q: "shoe", CategoryID: 1, MinPrice: floatPtr(10), MaxPrice: floatPtr(50)},
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository) {
+				categoryRepo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1}, nil)
+				categoryRepo.On("ListDescendants", mock.Anything, 1, false).Return([]*domain.Category{{ID: 4}}, nil)
+				productRepo.On("Search", mock.Anything, domain.ProductSearchQuery{
+					Text:        "shoe",
+					CategoryIDs: []int{1, 4},
+					MinPrice:    floatPtr(10),
+					MaxPrice:    floatPtr(50),
+					Limit:       domain.DefaultPageLimit,
+				}).Return(&domain.ProductSearchResult{
+					Hits: []domain.ProductSearchHit{{Product: &domain.Product{ID: 3}}, {Product: &domain.Product{ID: 8}}},
+				}, nil)
+			},
+			expectedLen: 2,
+		},
+		{
+			name:        "empty text",
+			dto:         dtos.SearchProductsDTO{Q: "   "},
+			expectedErr: domain.ErrEmptySearchQuery,
+		},
+		{
+			name:        "min price above max price",
+			dto:         dtos.SearchProductsDTO{Q: "shoe", MinPrice: floatPtr(50), MaxPrice: floatPtr(10)},
+			expectedErr: domain.ErrInvalidPriceRange,
+		},
+		{
+			name:        "limit too large",
+			dto:         dtos.SearchProductsDTO{Q: "shoe", Limit: domain.MaxPageLimit + 1},
+			expectedErr: domain.ErrInvalidPageLimit,
+		},
+		{
+			name: "category not found",
+			dto:  dtos.SearchProductsDTO{Q: "shoe", CategoryID: 9},
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository) {
+				categoryRepo.On("GetByID", mock.Anything, 9).Return(nil, domain.ErrCategoryNotFound)
+			},
+			expectedErr: domain.ErrCategoryNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := productRepositoryMock.NewMockProductRepository(t)
+			categoryRepo := categoryRepositoryMock.NewMockCategoryRepository(t)
+			if tc.mockSetup != nil {
+				tc.mockSetup(productRepo, categoryRepo)
+			}
+			usecase := NewProductUsecase(productRepo, categoryRepo)
+			result, err := usecase.SearchProducts(context.Background(), tc.dto)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.Hits, tc.expectedLen)
+			}
+		})
+	}
+}
+
+func TestRestoreProduct(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          int
+		mockSetup   func(productRepo *productRepositoryMock.MockProductRepository)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			id:   1,
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository) {
+				productRepo.On("GetDeletedByID", mock.Anything, 1).Return(&domain.Product{ID: 1}, nil)
+				productRepo.On("Restore", mock.Anything, 1).Return(nil)
+				productRepo.On("GetByID", mock.Anything, 1).Return(&domain.Product{ID: 1}, nil)
+			},
+		},
+		{
+			name: "not deleted",
+			id:   1,
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository) {
+				productRepo.On("GetDeletedByID", mock.Anything, 1).Return(nil, domain.ErrProductNotFound)
+				productRepo.On("GetByID", mock.Anything, 1).Return(&domain.Product{ID: 1}, nil)
+			},
+			expectedErr: domain.ErrProductNotDeleted,
+		},
+		{
+			name: "not found",
+			id:   2,
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository) {
+				productRepo.On("GetDeletedByID", mock.Anything, 2).Return(nil, domain.ErrProductNotFound)
+				productRepo.On("GetByID", mock.Anything, 2).Return(nil, domain.ErrProductNotFound)
+			},
+			expectedErr: domain.ErrProductNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := productRepositoryMock.NewMockProductRepository(t)
+			tc.mockSetup(productRepo)
+			usecase := NewProductUsecase(productRepo, categoryRepositoryMock.NewMockCategoryRepository(t))
+			product, err := usecase.RestoreProduct(context.Background(), tc.id)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, product)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, product)
+			}
+		})
+	}
+}
+
+func TestCreateProduct(t *testing.T) {
+	dto := dtos.CreateProductDTO{Name: "Oak chair", SKU: "CHAIR-OAK-01", Price: 120, CategoryID: 2}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		mockSetup   func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			ctx:  context.Background(),
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository) {
+				categoryRepo.On("GetByID", mock.Anything, 2).Return(&domain.Category{ID: 2}, nil)
+				productRepo.On("Create", mock.Anything, &domain.Product{Name: "Oak chair", SKU: "CHAIR-OAK-01", Price: 120, CategoryID: 2}).Return(nil)
+			},
+		},
+		{
+			name: "category not found",
+			ctx:  context.Background(),
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository) {
+				categoryRepo.On("GetByID", mock.Anything, 2).Return(nil, domain.ErrCategoryNotFound)
+			},
+			expectedErr: domain.ErrCategoryNotFound,
+		},
+		{
+			name: "SKU taken",
+			ctx:  context.Background(),
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository) {
+				categoryRepo.On("GetByID", mock.Anything, 2).Return(&domain.Category{ID: 2}, nil)
+				productRepo.On("Create", mock.Anything, mock.Anything).Return(domain.ErrProductSKUTaken)
+			},
+			expectedErr: domain.ErrProductSKUTaken,
+		},
+		{
+			name:        "without products:write",
+			ctx:         principalContext(auth.RoleViewer),
+			mockSetup:   func(*productRepositoryMock.MockProductRepository, *categoryRepositoryMock.MockCategoryRepository) {},
+			expectedErr: auth.ErrForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := productRepositoryMock.NewMockProductRepository(t)
+			categoryRepo := categoryRepositoryMock.NewMockCategoryRepository(t)
+			tc.mockSetup(productRepo, categoryRepo)
+
+			product, err := NewProductUsecase(productRepo, categoryRepo).CreateProduct(tc.ctx, dto)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, product)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "CHAIR-OAK-01", product.SKU)
+		})
+	}
+}
+
+func TestUpdateProduct(t *testing.T) {
+	current := func() *domain.Product {
+		return &domain.Product{ID: 1, Name: "Chair", SKU: "CHAIR-01", Price: 100, CategoryID: 2}
+	}
+
+	tests := []struct {
+		name        string
+		dto         dtos.UpdateProductDTO
+		mockSetup   func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository)
+		expectedErr error
+		expectedSKU string
+	}{
+		{
+			name: "without SKU keeps the current one",
+			dto:  dtos.UpdateProductDTO{Name: "Oak chair", Price: 120, CategoryID: 2},
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository) {
+				productRepo.On("GetByID", mock.Anything, 1).Return(current(), nil)
+				productRepo.On("Update", mock.Anything, &domain.Product{ID: 1, Name: "Oak chair", SKU: "CHAIR-01", Price: 120, CategoryID: 2}).Return(nil)
+			},
+			expectedSKU: "CHAIR-01",
+		},
+		{
+			name: "new SKU and category",
+			dto:  dtos.UpdateProductDTO{Name: "Oak chair", SKU: "CHAIR-OAK-01", Price: 120, CategoryID: 3},
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository) {
+				productRepo.On("GetByID", mock.Anything, 1).Return(current(), nil)
+				categoryRepo.On("GetByID", mock.Anything, 3).Return(&domain.Category{ID: 3}, nil)
+				productRepo.On("Update", mock.Anything, &domain.Product{ID: 1, Name: "Oak chair", SKU: "CHAIR-OAK-01", Price: 120, CategoryID: 3}).Return(nil)
+			},
+			expectedSKU: "CHAIR-OAK-01",
+		},
+		{
+			name: "product not found",
+			dto:  dtos.UpdateProductDTO{Name: "Oak chair", CategoryID: 2},
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository) {
+				productRepo.On("GetByID", mock.Anything, 1).Return(nil, domain.ErrProductNotFound)
+			},
+			expectedErr: domain.ErrProductNotFound,
+		},
+		{
+			name: "category not found",
+			dto:  dtos.UpdateProductDTO{Name: "Oak chair", CategoryID: 9},
+			mockSetup: func(productRepo *productRepositoryMock.MockProductRepository, categoryRepo *categoryRepositoryMock.MockCategoryRepository) {
+				productRepo.On("GetByID", mock.Anything, 1).Return(current(), nil)
+				categoryRepo.On("GetByID", mock.Anything, 9).Return(nil, domain.ErrCategoryNotFound)
+			},
+			expectedErr: domain.ErrCategoryNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			productRepo := productRepositoryMock.NewMockProductRepository(t)
+			categoryRepo := categoryRepositoryMock.NewMockCategoryRepository(t)
+			tc.mockSetup(productRepo, categoryRepo)
+
+			product, err := NewProductUsecase(productRepo, categoryRepo).UpdateProduct(context.Background(), 1, tc.dto)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, product)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSKU, product.SKU)
+		})
+	}
+}
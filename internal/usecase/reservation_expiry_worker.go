@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"go.uber.org/zap"
+)
+
+// ReservationExpiryWorker expires the active reservations of every tenant
+// once their expiry has passed, returning their stock to the available
+// quantity. Several instances of the service may run a worker each; a
+// reservation is only expired by one of them.
+type ReservationExpiryWorker struct {
+	repo     domain.StockReservationRepository
+	counter  ReservationCounter
+	logger   *zap.Logger
+	interval time.Duration
+}
+
+// NewReservationExpiryWorker returns the worker; counter may be nil.
+func NewReservationExpiryWorker(repo domain.StockReservationRepository, counter ReservationCounter, logger *zap.Logger, interval time.Duration) *ReservationExpiryWorker {
+	return &ReservationExpiryWorker{
+		repo:     repo,
+		counter:  counter,
+		logger:   logger,
+		interval: interval,
+	}
+}
+
+// Run expires due reservations every interval until ctx is cancelled.
+func (w *ReservationExpiryWorker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		w.Poll(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll expires the reservations that are due now.
+func (w *ReservationExpiryWorker) Poll(ctx context.Context) {
+	expired, err := w.repo.ExpireDue(ctx, time.Now())
+	if err != nil {
+		w.logger.Error("Expiring reservations failed", zap.Error(err))
+		return
+	}
+	if len(expired) == 0 {
+		return
+	}
+	if w.counter != nil {
+		w.counter.CountReservations(domain.ReservationStatusExpired, len(expired))
+	}
+	for _, r := range expired {
+		w.logger.Info(
+			"Reservation expired",
+			zap.Int("reservationID", r.ID),
+			zap.String("tenantID", r.TenantID),
+			zap.Int("productID", r.ProductID),
+			zap.Int("quantity", r.ReservedQty),
+		)
+	}
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"go.uber.org/zap"
+)
+
+// ReservationCounter counts reservations as they enter a status: active
+// when created, then released or expired.
+type ReservationCounter interface {
+	CountReservations(status string, n int)
+}
+
+type ReservationUsecase struct {
+	repo           domain.StockReservationRepository
+	stockLevelRepo domain.StockLevelRepository
+	productRepo    domain.ProductRepository
+	transactor     domain.Transactor
+	counter        ReservationCounter
+}
+
+// NewReservationUsecase returns the use case; counter may be nil.
+func NewReservationUsecase(
+	repo domain.StockReservationRepository,
+	stockLevelRepo domain.StockLevelRepository,
+	productRepo domain.ProductRepository,
+	transactor domain.Transactor,
+	counter ReservationCounter,
+) *ReservationUsecase {
+	return &ReservationUsecase{
+		repo:           repo,
+		stockLevelRepo: stockLevelRepo,
+		productRepo:    productRepo,
+		transactor:     transactor,
+		counter:        counter,
+	}
+}
+
+// CreateReservation reserves units of a product on behalf of the caller.
+// The stock level of the product stays locked while the available quantity
+// is checked, so concurrent reservations cannot both take the last units.
+func (u *ReservationUsecase) CreateReservation(ctx context.Context, dto dtos.CreateReservationDTO) (_ *domain.StockReservation, err error) {
+	ctx, span := startSpan(ctx, "ReservationUsecase.CreateReservation")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermStockReserve); err != nil {
+		return nil, err
+	}
+	if dto.Quantity <= 0 {
+		return nil, domain.ErrInvalidMovementQty
+	}
+
+	reservation := &domain.StockReservation{
+		ProductID:   dto.ProductID,
+		ReservedQty: dto.Quantity,
+		ReferenceID: dto.ReferenceID,
+		ExpiresAt:   dto.ExpiresAt,
+		// The author comes from the verified token, never from the body.
+		UserID: auth.UserID(ctx),
+	}
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := u.productRepo.GetByID(ctx, dto.ProductID); err != nil {
+			return err
+		}
+		level, err := u.stockLevelRepo.LockByProductID(ctx, dto.ProductID)
+		if err != nil {
+			return err
+		}
+		active, err := u.repo.ListActiveByProduct(ctx, dto.ProductID)
+		if err != nil {
+			return err
+		}
+		balance := domain.StockBalance{ProductID: dto.ProductID, OnHand: level.Quantity}
+		for _, r := range active {
+			balance.Reserved += r.ReservedQty
+		}
+		if balance.Available() < dto.Quantity {
+			return domain.ErrInsufficientStock
+		}
+		return u.repo.Create(ctx, reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.count(domain.ReservationStatusActive, 1)
+
+	logging.FromContext(ctx).Info(
+		"Reservation created",
+		zap.Int("reservationID", reservation.ID),
+		zap.Int("productID", reservation.ProductID),
+		zap.Int("quantity", reservation.ReservedQty),
+		zap.String("referenceID", reservation.ReferenceID),
+	)
+	return reservation, nil
+}
+
+// ListActiveReservations returns the active reservations matching dto,
+// oldest first.
+func (u *ReservationUsecase) ListActiveReservations(ctx context.Context, dto dtos.ListReservationsDTO) (_ []*domain.StockReservation, err error) {
+	ctx, span := startSpan(ctx, "ReservationUsecase.ListActiveReservations")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermStockRead); err != nil {
+		return nil, err
+	}
+
+	var reservations []*domain.StockReservation
+	if dto.ProductID > 0 {
+		reservations, err = u.repo.ListActiveByProduct(ctx, dto.ProductID)
+	} else {
+		reservations, err = u.repo.ListActive(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if dto.ReservedBefore.IsZero() {
+		return reservations, nil
+	}
+
+	older := reservations[:0]
+	for _, r := range reservations {
+		if r.ReservedAt.Before(dto.ReservedBefore) {
+			older = append(older, r)
+		}
+	}
+	return older, nil
+}
+
+// ReleaseReservation releases an active reservation, returning its stock to
+// the available quantity. It is meant for reservations whose order never
+// completed nor cancelled them.
+func (u *ReservationUsecase) ReleaseReservation(ctx context.Context, id int) (_ *domain.StockReservation, err error) {
+	ctx, span := startSpan(ctx, "ReservationUsecase.ReleaseReservation")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermStockReserve); err != nil {
+		return nil, err
+	}
+
+	reservation, err := u.repo.Release(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	u.count(domain.ReservationStatusReleased, 1)
+
+	logging.FromContext(ctx).Info(
+		"Reservation released",
+		zap.Int("reservationID", reservation.ID),
+		zap.Int("productID", reservation.ProductID),
+		zap.Int("quantity", reservation.ReservedQty),
+		zap.String("userID", auth.UserID(ctx)),
+	)
+	return reservation, nil
+}
+
+func (u *ReservationUsecase) count(status string, n int) {
+	if u.counter != nil {
+		u.counter.CountReservations(status, n)
+	}
+}
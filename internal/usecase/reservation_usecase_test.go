@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	productRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/ProductRepository"
+	stockLevelRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockLevelRepository"
+	stockReservationRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockReservationRepository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestListActiveReservations(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	reservations := func() []*domain.StockReservation {
+		return []*domain.StockReservation{
+			{ID: 1, ProductID: 7, ReservedAt: now.Add(-48 * time.Hour)},
+			{ID: 2, ProductID: 7, ReservedAt: now.Add(-time.Hour)},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		dto         dtos.ListReservationsDTO
+		setupMock   func(repo *stockReservationRepositoryMock.MockStockReservationRepository)
+		expectedIDs []int
+	}{
+		{
+			name: "every product",
+			setupMock: func(repo *stockReservationRepositoryMock.MockStockReservationRepository) {
+				repo.On("ListActive", mock.Anything).Return(reservations(), nil)
+			},
+			expectedIDs: []int{1, 2},
+		},
+		{
+			name: "one product",
+			dto:  dtos.ListReservationsDTO{ProductID: 7},
+			setupMock: func(repo *stockReservationRepositoryMock.MockStockReservationRepository) {
+				repo.On("ListActiveByProduct", mock.Anything, 7).Return(reservations(), nil)
+			},
+			expectedIDs: []int{1, 2},
+		},
+		{
+			name: "reserved before",
+			dto:  dtos.ListReservationsDTO{ReservedBefore: now.Add(-24 * time.Hour)},
+			setupMock: func(repo *stockReservationRepositoryMock.MockStockReservationRepository) {
+				repo.On("ListActive", mock.Anything).Return(reservations(), nil)
+			},
+			expectedIDs: []int{1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := stockReservationRepositoryMock.NewMockStockReservationRepository(t)
+			tc.setupMock(repo)
+
+			found, err := NewReservationUsecase(repo, nil, nil, nil, nil).ListActiveReservations(context.Background(), tc.dto)
+
+			require.NoError(t, err)
+			var ids []int
+			for _, r := range found {
+				ids = append(ids, r.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestReleaseReservation(t *testing.T) {
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		setupMock   func(repo *stockReservationRepositoryMock.MockStockReservationRepository)
+		expectedErr error
+	}{
+		{
+			name: "active reservation",
+			ctx:  principalContext(auth.RoleService),
+			setupMock: func(repo *stockReservationRepositoryMock.MockStockReservationRepository) {
+				repo.On("Release", mock.Anything, 5).Return(&domain.StockReservation{ID: 5, Status: domain.ReservationStatusReleased}, nil)
+			},
+		},
+		{
+			name: "already released",
+			ctx:  principalContext(auth.RoleService),
+			setupMock: func(repo *stockReservationRepositoryMock.MockStockReservationRepository) {
+				repo.On("Release", mock.Anything, 5).Return(nil, domain.ErrReservationNotActive)
+			},
+			expectedErr: domain.ErrReservationNotActive,
+		},
+		{
+			name: "not found",
+			ctx:  principalContext(auth.RoleService),
+			setupMock: func(repo *stockReservationRepositoryMock.MockStockReservationRepository) {
+				repo.On("Release", mock.Anything, 5).Return(nil, domain.ErrReservationNotFound)
+			},
+			expectedErr: domain.ErrReservationNotFound,
+		},
+		{
+			name:        "without stock:reserve",
+			ctx:         principalContext(auth.RoleManager),
+			setupMock:   func(*stockReservationRepositoryMock.MockStockReservationRepository) {},
+			expectedErr: auth.ErrForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := stockReservationRepositoryMock.NewMockStockReservationRepository(t)
+			tc.setupMock(repo)
+
+			counter := &reservationCounter{}
+
+			reservation, err := NewReservationUsecase(repo, nil, nil, nil, counter).ReleaseReservation(tc.ctx, 5)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, reservation)
+				assert.Empty(t, counter.counts)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, domain.ReservationStatusReleased, reservation.Status)
+			assert.Equal(t, map[string]int{domain.ReservationStatusReleased: 1}, counter.counts)
+		})
+	}
+}
+
+func TestCreateReservation(t *testing.T) {
+	active := []*domain.StockReservation{{ID: 1, ProductID: 7, ReservedQty: 6}}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		quantity    int
+		setupMocks  func(repo *stockReservationRepositoryMock.MockStockReservationRepository, stockLevelRepo *stockLevelRepositoryMock.MockStockLevelRepository, productRepo *productRepositoryMock.MockProductRepository)
+		expectedErr error
+	}{
+		{
+			name:     "enough available",
+			ctx:      principalContext(auth.RoleService),
+			quantity: 4,
+			setupMocks: func(repo *stockReservationRepositoryMock.MockStockReservationRepository, stockLevelRepo *stockLevelRepositoryMock.MockStockLevelRepository, productRepo *productRepositoryMock.MockProductRepository) {
+				productRepo.On("GetByID", mock.MatchedBy(inTransaction), 7).Return(&domain.Product{ID: 7}, nil)
+				stockLevelRepo.On("LockByProductID", mock.MatchedBy(inTransaction), 7).Return(&domain.StockLevel{ProductID: 7, Quantity: 10}, nil)
+				repo.On("ListActiveByProduct", mock.MatchedBy(inTransaction), 7).Return(active, nil)
+				repo.On("Create", mock.MatchedBy(inTransaction), mock.MatchedBy(func(r *domain.StockReservation) bool {
+					return r.ProductID == 7 && r.ReservedQty == 4 && r.UserID == testUserID
+				})).Return(nil)
+			},
+		},
+		{
+			name:     "held by other reservations",
+			ctx:      principalContext(auth.RoleService),
+			quantity: 5,
+			setupMocks: func(repo *stockReservationRepositoryMock.MockStockReservationRepository, stockLevelRepo *stockLevelRepositoryMock.MockStockLevelRepository, productRepo *productRepositoryMock.MockProductRepository) {
+				productRepo.On("GetByID", mock.Anything, 7).Return(&domain.Product{ID: 7}, nil)
+				stockLevelRepo.On("LockByProductID", mock.Anything, 7).Return(&domain.StockLevel{ProductID: 7, Quantity: 10}, nil)
+				repo.On("ListActiveByProduct", mock.Anything, 7).Return(active, nil)
+			},
+			expectedErr: domain.ErrInsufficientStock,
+		},
+		{
+			name:     "unknown product",
+			ctx:      principalContext(auth.RoleService),
+			quantity: 1,
+			setupMocks: func(_ *stockReservationRepositoryMock.MockStockReservationRepository, _ *stockLevelRepositoryMock.MockStockLevelRepository, productRepo *productRepositoryMock.MockProductRepository) {
+				productRepo.On("GetByID", mock.Anything, 7).Return(nil, domain.ErrProductNotFound)
+			},
+			expectedErr: domain.ErrProductNotFound,
+		},
+		{
+			name:     "without stock:reserve",
+			ctx:      principalContext(auth.RoleClerk),
+			quantity: 1,
+			setupMocks: func(*stockReservationRepositoryMock.MockStockReservationRepository, *stockLevelRepositoryMock.MockStockLevelRepository, *productRepositoryMock.MockProductRepository) {
+			},
+			expectedErr: auth.ErrForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := stockReservationRepositoryMock.NewMockStockReservationRepository(t)
+			stockLevelRepo := stockLevelRepositoryMock.NewMockStockLevelRepository(t)
+			productRepo := productRepositoryMock.NewMockProductRepository(t)
+			tc.setupMocks(repo, stockLevelRepo, productRepo)
+			counter := &reservationCounter{}
+			usecase := NewReservationUsecase(repo, stockLevelRepo, productRepo, inlineTransactor(t), counter)
+
+			reservation, err := usecase.CreateReservation(tc.ctx, dtos.CreateReservationDTO{ProductID: 7, Quantity: tc.quantity})
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, reservation)
+				assert.Empty(t, counter.counts, "nothing is counted for a rejected reservation")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testUserID, reservation.UserID)
+			assert.Equal(t, map[string]int{domain.ReservationStatusActive: 1}, counter.counts)
+		})
+	}
+}
+
+func TestReservationExpiryWorkerCountsExpired(t *testing.T) {
+	repo := stockReservationRepositoryMock.NewMockStockReservationRepository(t)
+	repo.On("ExpireDue", mock.Anything, mock.AnythingOfType("time.Time")).Return([]*domain.StockReservation{
+		{ID: 1, TenantID: "acme", ProductID: 7, ReservedQty: 2},
+		{ID: 2, TenantID: "globex", ProductID: 3, ReservedQty: 1},
+	}, nil).Once()
+	repo.On("ExpireDue", mock.Anything, mock.AnythingOfType("time.Time")).Return(nil, errors.New("connection reset")).Once()
+	counter := &reservationCounter{}
+	worker := NewReservationExpiryWorker(repo, counter, zap.NewNop(), time.Minute)
+
+	worker.Poll(context.Background())
+	worker.Poll(context.Background())
+
+	assert.Equal(t, map[string]int{domain.ReservationStatusExpired: 2}, counter.counts, "a failed poll counts nothing")
+}
+
+// reservationCounter records the reservations counted by status.
+type reservationCounter struct {
+	counts map[string]int
+}
+
+func (c *reservationCounter) CountReservations(status string, n int) {
+	if c.counts == nil {
+		c.counts = make(map[string]int)
+	}
+	c.counts[status] += n
+}
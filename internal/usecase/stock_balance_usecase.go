@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+)
+
+type StockBalanceUsecase struct {
+	stockLevelRepo  domain.StockLevelRepository
+	reservationRepo domain.StockReservationRepository
+	productRepo     domain.ProductRepository
+}
+
+func NewStockBalanceUsecase(
+	stockLevelRepo domain.StockLevelRepository,
+	reservationRepo domain.StockReservationRepository,
+	productRepo domain.ProductRepository,
+) *StockBalanceUsecase {
+	return &StockBalanceUsecase{
+		stockLevelRepo:  stockLevelRepo,
+		reservationRepo: reservationRepo,
+		productRepo:     productRepo,
+	}
+}
+
+// GetBalances returns the balances of productIDs, in that order. Without
+// product IDs it returns the balance of every product with stock or
+// reservations, by product ID.
+func (u *StockBalanceUsecase) GetBalances(ctx context.Context, productIDs []int) (_ []domain.StockBalance, err error) {
+	ctx, span := startSpan(ctx, "StockBalanceUsecase.GetBalances")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermStockRead); err != nil {
+		return nil, err
+	}
+
+	if len(productIDs) == 0 {
+		return u.allBalances(ctx)
+	}
+
+	balances := make([]domain.StockBalance, 0, len(productIDs))
+	for _, id := range productIDs {
+		product, err := u.productRepo.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		balance := domain.StockBalance{ProductID: id, ProductName: product.Name}
+
+		level, err := u.stockLevelRepo.GetByProductID(ctx, id)
+		switch {
+		case err == nil:
+			balance.OnHand = level.Quantity
+		case !errors.Is(err, domain.ErrStockLevelNotFound):
+			return nil, err
+		}
+
+		reservations, err := u.reservationRepo.ListActiveByProduct(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range reservations {
+			balance.Reserved += r.ReservedQty
+		}
+		balances = append(balances, balance)
+	}
+	return balances, nil
+}
+
+func (u *StockBalanceUsecase) allBalances(ctx context.Context) ([]domain.StockBalance, error) {
+	levels, err := u.stockLevelRepo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reservations, err := u.reservationRepo.ListActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Deleted products may still hold stock.
+	products, err := u.productRepo.ListAll(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	byProduct := make(map[int]*domain.StockBalance)
+	for _, l := range levels {
+		byProduct[l.ProductID] = &domain.StockBalance{ProductID: l.ProductID, OnHand: l.Quantity}
+	}
+	for _, r := range reservations {
+		balance, ok := byProduct[r.ProductID]
+		if !ok {
+			balance = &domain.StockBalance{ProductID: r.ProductID}
+			byProduct[r.ProductID] = balance
+		}
+		balance.Reserved += r.ReservedQty
+	}
+
+	var balances []domain.StockBalance
+	for _, p := range products {
+		balance, ok := byProduct[p.ID]
+		if !ok {
+			continue
+		}
+		balance.ProductName = p.Name
+		balances = append(balances, *balance)
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		return balances[i].ProductID < balances[j].ProductID
+	})
+	return balances, nil
+}
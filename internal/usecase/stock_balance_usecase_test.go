@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	productRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/ProductRepository"
+	stockLevelRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockLevelRepository"
+	stockReservationRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockReservationRepository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBalances(t *testing.T) {
+	t.Run("every product", func(t *testing.T) {
+		levelRepo := stockLevelRepositoryMock.NewMockStockLevelRepository(t)
+		reservationRepo := stockReservationRepositoryMock.NewMockStockReservationRepository(t)
+		productRepo := productRepositoryMock.NewMockProductRepository(t)
+		levelRepo.On("ListAll", mock.Anything).Return([]*domain.StockLevel{
+			{ProductID: 1, Quantity: 10},
+			{ProductID: 2, Quantity: 4},
+		}, nil)
+		reservationRepo.On("ListActive", mock.Anything).Return([]*domain.StockReservation{
+			{ProductID: 1, ReservedQty: 3},
+			{ProductID: 1, ReservedQty: 2},
+			{ProductID: 3, ReservedQty: 1},
+		}, nil)
+		productRepo.On("ListAll", mock.Anything, true).Return([]*domain.Product{
+			{ID: 3, Name: "Adapter"},
+			{ID: 1, Name: "Cable"},
+			{ID: 2, Name: "Charger"},
+			{ID: 4, Name: "Unstocked"},
+		}, nil)
+
+		usecase := NewStockBalanceUsecase(levelRepo, reservationRepo, productRepo)
+		balances, err := usecase.GetBalances(context.Background(), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.StockBalance{
+			{ProductID: 1, ProductName: "Cable", OnHand: 10, Reserved: 5},
+			{ProductID: 2, ProductName: "Charger", OnHand: 4},
+			{ProductID: 3, ProductName: "Adapter", Reserved: 1},
+		}, balances)
+		assert.Equal(t, 5, balances[0].Available())
+		assert.Equal(t, -1, balances[2].Available())
+	})
+
+	t.Run("selected products", func(t *testing.T) {
+		levelRepo := stockLevelRepositoryMock.NewMockStockLevelRepository(t)
+		reservationRepo := stockReservationRepositoryMock.NewMockStockReservationRepository(t)
+		productRepo := productRepositoryMock.NewMockProductRepository(t)
+		productRepo.On("GetByID", mock.Anything, 2).Return(&domain.Product{ID: 2, Name: "Charger"}, nil)
+		productRepo.On("GetByID", mock.Anything, 1).Return(&domain.Product{ID: 1, Name: "Cable"}, nil)
+		levelRepo.On("GetByProductID", mock.Anything, 2).Return(nil, domain.ErrStockLevelNotFound)
+		levelRepo.On("GetByProductID", mock.Anything, 1).Return(&domain.StockLevel{ProductID: 1, Quantity: 10}, nil)
+		reservationRepo.On("ListActiveByProduct", mock.Anything, 2).Return(nil, nil)
+		reservationRepo.On("ListActiveByProduct", mock.Anything, 1).Return([]*domain.StockReservation{{ProductID: 1, ReservedQty: 4}}, nil)
+
+		usecase := NewStockBalanceUsecase(levelRepo, reservationRepo, productRepo)
+		balances, err := usecase.GetBalances(context.Background(), []int{2, 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.StockBalance{
+			{ProductID: 2, ProductName: "Charger"},
+			{ProductID: 1, ProductName: "Cable", OnHand: 10, Reserved: 4},
+		}, balances)
+	})
+
+	t.Run("unknown product", func(t *testing.T) {
+		productRepo := productRepositoryMock.NewMockProductRepository(t)
+		productRepo.On("GetByID", mock.Anything, 9).Return(nil, domain.ErrProductNotFound)
+
+		usecase := NewStockBalanceUsecase(
+			stockLevelRepositoryMock.NewMockStockLevelRepository(t),
+			stockReservationRepositoryMock.NewMockStockReservationRepository(t),
+			productRepo,
+		)
+		_, err := usecase.GetBalances(context.Background(), []int{9})
+
+		assert.ErrorIs(t, err, domain.ErrProductNotFound)
+	})
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"go.uber.org/zap"
+)
+
+// StockLevelObserver is told the stock level of a product once a movement
+// changing it is committed.
+type StockLevelObserver interface {
+	ObserveStockLevel(stockLevel *domain.StockLevel)
+}
+
+type StockMovementUsecase struct {
+	movementRepo   domain.StockMovementRepository
+	stockLevelRepo domain.StockLevelRepository
+	costLayerRepo  domain.CostLayerRepository
+	productRepo    domain.ProductRepository
+	transactor     domain.Transactor
+	observer       StockLevelObserver
+}
+
+// NewStockMovementUsecase returns the use case; observer may be nil.
+func NewStockMovementUsecase(
+	movementRepo domain.StockMovementRepository,
+	stockLevelRepo domain.StockLevelRepository,
+	costLayerRepo domain.CostLayerRepository,
+	productRepo domain.ProductRepository,
+	transactor domain.Transactor,
+	observer StockLevelObserver,
+) *StockMovementUsecase {
+	return &StockMovementUsecase{
+		movementRepo:   movementRepo,
+		stockLevelRepo: stockLevelRepo,
+		costLayerRepo:  costLayerRepo,
+		productRepo:    productRepo,
+		transactor:     transactor,
+		observer:       observer,
+	}
+}
+
+// RecordMovement appends a movement to the ledger, applies it to the stock
+// level and keeps the product's cost layers in sync, all in one
+// transaction.
+func (u *StockMovementUsecase) RecordMovement(ctx context.Context, dto dtos.CreateStockMovementDTO) (_ *domain.StockMovement, err error) {
+	ctx, span := startSpan(ctx, "StockMovementUsecase.RecordMovement")
+	defer func() { endSpan(span, err) }()
+
+	if err := auth.Authorize(ctx, auth.PermStockWrite); err != nil {
+		return nil, err
+	}
+
+	if err := validateMovement(dto); err != nil {
+		return nil, err
+	}
+	if dto.MovementType == domain.MovementTypeAdjustment {
+		if err := auth.AuthorizeAdjustment(ctx, dto.Quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	movement := &domain.StockMovement{
+		ProductID:    dto.ProductID,
+		MovementType: dto.MovementType,
+		Quantity:     dto.Quantity,
+		UnitCost:     dto.UnitCost,
+		Reason:       dto.Reason,
+		// The author comes from the verified token, never from the body.
+		UserID: auth.UserID(ctx),
+	}
+
+	var level *domain.StockLevel
+	err = u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		level, err = u.applyMovement(ctx, movement)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Only now is the level committed, unless the caller's own transaction
+	// was joined.
+	if u.observer != nil {
+		u.observer.ObserveStockLevel(level)
+	}
+
+	return movement, nil
+}
+
+// applyMovement writes movement, its cost layers and the new stock level,
+// which it returns. The stock level and the open cost layers of the product stay locked until
+// the transaction ctx runs in ends, so concurrent movements cannot both
+// spend the same stock.
+func (u *StockMovementUsecase) applyMovement(ctx context.Context, movement *domain.StockMovement) (*domain.StockLevel, error) {
+	if _, err := u.productRepo.GetByID(ctx, movement.ProductID); err != nil {
+		return nil, err
+	}
+
+	level, err := u.stockLevelRepo.LockByProductID(ctx, movement.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	delta := movement.SignedQuantity()
+	if level.Quantity+delta < 0 {
+		return nil, domain.ErrInsufficientStock
+	}
+
+	layers, err := u.costLayerRepo.ListOpenByProductID(ctx, movement.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	var consumed []*domain.CostLayer
+	if delta < 0 {
+		consumed, err = domain.ConsumeLayers(layers, -delta)
+		if err != nil {
+			return nil, err
+		}
+	} else if movement.UnitCost == nil {
+		cost := domain.AverageLayerCost(layers)
+		movement.UnitCost = &cost
+	}
+
+	if err := u.movementRepo.Create(ctx, movement); err != nil {
+		return nil, err
+	}
+
+	if delta > 0 {
+		layer := &domain.CostLayer{
+			ProductID:    movement.ProductID,
+			MovementID:   movement.ID,
+			Quantity:     delta,
+			RemainingQty: delta,
+			UnitCost:     *movement.UnitCost,
+			ReceivedAt:   movement.CreatedAt,
+		}
+		if err := u.costLayerRepo.Create(ctx, layer); err != nil {
+			return nil, err
+		}
+	}
+	for _, layer := range consumed {
+		if err := u.costLayerRepo.UpdateRemainingQty(ctx, layer.ID, layer.RemainingQty); err != nil {
+			return nil, err
+		}
+	}
+
+	previousQty := level.Quantity
+	level.Quantity += delta
+	if err := u.stockLevelRepo.UpdateQuantity(ctx, level); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Debug(
+		"Stock level updated",
+		zap.Int("productID", level.ProductID),
+		zap.Int("previousQuantity", previousQty),
+		zap.Int("quantity", level.Quantity),
+		zap.Int("costLayersConsumed", len(consumed)),
+	)
+	return level, nil
+}
+
+func (u *StockMovementUsecase) ListMovementsByProduct(ctx context.Context, productID int, dto dtos.ListStockMovementsDTO) (_ *domain.Page[*domain.StockMovement], err error) {
+	ctx, span := startSpan(ctx, "StockMovementUsecase.ListMovementsByProduct")
+	defer func() { endSpan(span, err) }()
+
+	query, err := toListQuery(dto.ListQueryDTO, false, stockMovementSortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	return u.movementRepo.ListByProductID(ctx, productID, query)
+}
+
+func validateMovement(dto dtos.CreateStockMovementDTO) error {
+	switch dto.MovementType {
+	case domain.MovementTypeReceipt:
+		if dto.Quantity <= 0 {
+			return domain.ErrInvalidMovementQty
+		}
+		if dto.UnitCost == nil || *dto.UnitCost < 0 {
+			return domain.ErrMissingUnitCost
+		}
+	case domain.MovementTypeIssue:
+		if dto.Quantity <= 0 {
+			return domain.ErrInvalidMovementQty
+		}
+	case domain.MovementTypeAdjustment:
+		if dto.Quantity == 0 {
+			return domain.ErrInvalidMovementQty
+		}
+	default:
+		return domain.ErrInvalidMovementType
+	}
+	return nil
+}
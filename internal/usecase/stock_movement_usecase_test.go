@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/app/dtos"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/auth"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	costLayerRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/CostLayerRepository"
+	productRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/ProductRepository"
+	stockLevelRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockLevelRepository"
+	stockMovementRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockMovementRepository"
+	transactorMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/Transactor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type stockMovementMocks struct {
+	movementRepo   *stockMovementRepositoryMock.MockStockMovementRepository
+	stockLevelRepo *stockLevelRepositoryMock.MockStockLevelRepository
+	costLayerRepo  *costLayerRepositoryMock.MockCostLayerRepository
+	productRepo    *productRepositoryMock.MockProductRepository
+	transactor     *transactorMock.MockTransactor
+}
+
+// testUserID is the user authenticated in the movement tests; movements are
+// attributed to it.
+const testUserID = "7f7a3c9e-0d7c-4f7e-9a53-5f0a2b2d6a10"
+
+// principalContext authenticates testUserID with roles under the default
+// policy.
+func principalContext(roles ...string) context.Context {
+	claims := auth.Claims{Roles: roles}
+	claims.Subject = "auth0|42"
+	return auth.WithPrincipal(context.Background(), auth.NewPrincipal(claims, testUserID, auth.DefaultPolicy()))
+}
+
+type inTransactionKey struct{}
+
+// inlineTransactor returns a transactor running units of work in place,
+// with a context inTransaction recognizes.
+func inlineTransactor(t *testing.T) *transactorMock.MockTransactor {
+	transactor := transactorMock.NewMockTransactor(t)
+	transactor.On("WithinTransaction", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(context.WithValue(ctx, inTransactionKey{}, true))
+		}).
+		Maybe()
+	return transactor
+}
+
+// inTransaction tells whether ctx was handed out by inlineTransactor.
+func inTransaction(ctx context.Context) bool {
+	in, _ := ctx.Value(inTransactionKey{}).(bool)
+	return in
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestRecordMovement(t *testing.T) {
+	tests := []struct {
+		name        string
+		dto         dtos.CreateStockMovementDTO
+		role        string
+		mockSetup   func(m stockMovementMocks)
+		expectedErr error
+	}{
+		{
+			name: "receipt creates cost layer and stock level",
+			dto:  dtos.CreateStockMovementDTO{ProductID: 1, MovementType: domain.MovementTypeReceipt, Quantity: 10, UnitCost: floatPtr(2.5)},
+			mockSetup: func(m stockMovementMocks) {
+				m.productRepo.On("GetByID", mock.Anything, 1).Return(&domain.Product{ID: 1}, nil)
+				m.stockLevelRepo.On("LockByProductID", mock.Anything, 1).Return(&domain.StockLevel{ProductID: 1}, nil)
+				m.costLayerRepo.On("ListOpenByProductID", mock.Anything, 1).Return([]*domain.CostLayer{}, nil)
+				m.movementRepo.On("Create", mock.Anything, mock.MatchedBy(func(mv *domain.StockMovement) bool {
+					return mv.UserID == testUserID
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*domain.StockMovement).ID = 7
+				}).Return(nil)
+				m.costLayerRepo.On("Create", mock.Anything, mock.MatchedBy(func(l *domain.CostLayer) bool {
+					return l.MovementID == 7 && l.Quantity == 10 && l.RemainingQty == 10 && l.UnitCost == 2.5
+				})).Return(nil)
+				m.stockLevelRepo.On("UpdateQuantity", mock.Anything, mock.MatchedBy(func(s *domain.StockLevel) bool {
+					return s.ProductID == 1 && s.Quantity == 10
+				})).Return(nil)
+			},
+		},
+		{
+			name: "issue consumes oldest layers first",
+			dto:  dtos.CreateStockMovementDTO{ProductID: 1, MovementType: domain.MovementTypeIssue, Quantity: 6},
+			mockSetup: func(m stockMovementMocks) {
+				m.productRepo.On("GetByID", mock.Anything, 1).Return(&domain.Product{ID: 1}, nil)
+				m.stockLevelRepo.On("LockByProductID", mock.Anything, 1).Return(&domain.StockLevel{ProductID: 1, Quantity: 8}, nil)
+				m.costLayerRepo.On("ListOpenByProductID", mock.Anything, 1).Return([]*domain.CostLayer{
+					{ID: 1, Quantity: 5, RemainingQty: 5, UnitCost: 1},
+					{ID: 2, Quantity: 3, RemainingQty: 3, UnitCost: 2},
+				}, nil)
+				m.movementRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.costLayerRepo.On("UpdateRemainingQty", mock.Anything, 1, 0).Return(nil)
+				m.costLayerRepo.On("UpdateRemainingQty", mock.Anything, 2, 2).Return(nil)
+				m.stockLevelRepo.On("UpdateQuantity", mock.Anything, mock.MatchedBy(func(s *domain.StockLevel) bool {
+					return s.Quantity == 2
+				})).Return(nil)
+			},
+		},
+		{
+			name: "positive adjustment without cost uses average layer cost",
+			dto:  dtos.CreateStockMovementDTO{ProductID: 1, MovementType: domain.MovementTypeAdjustment, Quantity: 2},
+			mockSetup: func(m stockMovementMocks) {
+				m.productRepo.On("GetByID", mock.Anything, 1).Return(&domain.Product{ID: 1}, nil)
+				m.stockLevelRepo.On("LockByProductID", mock.Anything, 1).Return(&domain.StockLevel{ProductID: 1, Quantity: 4}, nil)
+				m.costLayerRepo.On("ListOpenByProductID", mock.Anything, 1).Return([]*domain.CostLayer{
+					{ID: 1, Quantity: 2, RemainingQty: 2, UnitCost: 1},
+					{ID: 2, Quantity: 2, RemainingQty: 2, UnitCost: 3},
+				}, nil)
+				m.movementRepo.On("Create", mock.Anything, mock.MatchedBy(func(mv *domain.StockMovement) bool {
+					return mv.UnitCost != nil && *mv.UnitCost == 2
+				})).Return(nil)
+				m.costLayerRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.stockLevelRepo.On("UpdateQuantity", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:        "receipt without unit cost",
+			dto:         dtos.CreateStockMovementDTO{ProductID: 1, MovementType: domain.MovementTypeReceipt, Quantity: 1},
+			mockSetup:   func(m stockMovementMocks) {},
+			expectedErr: domain.ErrMissingUnitCost,
+		},
+		{
+			name:        "invalid movement type",
+			dto:         dtos.CreateStockMovementDTO{ProductID: 1, MovementType: "transfer", Quantity: 1},
+			mockSetup:   func(m stockMovementMocks) {},
+			expectedErr: domain.ErrInvalidMovementType,
+		},
+		{
+			name:        "zero adjustment",
+			dto:         dtos.CreateStockMovementDTO{ProductID: 1, MovementType: domain.MovementTypeAdjustment},
+			mockSetup:   func(m stockMovementMocks) {},
+			expectedErr: domain.ErrInvalidMovementQty,
+		},
+		{
+			name: "product not found",
+			dto:  dtos.CreateStockMovementDTO{ProductID: 9, MovementType: domain.MovementTypeIssue, Quantity: 1},
+			mockSetup: func(m stockMovementMocks) {
+				m.productRepo.On("GetByID", mock.Anything, 9).Return(nil, domain.ErrProductNotFound)
+			},
+			expectedErr: domain.ErrProductNotFound,
+		},
+		{
+			name: "insufficient stock",
+			dto:  dtos.CreateStockMovementDTO{ProductID: 1, MovementType: domain.MovementTypeIssue, Quantity: 5},
+			mockSetup: func(m stockMovementMocks) {
+				m.productRepo.On("GetByID", mock.Anything, 1).Return(&domain.Product{ID: 1}, nil)
+				m.stockLevelRepo.On("LockByProductID", mock.Anything, 1).Return(&domain.StockLevel{ProductID: 1, Quantity: 4}, nil)
+			},
+			expectedErr: domain.ErrInsufficientStock,
+		},
+		{
+			name:        "clerk cannot make a large adjustment",
+			dto:         dtos.CreateStockMovementDTO{ProductID: 1, MovementType: domain.MovementTypeAdjustment, Quantity: -500},
+			mockSetup:   func(m stockMovementMocks) {},
+			expectedErr: auth.ErrForbidden,
+		},
+		{
+			name: "manager makes a large adjustment",
+			dto:  dtos.CreateStockMovementDTO{ProductID: 1, MovementType: domain.MovementTypeAdjustment, Quantity: -500},
+			role: auth.RoleManager,
+			mockSetup: func(m stockMovementMocks) {
+				m.productRepo.On("GetByID", mock.Anything, 1).Return(&domain.Product{ID: 1}, nil)
+				m.stockLevelRepo.On("LockByProductID", mock.Anything, 1).Return(&domain.StockLevel{ProductID: 1, Quantity: 600}, nil)
+				m.costLayerRepo.On("ListOpenByProductID", mock.Anything, 1).Return([]*domain.CostLayer{
+					{ID: 1, Quantity: 600, RemainingQty: 600, UnitCost: 1},
+				}, nil)
+				m.movementRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.costLayerRepo.On("UpdateRemainingQty", mock.Anything, 1, 100).Return(nil)
+				m.stockLevelRepo.On("UpdateQuantity", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:        "viewer cannot record movements",
+			dto:         dtos.CreateStockMovementDTO{ProductID: 1, MovementType: domain.MovementTypeIssue, Quantity: 1},
+			role:        auth.RoleViewer,
+			mockSetup:   func(m stockMovementMocks) {},
+			expectedErr: auth.ErrForbidden,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := stockMovementMocks{
+				movementRepo:   stockMovementRepositoryMock.NewMockStockMovementRepository(t),
+				stockLevelRepo: stockLevelRepositoryMock.NewMockStockLevelRepository(t),
+				costLayerRepo:  costLayerRepositoryMock.NewMockCostLayerRepository(t),
+				productRepo:    productRepositoryMock.NewMockProductRepository(t),
+				transactor:     inlineTransactor(t),
+			}
+			tc.mockSetup(m)
+			usecase := NewStockMovementUsecase(m.movementRepo, m.stockLevelRepo, m.costLayerRepo, m.productRepo, m.transactor, nil)
+			role := tc.role
+			if role == "" {
+				role = auth.RoleClerk
+			}
+			movement, err := usecase.RecordMovement(principalContext(role), tc.dto)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, movement)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, movement)
+			}
+		})
+	}
+}
+
+func TestRecordMovementRunsInOneTransaction(t *testing.T) {
+	m := stockMovementMocks{
+		movementRepo:   stockMovementRepositoryMock.NewMockStockMovementRepository(t),
+		stockLevelRepo: stockLevelRepositoryMock.NewMockStockLevelRepository(t),
+		costLayerRepo:  costLayerRepositoryMock.NewMockCostLayerRepository(t),
+		productRepo:    productRepositoryMock.NewMockProductRepository(t),
+		transactor:     inlineTransactor(t),
+	}
+	inTx := mock.MatchedBy(inTransaction)
+	m.productRepo.On("GetByID", inTx, 1).Return(&domain.Product{ID: 1}, nil)
+	m.stockLevelRepo.On("LockByProductID", inTx, 1).Return(&domain.StockLevel{ProductID: 1, Quantity: 3}, nil)
+	m.costLayerRepo.On("ListOpenByProductID", inTx, 1).Return([]*domain.CostLayer{
+		{ID: 1, Quantity: 3, RemainingQty: 3, UnitCost: 1},
+	}, nil)
+	m.movementRepo.On("Create", inTx, mock.Anything).Return(nil)
+	m.costLayerRepo.On("UpdateRemainingQty", inTx, 1, 1).Return(nil)
+	writeErr := errors.New("connection reset")
+	m.stockLevelRepo.On("UpdateQuantity", inTx, mock.Anything).Return(writeErr)
+	observer := &levelObserver{}
+	usecase := NewStockMovementUsecase(m.movementRepo, m.stockLevelRepo, m.costLayerRepo, m.productRepo, m.transactor, observer)
+
+	movement, err := usecase.RecordMovement(principalContext(auth.RoleClerk), dtos.CreateStockMovementDTO{
+		ProductID: 1, MovementType: domain.MovementTypeIssue, Quantity: 2,
+	})
+
+	assert.ErrorIs(t, err, writeErr, "the failure rolls the whole movement back")
+	assert.Nil(t, movement)
+	assert.Empty(t, observer.observed, "a rolled back level is not observed")
+}
+
+// committingTransactor runs units of work in place and records when they
+// commit.
+type committingTransactor struct {
+	committed bool
+}
+
+func (t *committingTransactor) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	t.committed = true
+	return nil
+}
+
+// levelObserver records the observed stock levels and whether their
+// transaction had committed by then.
+type levelObserver struct {
+	tx       *committingTransactor
+	observed []domain.StockLevel
+	early    bool
+}
+
+func (o *levelObserver) ObserveStockLevel(stockLevel *domain.StockLevel) {
+	o.observed = append(o.observed, *stockLevel)
+	o.early = o.early || (o.tx != nil && !o.tx.committed)
+}
+
+func TestRecordMovementObservesTheCommittedLevel(t *testing.T) {
+	m := stockMovementMocks{
+		movementRepo:   stockMovementRepositoryMock.NewMockStockMovementRepository(t),
+		stockLevelRepo: stockLevelRepositoryMock.NewMockStockLevelRepository(t),
+		costLayerRepo:  costLayerRepositoryMock.NewMockCostLayerRepository(t),
+		productRepo:    productRepositoryMock.NewMockProductRepository(t),
+	}
+	m.productRepo.On("GetByID", mock.Anything, 1).Return(&domain.Product{ID: 1}, nil)
+	m.stockLevelRepo.On("LockByProductID", mock.Anything, 1).Return(&domain.StockLevel{ProductID: 1, Quantity: 3}, nil)
+	m.costLayerRepo.On("ListOpenByProductID", mock.Anything, 1).Return(nil, nil)
+	m.movementRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	m.costLayerRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	m.stockLevelRepo.On("UpdateQuantity", mock.Anything, mock.Anything).Return(nil)
+	tx := &committingTransactor{}
+	observer := &levelObserver{tx: tx}
+	usecase := NewStockMovementUsecase(m.movementRepo, m.stockLevelRepo, m.costLayerRepo, m.productRepo, tx, observer)
+
+	_, err := usecase.RecordMovement(principalContext(auth.RoleClerk), dtos.CreateStockMovementDTO{
+		ProductID: 1, MovementType: domain.MovementTypeReceipt, Quantity: 2, UnitCost: floatPtr(1),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.StockLevel{{ProductID: 1, Quantity: 5}}, observer.observed)
+	assert.False(t, observer.early, "the level is observed once committed")
+}
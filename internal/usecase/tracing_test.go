@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	categoryRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/CategoryRepository"
+	productRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/ProductRepository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestUsecaseSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "GET /categories/:id")
+
+	categoryRepo := categoryRepositoryMock.NewMockCategoryRepository(t)
+	categoryRepo.On("GetByID", mock.Anything, 1).Return(&domain.Category{ID: 1}, nil)
+	categoryRepo.On("GetByID", mock.Anything, 2).Return(nil, domain.ErrCategoryNotFound)
+	uc := NewCategoryUsecase(categoryRepo, productRepositoryMock.NewMockProductRepository(t), inlineTransactor(t))
+
+	_, err := uc.GetCategoryByID(ctx, 1)
+	require.NoError(t, err)
+	_, err = uc.GetCategoryByID(ctx, 2)
+	require.ErrorIs(t, err, domain.ErrCategoryNotFound)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	for _, span := range spans[:2] {
+		assert.Equal(t, "CategoryUsecase.GetCategoryByID", span.Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID(), "use case spans are children of the request span")
+	}
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, domain.ErrCategoryNotFound.Error(), spans[1].Status().Description)
+}
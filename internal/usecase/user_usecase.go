@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"go.uber.org/zap"
+)
+
+type UserUsecase struct {
+	repo domain.UserRepository
+}
+
+func NewUserUsecase(repo domain.UserRepository) *UserUsecase {
+	return &UserUsecase{repo: repo}
+}
+
+// ResolveUser returns the user with identity's subject, provisioning it from
+// identity on its first request.
+func (u *UserUsecase) ResolveUser(ctx context.Context, identity domain.User) (_ *domain.User, err error) {
+	ctx, span := startSpan(ctx, "UserUsecase.ResolveUser")
+	defer func() { endSpan(span, err) }()
+
+	user, err := u.repo.GetBySubject(ctx, identity.Subject)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+
+	if identity.UserName == "" {
+		identity.UserName = identity.Subject
+	}
+	user, err = u.repo.CreateIfMissing(ctx, &identity)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info(
+		"User provisioned",
+		zap.String("userID", user.ID),
+		zap.String("subject", user.Subject),
+	)
+	return user, nil
+}
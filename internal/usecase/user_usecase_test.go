@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	userRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/UserRepository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResolveUser(t *testing.T) {
+	existing := &domain.User{ID: "u-1", Subject: "auth0|42", UserName: "ana"}
+
+	tests := []struct {
+		name         string
+		identity     domain.User
+		mockSetup    func(repo *userRepositoryMock.MockUserRepository)
+		expectedErr  error
+		expectedUser string
+	}{
+		{
+			name:     "known subject",
+			identity: domain.User{Subject: "auth0|42"},
+			mockSetup: func(repo *userRepositoryMock.MockUserRepository) {
+				repo.On("GetBySubject", mock.Anything, "auth0|42").Return(existing, nil)
+			},
+			expectedUser: "u-1",
+		},
+		{
+			name:     "new subject is provisioned",
+			identity: domain.User{Subject: "auth0|43", Email: "bo@nexusmarket.test"},
+			mockSetup: func(repo *userRepositoryMock.MockUserRepository) {
+				repo.On("GetBySubject", mock.Anything, "auth0|43").Return(nil, domain.ErrUserNotFound)
+				repo.On("CreateIfMissing", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+					// Tokens without a username fall back to the subject.
+					return u.Subject == "auth0|43" && u.UserName == "auth0|43" && u.Email == "bo@nexusmarket.test"
+				})).Return(&domain.User{ID: "u-2", Subject: "auth0|43"}, nil)
+			},
+			expectedUser: "u-2",
+		},
+		{
+			name:     "lookup failure",
+			identity: domain.User{Subject: "auth0|42"},
+			mockSetup: func(repo *userRepositoryMock.MockUserRepository) {
+				repo.On("GetBySubject", mock.Anything, "auth0|42").Return(nil, errors.New("connection refused"))
+			},
+			expectedErr: errors.New("connection refused"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := userRepositoryMock.NewMockUserRepository(t)
+			tc.mockSetup(repo)
+
+			user, err := NewUserUsecase(repo).ResolveUser(context.Background(), tc.identity)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+				assert.Nil(t, user)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedUser, user.ID)
+		})
+	}
+}
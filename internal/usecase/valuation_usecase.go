@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/logging"
+	"go.uber.org/zap"
+)
+
+const uncategorizedName = "Uncategorized"
+
+type ValuationUsecase struct {
+	movementRepo  domain.StockMovementRepository
+	costLayerRepo domain.CostLayerRepository
+	productRepo   domain.ProductRepository
+	categoryRepo  domain.CategoryRepository
+}
+
+func NewValuationUsecase(
+	movementRepo domain.StockMovementRepository,
+	costLayerRepo domain.CostLayerRepository,
+	productRepo domain.ProductRepository,
+	categoryRepo domain.CategoryRepository,
+) *ValuationUsecase {
+	return &ValuationUsecase{
+		movementRepo:  movementRepo,
+		costLayerRepo: costLayerRepo,
+		productRepo:   productRepo,
+		categoryRepo:  categoryRepo,
+	}
+}
+
+// GetValuation values the stock on hand at asOf by replaying the movement
+// ledger with the requested costing method. Stock carried over from before
+// cost tracking, which the ledger may not account for, enters the replay
+// as an opening movement.
+func (u *ValuationUsecase) GetValuation(ctx context.Context, method string, asOf time.Time) (_ *domain.ValuationReport, err error) {
+	ctx, span := startSpan(ctx, "ValuationUsecase.GetValuation")
+	defer func() { endSpan(span, err) }()
+
+	valuationMethod, err := domain.ParseValuationMethod(method)
+	if err != nil {
+		return nil, err
+	}
+
+	openings, err := u.costLayerRepo.ListOpening(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Telling the opening stock from the recorded one takes the movements
+	// until the opening layers were created, even past asOf.
+	until := asOf
+	for _, layer := range openings {
+		if layer.ReceivedAt.After(until) {
+			until = layer.ReceivedAt
+		}
+	}
+	movements, err := u.movementRepo.ListUntil(ctx, until)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deleted products may still have had stock on the valuation date.
+	products, err := u.productRepo.ListAll(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := u.categoryRepo.ListAll(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	movementsByProduct := make(map[int][]*domain.StockMovement)
+	for _, m := range movements {
+		movementsByProduct[m.ProductID] = append(movementsByProduct[m.ProductID], m)
+	}
+	for _, layer := range openings {
+		productMovements := movementsByProduct[layer.ProductID]
+		if opening := domain.OpeningMovement(layer, productMovements); opening != nil {
+			movementsByProduct[layer.ProductID] = append([]*domain.StockMovement{opening}, productMovements...)
+		}
+	}
+	for productID, productMovements := range movementsByProduct {
+		n := sort.Search(len(productMovements), func(i int) bool {
+			return productMovements[i].CreatedAt.After(asOf)
+		})
+		if n == 0 {
+			delete(movementsByProduct, productID)
+			continue
+		}
+		movementsByProduct[productID] = productMovements[:n]
+	}
+
+	categoryNames := make(map[int]string, len(categories))
+	for _, c := range categories {
+		categoryNames[c.ID] = c.Name
+	}
+
+	report := &domain.ValuationReport{Method: valuationMethod, AsOf: asOf}
+	byCategory := make(map[int]*domain.CategoryValuation)
+	for _, p := range products {
+		productMovements, ok := movementsByProduct[p.ID]
+		if !ok {
+			continue
+		}
+
+		qty, value := domain.ValueMovements(valuationMethod, productMovements)
+		categoryName, ok := categoryNames[p.CategoryID]
+		if !ok {
+			categoryName = uncategorizedName
+		}
+
+		cv, ok := byCategory[p.CategoryID]
+		if !ok {
+			cv = &domain.CategoryValuation{CategoryID: p.CategoryID, CategoryName: categoryName}
+			byCategory[p.CategoryID] = cv
+		}
+		cv.Products = append(cv.Products, domain.ProductValuation{
+			ProductID:    p.ID,
+			ProductName:  p.Name,
+			CategoryID:   p.CategoryID,
+			CategoryName: categoryName,
+			Quantity:     qty,
+			TotalValue:   value,
+		})
+		cv.Quantity += qty
+		cv.TotalValue += value
+		report.TotalValue += value
+	}
+
+	for _, cv := range byCategory {
+		sort.Slice(cv.Products, func(i, j int) bool {
+			return cv.Products[i].ProductName < cv.Products[j].ProductName
+		})
+		report.Categories = append(report.Categories, *cv)
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		return report.Categories[i].CategoryName < report.Categories[j].CategoryName
+	})
+
+	logging.FromContext(ctx).Debug(
+		"Valuation computed",
+		zap.String("method", string(valuationMethod)),
+		zap.Time("asOf", asOf),
+		zap.Int("movements", len(movements)),
+		zap.Int("openingLayers", len(openings)),
+		zap.Int("categories", len(report.Categories)),
+	)
+
+	return report, nil
+}
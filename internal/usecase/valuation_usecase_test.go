@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ch94Ca/ms-nexusMarket-inventory/internal/domain"
+	categoryRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/CategoryRepository"
+	costLayerRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/CostLayerRepository"
+	productRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/ProductRepository"
+	stockMovementRepositoryMock "github.com/Ch94Ca/ms-nexusMarket-inventory/internal/mocks/domain/StockMovementRepository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetValuation(t *testing.T) {
+	asOf := time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC)
+	movements := []*domain.StockMovement{
+		{ProductID: 1, MovementType: domain.MovementTypeReceipt, Quantity: 10, UnitCost: floatPtr(1)},
+		{ProductID: 1, MovementType: domain.MovementTypeReceipt, Quantity: 10, UnitCost: floatPtr(2)},
+		{ProductID: 1, MovementType: domain.MovementTypeIssue, Quantity: 15},
+		{ProductID: 2, MovementType: domain.MovementTypeReceipt, Quantity: 4, UnitCost: floatPtr(5)},
+	}
+	products := []*domain.Product{
+		{ID: 1, Name: "Cable", CategoryID: 10},
+		{ID: 2, Name: "Adapter", CategoryID: 10},
+		{ID: 3, Name: "Unused", CategoryID: 20},
+	}
+	categories := []*domain.Category{{ID: 10, Name: "Electronics"}, {ID: 20, Name: "Books"}}
+
+	tests := []struct {
+		name          string
+		method        string
+		expectedErr   error
+		expectedTotal float64
+		expectedCable float64
+	}{
+		{name: "fifo", method: "fifo", expectedTotal: 30, expectedCable: 10},
+		{name: "default method is fifo", method: "", expectedTotal: 30, expectedCable: 10},
+		{name: "weighted average", method: "weighted_average", expectedTotal: 27.5, expectedCable: 7.5},
+		{name: "invalid method", method: "lifo", expectedErr: domain.ErrInvalidValuationMethod},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			movementRepo := stockMovementRepositoryMock.NewMockStockMovementRepository(t)
+			costLayerRepo := costLayerRepositoryMock.NewMockCostLayerRepository(t)
+			productRepo := productRepositoryMock.NewMockProductRepository(t)
+			categoryRepo := categoryRepositoryMock.NewMockCategoryRepository(t)
+			if tc.expectedErr == nil {
+				costLayerRepo.On("ListOpening", mock.Anything).Return([]*domain.CostLayer{}, nil)
+				movementRepo.On("ListUntil", mock.Anything, asOf).Return(movements, nil)
+				productRepo.On("ListAll", mock.Anything, true).Return(products, nil)
+				categoryRepo.On("ListAll", mock.Anything, true).Return(categories, nil)
+			}
+
+			usecase := NewValuationUsecase(movementRepo, costLayerRepo, productRepo, categoryRepo)
+			report, err := usecase.GetValuation(context.Background(), tc.method, asOf)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, report)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.InDelta(t, tc.expectedTotal, report.TotalValue, 1e-9)
+			assert.Len(t, report.Categories, 1)
+			electronics := report.Categories[0]
+			assert.Equal(t, "Electronics", electronics.CategoryName)
+			assert.Equal(t, 9, electronics.Quantity)
+			assert.Equal(t, "Adapter", electronics.Products[0].ProductName)
+			assert.Equal(t, "Cable", electronics.Products[1].ProductName)
+			assert.Equal(t, 5, electronics.Products[1].Quantity)
+			assert.InDelta(t, tc.expectedCable, electronics.Products[1].TotalValue, 1e-9)
+		})
+	}
+}
+
+func TestGetValuationIncludesStockPredatingTheLedger(t *testing.T) {
+	day := func(month time.Month, d int) time.Time { return time.Date(2024, month, d, 12, 0, 0, 0, time.UTC) }
+	movements := []*domain.StockMovement{
+		{ProductID: 1, MovementType: domain.MovementTypeReceipt, Quantity: 10, UnitCost: floatPtr(1), CreatedAt: day(time.December, 1)},
+		{ProductID: 1, MovementType: domain.MovementTypeIssue, Quantity: 4, CreatedAt: day(time.December, 5)},
+		{ProductID: 1, MovementType: domain.MovementTypeReceipt, Quantity: 2, UnitCost: floatPtr(3), CreatedAt: day(time.December, 20)},
+	}
+	// The movements recorded before cost tracking explain 6 of the 9 units
+	// product 1 then held; none explain the stock of product 2.
+	openings := []*domain.CostLayer{
+		{ProductID: 1, Quantity: 9, RemainingQty: 9, ReceivedAt: day(time.December, 10)},
+		{ProductID: 2, Quantity: 5, RemainingQty: 5, ReceivedAt: day(time.November, 20)},
+	}
+	products := []*domain.Product{{ID: 1, Name: "Cable", CategoryID: 10}, {ID: 2, Name: "Adapter", CategoryID: 10}}
+	categories := []*domain.Category{{ID: 10, Name: "Electronics"}}
+
+	tests := []struct {
+		name          string
+		asOf          time.Time
+		until         time.Time
+		cableQuantity int
+		cableValue    float64
+	}{
+		// FIFO issues the 3 opening units and 1 received at 1 first.
+		{name: "after cost tracking started", asOf: day(time.December, 31), until: day(time.December, 31), cableQuantity: 11, cableValue: 15},
+		{name: "before cost tracking started", asOf: day(time.December, 6), until: day(time.December, 10), cableQuantity: 9, cableValue: 9},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			movementRepo := stockMovementRepositoryMock.NewMockStockMovementRepository(t)
+			costLayerRepo := costLayerRepositoryMock.NewMockCostLayerRepository(t)
+			productRepo := productRepositoryMock.NewMockProductRepository(t)
+			categoryRepo := categoryRepositoryMock.NewMockCategoryRepository(t)
+			costLayerRepo.On("ListOpening", mock.Anything).Return(openings, nil)
+			var listed []*domain.StockMovement
+			for _, m := range movements {
+				if !m.CreatedAt.After(tc.until) {
+					listed = append(listed, m)
+				}
+			}
+			movementRepo.On("ListUntil", mock.Anything, tc.until).Return(listed, nil)
+			productRepo.On("ListAll", mock.Anything, true).Return(products, nil)
+			categoryRepo.On("ListAll", mock.Anything, true).Return(categories, nil)
+
+			usecase := NewValuationUsecase(movementRepo, costLayerRepo, productRepo, categoryRepo)
+			report, err := usecase.GetValuation(context.Background(), "fifo", tc.asOf)
+
+			assert.NoError(t, err)
+			assert.Len(t, report.Categories, 1)
+			valued := report.Categories[0].Products
+			assert.Len(t, valued, 2)
+			assert.Equal(t, "Adapter", valued[0].ProductName)
+			assert.Equal(t, 5, valued[0].Quantity)
+			assert.Zero(t, valued[0].TotalValue)
+			assert.Equal(t, "Cable", valued[1].ProductName)
+			assert.Equal(t, tc.cableQuantity, valued[1].Quantity)
+			assert.InDelta(t, tc.cableValue, valued[1].TotalValue, 1e-9)
+		})
+	}
+}
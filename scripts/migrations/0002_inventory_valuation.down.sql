@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS cost_layers;
+DROP INDEX IF EXISTS idx_stock_movements_created_at;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS unit_cost;
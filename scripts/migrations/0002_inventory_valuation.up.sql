@@ -0,0 +1,23 @@
+ALTER TABLE stock_movements ADD COLUMN unit_cost NUMERIC(12,4);
+
+CREATE INDEX idx_stock_movements_created_at ON stock_movements (created_at, id);
+
+CREATE TABLE cost_layers (
+    id SERIAL PRIMARY KEY,
+    product_id INT NOT NULL REFERENCES products(id),
+    movement_id INT REFERENCES stock_movements(id),
+    quantity INT NOT NULL,
+    remaining_qty INT NOT NULL,
+    unit_cost NUMERIC(12,4) NOT NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (remaining_qty >= 0 AND remaining_qty <= quantity)
+);
+
+CREATE INDEX idx_cost_layers_open ON cost_layers (product_id, received_at, id) WHERE remaining_qty > 0;
+
+-- Stock that predates cost tracking is carried at zero cost so issues can
+-- still consume it.
+INSERT INTO cost_layers (product_id, quantity, remaining_qty, unit_cost, received_at)
+SELECT product_id, quantity, quantity, 0, COALESCE(updated_at, NOW())
+FROM stock_levels
+WHERE quantity > 0;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_products_category_id;
+DROP INDEX IF EXISTS idx_categories_parent_id;
+ALTER TABLE categories
+    DROP CONSTRAINT IF EXISTS chk_categories_parent_not_self,
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,7 @@
+ALTER TABLE categories
+    ADD COLUMN parent_id INT REFERENCES categories(id),
+    ADD CONSTRAINT chk_categories_parent_not_self CHECK (parent_id <> id);
+
+CREATE INDEX idx_categories_parent_id ON categories (parent_id);
+
+CREATE INDEX idx_products_category_id ON products (category_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_categories_deleted_at;
+DROP INDEX IF EXISTS uq_categories_name_active;
+DELETE FROM categories WHERE deleted_at IS NOT NULL;
+ALTER TABLE categories ADD CONSTRAINT categories_name_key UNIQUE (name);
+ALTER TABLE categories DROP COLUMN IF EXISTS deleted_at;
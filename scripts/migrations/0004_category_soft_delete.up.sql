@@ -0,0 +1,7 @@
+ALTER TABLE categories ADD COLUMN deleted_at TIMESTAMP;
+
+-- Soft-deleted categories must not hold on to their name.
+ALTER TABLE categories DROP CONSTRAINT categories_name_key;
+CREATE UNIQUE INDEX uq_categories_name_active ON categories (name) WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_categories_deleted_at ON categories (deleted_at);
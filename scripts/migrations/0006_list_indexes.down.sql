@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_stock_movements_product_created_at;
+DROP INDEX IF EXISTS idx_products_created_at;
+DROP INDEX IF EXISTS idx_products_name;
+DROP INDEX IF EXISTS idx_categories_created_at;
@@ -0,0 +1,4 @@
+CREATE INDEX idx_categories_created_at ON categories (created_at, id);
+CREATE INDEX idx_products_name ON products (name, id);
+CREATE INDEX idx_products_created_at ON products (created_at, id);
+CREATE INDEX idx_stock_movements_product_created_at ON stock_movements (product_id, created_at, id);
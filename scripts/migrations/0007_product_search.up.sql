@@ -0,0 +1,9 @@
+-- The 'simple' configuration lowercases words without stemming, which keeps
+-- prefix matching predictable for product names in any language.
+ALTER TABLE products ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX idx_products_search_vector ON products USING GIN (search_vector);
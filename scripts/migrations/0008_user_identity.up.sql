@@ -0,0 +1,6 @@
+-- Users are provisioned from the sub claim of their access token. The column
+-- is renamed to the name GORM derives from User.UserName.
+ALTER TABLE users RENAME COLUMN username TO user_name;
+ALTER TABLE users ADD COLUMN subject VARCHAR(255);
+
+CREATE UNIQUE INDEX idx_users_subject ON users (subject);
@@ -0,0 +1,17 @@
+-- API keys of other services. Only the SHA-256 of the secret part of a key is
+-- stored; prefix is the public part used to find it.
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(32) NOT NULL,
+    secret_hash CHAR(64) NOT NULL,
+    scopes TEXT NOT NULL DEFAULT '',
+    created_by UUID REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMP,
+    rotated_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_api_keys_prefix ON api_keys (prefix);
@@ -0,0 +1,31 @@
+-- Recreating the global unique index on category names fails when two
+-- tenants use the same name; rename one of them first.
+DROP INDEX IF EXISTS idx_api_keys_tenant_id;
+DROP INDEX IF EXISTS idx_stock_movements_created_at;
+DROP INDEX IF EXISTS idx_products_created_at;
+DROP INDEX IF EXISTS idx_products_name;
+DROP INDEX IF EXISTS idx_categories_created_at;
+CREATE INDEX idx_categories_created_at ON categories (created_at, id);
+CREATE INDEX idx_products_name ON products (name, id);
+CREATE INDEX idx_products_created_at ON products (created_at, id);
+CREATE INDEX idx_stock_movements_created_at ON stock_movements (created_at, id);
+
+ALTER TABLE cost_layers DROP CONSTRAINT IF EXISTS fk_cost_layers_product_tenant;
+ALTER TABLE stock_reservations DROP CONSTRAINT IF EXISTS fk_stock_reservations_product_tenant;
+ALTER TABLE stock_movements DROP CONSTRAINT IF EXISTS fk_stock_movements_product_tenant;
+ALTER TABLE stock_levels DROP CONSTRAINT IF EXISTS fk_stock_levels_product_tenant;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS fk_products_category_tenant;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS fk_categories_parent_tenant;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS uq_products_tenant_id;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS uq_categories_tenant_id;
+
+DROP INDEX IF EXISTS uq_categories_name_active;
+CREATE UNIQUE INDEX uq_categories_name_active ON categories (name) WHERE deleted_at IS NULL;
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE cost_layers DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE stock_reservations DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE stock_levels DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE products DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE categories DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,51 @@
+-- Every seller is a tenant with its own categories, products and stock. Rows
+-- that predate tenancy belong to the 'default' tenant; new rows must name
+-- theirs, so the default is dropped once they are filled.
+ALTER TABLE categories ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE products ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE stock_levels ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE stock_movements ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE stock_reservations ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE cost_layers ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE api_keys ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+
+ALTER TABLE categories ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE products ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE stock_levels ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE stock_movements ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE stock_reservations ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE cost_layers ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE api_keys ALTER COLUMN tenant_id DROP DEFAULT;
+
+-- Category names are unique within a tenant only.
+DROP INDEX uq_categories_name_active;
+CREATE UNIQUE INDEX uq_categories_name_active ON categories (tenant_id, name) WHERE deleted_at IS NULL;
+
+-- References carry the tenant, so that a row can never point at a row of
+-- another tenant.
+ALTER TABLE categories ADD CONSTRAINT uq_categories_tenant_id UNIQUE (tenant_id, id);
+ALTER TABLE products ADD CONSTRAINT uq_products_tenant_id UNIQUE (tenant_id, id);
+
+ALTER TABLE categories ADD CONSTRAINT fk_categories_parent_tenant
+    FOREIGN KEY (tenant_id, parent_id) REFERENCES categories (tenant_id, id);
+ALTER TABLE products ADD CONSTRAINT fk_products_category_tenant
+    FOREIGN KEY (tenant_id, category_id) REFERENCES categories (tenant_id, id);
+ALTER TABLE stock_levels ADD CONSTRAINT fk_stock_levels_product_tenant
+    FOREIGN KEY (tenant_id, product_id) REFERENCES products (tenant_id, id);
+ALTER TABLE stock_movements ADD CONSTRAINT fk_stock_movements_product_tenant
+    FOREIGN KEY (tenant_id, product_id) REFERENCES products (tenant_id, id);
+ALTER TABLE stock_reservations ADD CONSTRAINT fk_stock_reservations_product_tenant
+    FOREIGN KEY (tenant_id, product_id) REFERENCES products (tenant_id, id);
+ALTER TABLE cost_layers ADD CONSTRAINT fk_cost_layers_product_tenant
+    FOREIGN KEY (tenant_id, product_id) REFERENCES products (tenant_id, id);
+
+-- List and report queries always filter on the tenant first.
+DROP INDEX idx_categories_created_at;
+DROP INDEX idx_products_name;
+DROP INDEX idx_products_created_at;
+DROP INDEX idx_stock_movements_created_at;
+CREATE INDEX idx_categories_created_at ON categories (tenant_id, created_at, id);
+CREATE INDEX idx_products_name ON products (tenant_id, name, id);
+CREATE INDEX idx_products_created_at ON products (tenant_id, created_at, id);
+CREATE INDEX idx_stock_movements_created_at ON stock_movements (tenant_id, created_at, id);
+CREATE INDEX idx_api_keys_tenant_id ON api_keys (tenant_id);
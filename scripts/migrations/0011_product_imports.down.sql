@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS import_jobs;
+DROP INDEX IF EXISTS uq_products_sku_active;
+ALTER TABLE products DROP COLUMN IF EXISTS sku;
@@ -0,0 +1,29 @@
+-- Products imported in bulk are identified by a SKU, unique within the
+-- tenant among products that are not deleted. Older products have none.
+ALTER TABLE products ADD COLUMN sku VARCHAR(64);
+CREATE UNIQUE INDEX uq_products_sku_active ON products (tenant_id, sku) WHERE sku IS NOT NULL AND deleted_at IS NULL;
+
+-- Bulk imports run in the background. The uploaded file is kept in payload
+-- until the job finishes.
+CREATE TABLE import_jobs (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(64) NOT NULL,
+    format VARCHAR(10) NOT NULL,
+    dry_run BOOLEAN NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    payload BYTEA,
+    total_rows INT NOT NULL DEFAULT 0,
+    processed_rows INT NOT NULL DEFAULT 0,
+    created_products INT NOT NULL DEFAULT 0,
+    created_categories INT NOT NULL DEFAULT 0,
+    errors JSONB NOT NULL DEFAULT '[]',
+    error_count INT NOT NULL DEFAULT 0,
+    failure TEXT NOT NULL DEFAULT '',
+    user_id UUID REFERENCES users(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+-- The worker claims the oldest pending job of any tenant.
+CREATE INDEX idx_import_jobs_status ON import_jobs (status, created_at);
@@ -0,0 +1,10 @@
+// Package migrations embeds the versioned SQL migrations of the inventory
+// database, so that the API binary can apply them itself.
+package migrations
+
+import "embed"
+
+// FS holds the NNNN_name.up.sql and NNNN_name.down.sql files.
+//
+//go:embed *.sql
+var FS embed.FS